@@ -14,6 +14,7 @@ import (
 
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 	"github.com/3scale/3scale-operator/pkg/apis"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/controller"
 	"github.com/3scale/3scale-operator/version"
 	"github.com/prometheus/client_golang/prometheus"
@@ -43,6 +44,7 @@ var (
 	metricsHost               = "0.0.0.0"
 	metricsPort         int32 = 8383
 	operatorMetricsPort int32 = 8686
+	webhookPort               = 9443
 )
 var log = logf.Log.WithName("cmd")
 
@@ -101,6 +103,7 @@ func main() {
 	mgr, err := manager.New(cfg, manager.Options{
 		Namespace:          namespace,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
+		Port:               webhookPort,
 	})
 	if err != nil {
 		log.Error(err, "")
@@ -139,6 +142,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup admission webhooks. They require the serving certificates to be
+	// provisioned, i.e. by OLM, so they are only enabled on demand
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err := (&appsv1alpha1.APIManager{}).SetupWebhookWithManager(mgr); err != nil {
+			log.Error(err, "unable to create webhook", "webhook", "APIManager")
+			os.Exit(1)
+		}
+	}
+
 	register3scaleVersionInfoMetric()
 
 	// Add the Metrics Service
@@ -0,0 +1,119 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	exportAdminPortalURL        string
+	exportAccessToken           string
+	exportCABundleFile          string
+	exportInsecureSkipVerify    bool
+	exportBindingName           string
+	exportCredentialsSecretName string
+	exportServices              []string
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the services of a 3scale account as capabilities objects",
+	Long: `Export reads the services of a 3scale account from its admin portal and prints
+the API, Metric, MappingRule, Policy, Plan, Limit and Binding objects describing them
+as YAML, ready to be applied in a namespace. For example:
+
+  generator export --admin-portal-url https://3scale-admin.example.com --access-token <token> --credentials-secret <secret> | oc apply -f -
+
+The Binding references the credentials secret, which has to exist in the namespace.`,
+	Args: cobra.NoArgs,
+	Run:  runExportCommand,
+}
+
+func runExportCommand(cmd *cobra.Command, args []string) {
+	var caBundle []byte
+	if exportCABundleFile != "" {
+		var err error
+		caBundle, err = ioutil.ReadFile(exportCABundleFile)
+		check(err)
+	}
+
+	tlsConfig, err := helper.TLSConfig(caBundle, exportInsecureSkipVerify)
+	check(err)
+
+	c, err := helper.PortaClientFromURLString(exportAdminPortalURL, exportAccessToken, tlsConfig)
+	check(err)
+
+	objects, warnings, err := capabilitiesv1alpha1.Export(c, capabilitiesv1alpha1.ExportOptions{
+		BindingName:           exportBindingName,
+		CredentialsSecretName: exportCredentialsSecretName,
+		ServiceNames:          exportServices,
+	})
+	check(err)
+
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	check(printExportedObjects(os.Stdout, objects))
+}
+
+// printExportedObjects prints the objects as a multi-document YAML stream,
+// without status and server populated metadata
+func printExportedObjects(w io.Writer, objects []runtime.Object) error {
+	for _, object := range objects {
+		unstructuredObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return err
+		}
+		delete(unstructuredObject, "status")
+		if metadata, ok := unstructuredObject["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "creationTimestamp")
+		}
+
+		document, err := yaml.Marshal(unstructuredObject)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "---\n%s", document)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportAdminPortalURL, "admin-portal-url", "", "URL of the 3scale admin portal")
+	exportCmd.Flags().StringVar(&exportAccessToken, "access-token", "", "Access token of the 3scale admin portal")
+	exportCmd.Flags().StringVar(&exportCABundleFile, "ca-bundle", "", "File with the PEM encoded CA certificates trusted to verify the admin portal")
+	exportCmd.Flags().BoolVar(&exportInsecureSkipVerify, "insecure-skip-verify", false, "Skip the verification of the admin portal certificate")
+	exportCmd.Flags().StringVar(&exportBindingName, "binding-name", "binding", "Name of the exported Binding")
+	exportCmd.Flags().StringVar(&exportCredentialsSecretName, "credentials-secret", "", "Secret with the tenant credentials referenced by the Binding")
+	exportCmd.Flags().StringSliceVar(&exportServices, "service", nil, "System name of a service to export, can be repeated. All services by default")
+	exportCmd.MarkFlagRequired("admin-portal-url")
+	exportCmd.MarkFlagRequired("access-token")
+	exportCmd.MarkFlagRequired("credentials-secret")
+}
@@ -2,6 +2,7 @@ package component
 
 import (
 	"github.com/3scale/3scale-operator/pkg/common"
+	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/api/policy/v1beta1"
 
 	appsv1 "github.com/openshift/api/apps/v1"
@@ -219,6 +220,10 @@ func (apicast *Apicast) StagingDeploymentConfig() *appsv1.DeploymentConfig {
 							},
 						},
 					},
+					NodeSelector:      apicast.Options.stagingPodPlacement.NodeSelector,
+					Tolerations:       apicast.Options.stagingPodPlacement.Tolerations,
+					Affinity:          apicast.Options.stagingPodPlacement.Affinity,
+					PriorityClassName: apicast.Options.stagingPodPlacement.PriorityClassName,
 				},
 			},
 		},
@@ -346,6 +351,10 @@ func (apicast *Apicast) ProductionDeploymentConfig() *appsv1.DeploymentConfig {
 							},
 						},
 					},
+					NodeSelector:      apicast.Options.productionPodPlacement.NodeSelector,
+					Tolerations:       apicast.Options.productionPodPlacement.Tolerations,
+					Affinity:          apicast.Options.productionPodPlacement.Affinity,
+					PriorityClassName: apicast.Options.productionPodPlacement.PriorityClassName,
 				},
 			},
 		},
@@ -425,6 +434,14 @@ func (apicast *Apicast) StagingPodDisruptionBudget() *v1beta1.PodDisruptionBudge
 	}
 }
 
+func (apicast *Apicast) ProductionHorizontalPodAutoscaler() *v2beta2.HorizontalPodAutoscaler {
+	return horizontalPodAutoscaler("apicast-production", map[string]string{
+		"app":                          apicast.Options.appLabel,
+		"threescale_component":         "apicast",
+		"threescale_component_element": "production",
+	}, apicast.Options.productionAutoscaling)
+}
+
 func (apicast *Apicast) ProductionPodDisruptionBudget() *v1beta1.PodDisruptionBudget {
 	return &v1beta1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
@@ -21,6 +21,9 @@ type ApicastOptions struct {
 	stagingResourceRequirements    *v1.ResourceRequirements
 	productionReplicas             *int32
 	stagingReplicas                *int32
+	productionPodPlacement         *PodPlacement
+	stagingPodPlacement            *PodPlacement
+	productionAutoscaling          *Autoscaling
 }
 
 type ApicastOptionsBuilder struct {
@@ -67,6 +70,18 @@ func (a *ApicastOptionsBuilder) ProductionReplicas(replicas int32) {
 	a.options.productionReplicas = &replicas
 }
 
+func (a *ApicastOptionsBuilder) ProductionPodPlacement(podPlacement PodPlacement) {
+	a.options.productionPodPlacement = &podPlacement
+}
+
+func (a *ApicastOptionsBuilder) StagingPodPlacement(podPlacement PodPlacement) {
+	a.options.stagingPodPlacement = &podPlacement
+}
+
+func (a *ApicastOptionsBuilder) ProductionAutoscaling(autoscaling Autoscaling) {
+	a.options.productionAutoscaling = &autoscaling
+}
+
 func (a *ApicastOptionsBuilder) Build() (*ApicastOptions, error) {
 	err := a.setRequiredOptions()
 	if err != nil {
@@ -119,6 +134,21 @@ func (a *ApicastOptionsBuilder) setNonRequiredOptions() {
 		var defaultProductionReplicas int32 = 1
 		a.options.productionReplicas = &defaultProductionReplicas
 	}
+
+	if a.options.productionPodPlacement == nil {
+		a.options.productionPodPlacement = &PodPlacement{}
+	}
+
+	if a.options.stagingPodPlacement == nil {
+		a.options.stagingPodPlacement = &PodPlacement{}
+	}
+
+	if a.options.productionAutoscaling == nil {
+		a.options.productionAutoscaling = &Autoscaling{
+			MinReplicas: *a.options.productionReplicas,
+			MaxReplicas: *a.options.productionReplicas,
+		}
+	}
 }
 
 func (a *ApicastOptionsBuilder) defaultProductionResourceRequirements() *v1.ResourceRequirements {
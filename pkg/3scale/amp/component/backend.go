@@ -2,6 +2,7 @@ package component
 
 import (
 	"github.com/3scale/3scale-operator/pkg/common"
+	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/api/policy/v1beta1"
 
 	appsv1 "github.com/openshift/api/apps/v1"
@@ -12,19 +13,26 @@ import (
 )
 
 const (
-	BackendSecretBackendRedisSecretName                    = "backend-redis"
-	BackendSecretBackendRedisStorageURLFieldName           = "REDIS_STORAGE_URL"
-	BackendSecretBackendRedisQueuesURLFieldName            = "REDIS_QUEUES_URL"
-	BackendSecretBackendRedisStorageSentinelHostsFieldName = "REDIS_STORAGE_SENTINEL_HOSTS"
-	BackendSecretBackendRedisStorageSentinelRoleFieldName  = "REDIS_STORAGE_SENTINEL_ROLE"
-	BackendSecretBackendRedisQueuesSentinelHostsFieldName  = "REDIS_QUEUES_SENTINEL_HOSTS"
-	BackendSecretBackendRedisQueuesSentinelRoleFieldName   = "REDIS_QUEUES_SENTINEL_ROLE"
+	BackendSecretBackendRedisSecretName                       = "backend-redis"
+	BackendSecretBackendRedisStorageURLFieldName              = "REDIS_STORAGE_URL"
+	BackendSecretBackendRedisQueuesURLFieldName               = "REDIS_QUEUES_URL"
+	BackendSecretBackendRedisStorageSentinelHostsFieldName    = "REDIS_STORAGE_SENTINEL_HOSTS"
+	BackendSecretBackendRedisStorageSentinelRoleFieldName     = "REDIS_STORAGE_SENTINEL_ROLE"
+	BackendSecretBackendRedisQueuesSentinelHostsFieldName     = "REDIS_QUEUES_SENTINEL_HOSTS"
+	BackendSecretBackendRedisQueuesSentinelRoleFieldName      = "REDIS_QUEUES_SENTINEL_ROLE"
+	BackendSecretBackendRedisStorageSentinelPasswordFieldName = "REDIS_STORAGE_SENTINEL_PASSWORD"
+	BackendSecretBackendRedisQueuesSentinelPasswordFieldName  = "REDIS_QUEUES_SENTINEL_PASSWORD"
+	BackendSecretBackendRedisStorageSSLCAFieldName            = "REDIS_STORAGE_SSL_CA"
+	BackendSecretBackendRedisQueuesSSLCAFieldName             = "REDIS_QUEUES_SSL_CA"
 )
 
 const (
 	BackendSecretInternalApiSecretName        = "backend-internal-api"
 	BackendSecretInternalApiUsernameFieldName = "username"
 	BackendSecretInternalApiPasswordFieldName = "password"
+	// BackendSecretInternalApiPreviousPasswordFieldName keeps the replaced password
+	// while a credential rotation is in progress, so backend accepts both
+	BackendSecretInternalApiPreviousPasswordFieldName = "previous_password"
 )
 
 const (
@@ -133,6 +141,7 @@ func (backend *Backend) WorkerDeploymentConfig() *appsv1.DeploymentConfig {
 								"-c",
 								"until rake connectivity:redis_storage_queue_check; do sleep $SLEEP_SECONDS; done",
 							}, Env: append(backend.buildBackendCommonEnv(), envVarFromValue("SLEEP_SECONDS", "1")),
+							VolumeMounts: redisTLSVolumeMounts(backend.redisCAFiles()),
 						},
 					},
 					Containers: []v1.Container{
@@ -142,10 +151,17 @@ func (backend *Backend) WorkerDeploymentConfig() *appsv1.DeploymentConfig {
 							Args:            []string{"bin/3scale_backend_worker", "run"},
 							Env:             backend.buildBackendWorkerEnv(),
 							Resources:       *backend.Options.workerResourceRequirements,
+							VolumeMounts:    redisTLSVolumeMounts(backend.redisCAFiles()),
 							ImagePullPolicy: v1.PullIfNotPresent,
 						},
 					},
-					ServiceAccountName: "amp"}},
+					Volumes:            redisTLSVolumes(backend.redisCAFiles()),
+					ServiceAccountName: "amp",
+					NodeSelector:       backend.Options.workerPodPlacement.NodeSelector,
+					Tolerations:        backend.Options.workerPodPlacement.Tolerations,
+					Affinity:           backend.Options.workerPodPlacement.Affinity,
+					PriorityClassName:  backend.Options.workerPodPlacement.PriorityClassName,
+				}},
 		},
 	}
 }
@@ -205,6 +221,7 @@ func (backend *Backend) CronDeploymentConfig() *appsv1.DeploymentConfig {
 								"-c",
 								"until rake connectivity:redis_storage_queue_check; do sleep $SLEEP_SECONDS; done",
 							}, Env: append(backend.buildBackendCommonEnv(), envVarFromValue("SLEEP_SECONDS", "1")),
+							VolumeMounts: redisTLSVolumeMounts(backend.redisCAFiles()),
 						},
 					},
 					Containers: []v1.Container{
@@ -214,10 +231,16 @@ func (backend *Backend) CronDeploymentConfig() *appsv1.DeploymentConfig {
 							Args:            []string{"backend-cron"},
 							Env:             backend.buildBackendCronEnv(),
 							Resources:       *backend.Options.cronResourceRequirements,
+							VolumeMounts:    redisTLSVolumeMounts(backend.redisCAFiles()),
 							ImagePullPolicy: v1.PullIfNotPresent,
 						},
 					},
+					Volumes:            redisTLSVolumes(backend.redisCAFiles()),
 					ServiceAccountName: "amp",
+					NodeSelector:       backend.Options.cronPodPlacement.NodeSelector,
+					Tolerations:        backend.Options.cronPodPlacement.Tolerations,
+					Affinity:           backend.Options.cronPodPlacement.Affinity,
+					PriorityClassName:  backend.Options.cronPodPlacement.PriorityClassName,
 				}},
 		},
 	}
@@ -277,8 +300,9 @@ func (backend *Backend) ListenerDeploymentConfig() *appsv1.DeploymentConfig {
 								ContainerPort: 3000,
 								Protocol:      v1.ProtocolTCP},
 						},
-						Env:       backend.buildBackendListenerEnv(),
-						Resources: *backend.Options.listenerResourceRequirements,
+						Env:          backend.buildBackendListenerEnv(),
+						Resources:    *backend.Options.listenerResourceRequirements,
+						VolumeMounts: redisTLSVolumeMounts(backend.redisCAFiles()),
 						LivenessProbe: &v1.Probe{
 							Handler: v1.Handler{TCPSocket: &v1.TCPSocketAction{
 								Port: intstr.IntOrString{
@@ -307,7 +331,12 @@ func (backend *Backend) ListenerDeploymentConfig() *appsv1.DeploymentConfig {
 						ImagePullPolicy: v1.PullIfNotPresent,
 					},
 				},
+					Volumes:            redisTLSVolumes(backend.redisCAFiles()),
 					ServiceAccountName: "amp",
+					NodeSelector:       backend.Options.listenerPodPlacement.NodeSelector,
+					Tolerations:        backend.Options.listenerPodPlacement.Tolerations,
+					Affinity:           backend.Options.listenerPodPlacement.Affinity,
+					PriorityClassName:  backend.Options.listenerPodPlacement.PriorityClassName,
 				}},
 		},
 	}
@@ -400,27 +429,57 @@ func (backend *Backend) RedisSecret() *v1.Secret {
 			},
 		},
 		StringData: map[string]string{
-			BackendSecretBackendRedisStorageURLFieldName:           *backend.Options.storageURL,
-			BackendSecretBackendRedisQueuesURLFieldName:            *backend.Options.queuesURL,
-			BackendSecretBackendRedisStorageSentinelHostsFieldName: *backend.Options.storageSentinelHosts,
-			BackendSecretBackendRedisStorageSentinelRoleFieldName:  *backend.Options.storageSentinelRole,
-			BackendSecretBackendRedisQueuesSentinelHostsFieldName:  *backend.Options.queuesSentinelHosts,
-			BackendSecretBackendRedisQueuesSentinelRoleFieldName:   *backend.Options.queuesSentinelRole,
+			BackendSecretBackendRedisStorageURLFieldName:              *backend.Options.storageURL,
+			BackendSecretBackendRedisQueuesURLFieldName:               *backend.Options.queuesURL,
+			BackendSecretBackendRedisStorageSentinelHostsFieldName:    *backend.Options.storageSentinelHosts,
+			BackendSecretBackendRedisStorageSentinelRoleFieldName:     *backend.Options.storageSentinelRole,
+			BackendSecretBackendRedisQueuesSentinelHostsFieldName:     *backend.Options.queuesSentinelHosts,
+			BackendSecretBackendRedisQueuesSentinelRoleFieldName:      *backend.Options.queuesSentinelRole,
+			BackendSecretBackendRedisStorageSentinelPasswordFieldName: *backend.Options.storageSentinelPassword,
+			BackendSecretBackendRedisQueuesSentinelPasswordFieldName:  *backend.Options.queuesSentinelPassword,
+			BackendSecretBackendRedisStorageSSLCAFieldName:            *backend.Options.storageSSLCA,
+			BackendSecretBackendRedisQueuesSSLCAFieldName:             *backend.Options.queuesSSLCA,
 		},
 		Type: v1.SecretTypeOpaque,
 	}
 }
 
 func (backend *Backend) buildBackendCommonEnv() []v1.EnvVar {
-	return []v1.EnvVar{
+	result := []v1.EnvVar{
 		envVarFromSecret("CONFIG_REDIS_PROXY", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageURLFieldName),
 		envVarFromSecret("CONFIG_REDIS_SENTINEL_HOSTS", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelHostsFieldName),
 		envVarFromSecret("CONFIG_REDIS_SENTINEL_ROLE", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelRoleFieldName),
+		envVarFromSecretOptional("CONFIG_REDIS_SENTINEL_PASSWORD", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelPasswordFieldName),
 		envVarFromSecret("CONFIG_QUEUES_MASTER_NAME", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisQueuesURLFieldName),
 		envVarFromSecret("CONFIG_QUEUES_SENTINEL_HOSTS", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisQueuesSentinelHostsFieldName),
 		envVarFromSecret("CONFIG_QUEUES_SENTINEL_ROLE", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisQueuesSentinelRoleFieldName),
+		envVarFromSecretOptional("CONFIG_QUEUES_SENTINEL_PASSWORD", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisQueuesSentinelPasswordFieldName),
 		envVarFromConfigMap("RACK_ENV", "backend-environment", "RACK_ENV"),
 	}
+	result = append(result, redisTLSEnvVars(backend.redisCAFiles())...)
+	return result
+}
+
+// redisCAFiles returns the CA bundles of the backend redis TLS connections
+func (backend *Backend) redisCAFiles() []redisCAFile {
+	result := []redisCAFile{}
+	if *backend.Options.storageSSLCA != "" {
+		result = append(result, redisCAFile{
+			envVar:     "CONFIG_REDIS_CA_FILE",
+			secretName: BackendSecretBackendRedisSecretName,
+			secretKey:  BackendSecretBackendRedisStorageSSLCAFieldName,
+			fileName:   "backend-redis-storage-ca.crt",
+		})
+	}
+	if *backend.Options.queuesSSLCA != "" {
+		result = append(result, redisCAFile{
+			envVar:     "CONFIG_QUEUES_CA_FILE",
+			secretName: BackendSecretBackendRedisSecretName,
+			secretKey:  BackendSecretBackendRedisQueuesSSLCAFieldName,
+			fileName:   "backend-redis-queues-ca.crt",
+		})
+	}
+	return result
 }
 
 func (backend *Backend) buildBackendWorkerEnv() []v1.EnvVar {
@@ -446,6 +505,7 @@ func (backend *Backend) buildBackendListenerEnv() []v1.EnvVar {
 		envVarFromValue("PUMA_WORKERS", "16"),
 		envVarFromSecret("CONFIG_INTERNAL_API_USER", BackendSecretInternalApiSecretName, BackendSecretInternalApiUsernameFieldName),
 		envVarFromSecret("CONFIG_INTERNAL_API_PASSWORD", BackendSecretInternalApiSecretName, BackendSecretInternalApiPasswordFieldName),
+		envVarFromSecretOptional("CONFIG_INTERNAL_API_PREVIOUS_PASSWORD", BackendSecretInternalApiSecretName, BackendSecretInternalApiPreviousPasswordFieldName),
 	)
 	return result
 }
@@ -538,6 +598,14 @@ func (backend *Backend) CronPodDisruptionBudget() *v1beta1.PodDisruptionBudget {
 	}
 }
 
+func (backend *Backend) ListenerHorizontalPodAutoscaler() *v2beta2.HorizontalPodAutoscaler {
+	return horizontalPodAutoscaler("backend-listener", map[string]string{
+		"app":                          backend.Options.appLabel,
+		"threescale_component":         "backend",
+		"threescale_component_element": "listener",
+	}, backend.Options.listenerAutoscaling)
+}
+
 func (backend *Backend) ListenerPodDisruptionBudget() *v1beta1.PodDisruptionBudget {
 	return &v1beta1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
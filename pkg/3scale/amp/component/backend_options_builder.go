@@ -17,12 +17,20 @@ type BackendOptions struct {
 	storageSentinelRole          *string
 	queuesSentinelHosts          *string
 	queuesSentinelRole           *string
+	storageSentinelPassword      *string
+	queuesSentinelPassword       *string
+	storageSSLCA                 *string
+	queuesSSLCA                  *string
 	listenerResourceRequirements *v1.ResourceRequirements
 	workerResourceRequirements   *v1.ResourceRequirements
 	cronResourceRequirements     *v1.ResourceRequirements
 	listenerReplicas             *int32
 	workerReplicas               *int32
 	cronReplicas                 *int32
+	listenerPodPlacement         *PodPlacement
+	workerPodPlacement           *PodPlacement
+	cronPodPlacement             *PodPlacement
+	listenerAutoscaling          *Autoscaling
 
 	// required Options
 	appLabel              string
@@ -88,6 +96,22 @@ func (m *BackendOptionsBuilder) RedisQueuesSentinelRole(role *string) {
 	m.options.queuesSentinelRole = role
 }
 
+func (m *BackendOptionsBuilder) RedisStorageSentinelPassword(password *string) {
+	m.options.storageSentinelPassword = password
+}
+
+func (m *BackendOptionsBuilder) RedisQueuesSentinelPassword(password *string) {
+	m.options.queuesSentinelPassword = password
+}
+
+func (m *BackendOptionsBuilder) RedisStorageSSLCA(ca *string) {
+	m.options.storageSSLCA = ca
+}
+
+func (m *BackendOptionsBuilder) RedisQueuesSSLCA(ca *string) {
+	m.options.queuesSSLCA = ca
+}
+
 func (m *BackendOptionsBuilder) ListenerResourceRequirements(resourceRequirements v1.ResourceRequirements) {
 	m.options.listenerResourceRequirements = &resourceRequirements
 }
@@ -112,6 +136,22 @@ func (m *BackendOptionsBuilder) CronReplicas(replicas int32) {
 	m.options.cronReplicas = &replicas
 }
 
+func (m *BackendOptionsBuilder) ListenerPodPlacement(podPlacement PodPlacement) {
+	m.options.listenerPodPlacement = &podPlacement
+}
+
+func (m *BackendOptionsBuilder) WorkerPodPlacement(podPlacement PodPlacement) {
+	m.options.workerPodPlacement = &podPlacement
+}
+
+func (m *BackendOptionsBuilder) CronPodPlacement(podPlacement PodPlacement) {
+	m.options.cronPodPlacement = &podPlacement
+}
+
+func (m *BackendOptionsBuilder) ListenerAutoscaling(autoscaling Autoscaling) {
+	m.options.listenerAutoscaling = &autoscaling
+}
+
 func (m *BackendOptionsBuilder) Build() (*BackendOptions, error) {
 	err := m.setRequiredOptions()
 	if err != nil {
@@ -154,6 +194,10 @@ func (m *BackendOptionsBuilder) setNonRequiredOptions() {
 	defaultStorageSentinelRole := ""
 	defaultQueuesSentinelHosts := ""
 	defaultQueuesSentinelRole := ""
+	defaultStorageSentinelPassword := ""
+	defaultQueuesSentinelPassword := ""
+	defaultStorageSSLCA := ""
+	defaultQueuesSSLCA := ""
 
 	if m.options.serviceEndpoint == nil {
 		m.options.serviceEndpoint = &defaultServiceEndpoint
@@ -179,6 +223,18 @@ func (m *BackendOptionsBuilder) setNonRequiredOptions() {
 	if m.options.queuesSentinelRole == nil {
 		m.options.queuesSentinelRole = &defaultQueuesSentinelRole
 	}
+	if m.options.storageSentinelPassword == nil {
+		m.options.storageSentinelPassword = &defaultStorageSentinelPassword
+	}
+	if m.options.queuesSentinelPassword == nil {
+		m.options.queuesSentinelPassword = &defaultQueuesSentinelPassword
+	}
+	if m.options.storageSSLCA == nil {
+		m.options.storageSSLCA = &defaultStorageSSLCA
+	}
+	if m.options.queuesSSLCA == nil {
+		m.options.queuesSSLCA = &defaultQueuesSSLCA
+	}
 
 	if m.options.listenerResourceRequirements == nil {
 		m.options.listenerResourceRequirements = m.defaultListenerResourceRequirements()
@@ -206,6 +262,25 @@ func (m *BackendOptionsBuilder) setNonRequiredOptions() {
 		var cronDefaultReplicas int32 = 1
 		m.options.cronReplicas = &cronDefaultReplicas
 	}
+
+	if m.options.listenerPodPlacement == nil {
+		m.options.listenerPodPlacement = &PodPlacement{}
+	}
+
+	if m.options.workerPodPlacement == nil {
+		m.options.workerPodPlacement = &PodPlacement{}
+	}
+
+	if m.options.cronPodPlacement == nil {
+		m.options.cronPodPlacement = &PodPlacement{}
+	}
+
+	if m.options.listenerAutoscaling == nil {
+		m.options.listenerAutoscaling = &Autoscaling{
+			MinReplicas: *m.options.listenerReplicas,
+			MaxReplicas: *m.options.listenerReplicas,
+		}
+	}
 }
 
 func (m *BackendOptionsBuilder) defaultListenerResourceRequirements() *v1.ResourceRequirements {
@@ -0,0 +1,389 @@
+package component
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	BackupSystemDatabaseFileName = "system-database.sql.gz"
+	BackupBackendRedisFileName   = "backend-redis.rdb"
+	BackupSystemRedisFileName    = "system-redis.rdb"
+	BackupSystemStorageFileName  = "system-storage.tar.gz"
+
+	backupVolumeName = "backup"
+	backupMountPath  = "/backup"
+)
+
+// BackupStep is a job run by a backup or a restore
+type BackupStep struct {
+	Name string
+	Job  *batchv1.Job
+	// Names of the DeploymentConfigs that have to be stopped while the job runs
+	StoppedDeploymentNames []string
+}
+
+type Backup struct {
+	Options *BackupOptions
+}
+
+func NewBackup(options *BackupOptions) *Backup {
+	return &Backup{Options: options}
+}
+
+// BackupSecretNames are the secrets stored by a backup and reapplied by a
+// restore
+func BackupSecretNames() []string {
+	return []string{
+		SystemSecretSystemSeedSecretName,
+		SystemSecretSystemAppSecretName,
+		SystemSecretSystemDatabaseSecretName,
+		SystemSecretSystemRedisSecretName,
+		SystemSecretSystemEventsHookSecretName,
+		SystemSecretSystemMasterApicastSecretName,
+		SystemSecretSystemMemcachedSecretName,
+		SystemSecretSystemRecaptchaSecretName,
+		SystemSecretSystemSMTPSecretName,
+		BackendSecretBackendRedisSecretName,
+		BackendSecretInternalApiSecretName,
+		BackendSecretBackendListenerSecretName,
+		ZyncSecretName,
+	}
+}
+
+func (b *Backup) PersistentVolumeClaim() *v1.PersistentVolumeClaim {
+	pvc := b.Options.persistentVolumeClaim
+	if pvc == nil {
+		return nil
+	}
+
+	return &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   pvc.Name,
+			Labels: b.labels("backup"),
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: pvc.StorageClassName,
+			VolumeName:       pvc.VolumeName,
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: pvc.StorageRequest,
+				},
+			},
+		},
+	}
+}
+
+// BackupSteps returns the jobs that back up the system database, the
+// redis databases and the System file storage in that order
+func (b *Backup) BackupSteps() []BackupStep {
+	steps := []BackupStep{}
+
+	if b.Options.systemDatabase != nil {
+		steps = append(steps, BackupStep{
+			Name: "system-database",
+			Job:  b.backupJob("system-database", BackupSystemDatabaseFileName, b.systemDatabaseContainer(b.systemDatabaseBackupScript()), nil),
+		})
+	}
+
+	for _, redis := range b.redises() {
+		steps = append(steps, BackupStep{
+			Name: redis.name,
+			Job:  b.backupJob(redis.name, redis.fileName, b.redisBackupContainer(redis.options, redis.fileName), nil),
+		})
+	}
+
+	if b.Options.systemStoragePVCName != "" {
+		steps = append(steps, BackupStep{
+			Name: "system-storage",
+			Job:  b.backupJob("system-storage", BackupSystemStorageFileName, b.systemStorageContainer(fmt.Sprintf("tar czf %s/%s -C /system-storage .", backupMountPath, BackupSystemStorageFileName), true), b.systemStorageVolumes()),
+		})
+	}
+
+	return steps
+}
+
+// RestoreSteps returns the jobs that restore the system database, the
+// redis databases and the System file storage in that order. The redis
+// DeploymentConfigs have to be stopped while their data is replaced
+func (b *Backup) RestoreSteps() []BackupStep {
+	steps := []BackupStep{}
+
+	if b.Options.systemDatabase != nil {
+		// System must not write to the database while it is restored
+		steps = append(steps, BackupStep{
+			Name:                   "system-database",
+			Job:                    b.restoreJob("system-database", BackupSystemDatabaseFileName, b.systemDatabaseContainer(b.systemDatabaseRestoreScript()), nil),
+			StoppedDeploymentNames: []string{"system-app", "system-sidekiq"},
+		})
+	}
+
+	for _, redis := range b.redises() {
+		steps = append(steps, BackupStep{
+			Name:                   redis.name,
+			Job:                    b.restoreJob(redis.name, redis.fileName, b.redisRestoreContainer(redis.options, redis.fileName), b.redisVolumes(redis.options)),
+			StoppedDeploymentNames: []string{redis.options.DeploymentName},
+		})
+	}
+
+	if b.Options.systemStoragePVCName != "" {
+		steps = append(steps, BackupStep{
+			Name: "system-storage",
+			Job:  b.restoreJob("system-storage", BackupSystemStorageFileName, b.systemStorageContainer(fmt.Sprintf("tar xzf %s/%s -C /system-storage", backupMountPath, BackupSystemStorageFileName), false), b.systemStorageVolumes()),
+		})
+	}
+
+	return steps
+}
+
+type backupRedis struct {
+	name     string
+	fileName string
+	options  *BackupRedis
+}
+
+func (b *Backup) redises() []backupRedis {
+	redises := []backupRedis{}
+	if b.Options.backendRedis != nil {
+		redises = append(redises, backupRedis{name: "backend-redis", fileName: BackupBackendRedisFileName, options: b.Options.backendRedis})
+	}
+	if b.Options.systemRedis != nil {
+		redises = append(redises, backupRedis{name: "system-redis", fileName: BackupSystemRedisFileName, options: b.Options.systemRedis})
+	}
+	return redises
+}
+
+func (b *Backup) labels(component string) map[string]string {
+	return map[string]string{
+		"app":                  b.Options.appLabel,
+		"threescale_component": component,
+	}
+}
+
+// backupJob runs the given container, which writes the given file into the
+// backup volume. When the backup is stored in S3, the container runs as an
+// init container and the file is uploaded afterwards
+func (b *Backup) backupJob(step, fileName string, container v1.Container, volumes []v1.Volume) *batchv1.Job {
+	container.Name = "backup"
+	container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: backupVolumeName, MountPath: backupMountPath})
+
+	podSpec := v1.PodSpec{
+		RestartPolicy: v1.RestartPolicyNever,
+		Volumes:       append(volumes, b.backupVolume(false)),
+	}
+	if b.Options.s3 != nil {
+		podSpec.InitContainers = []v1.Container{container}
+		podSpec.Containers = []v1.Container{
+			b.s3Container("upload", fmt.Sprintf("s3 cp %s/%s \"%s\"", backupMountPath, fileName, b.s3URL(fileName))),
+		}
+	} else {
+		podSpec.Containers = []v1.Container{container}
+	}
+
+	return b.job(fmt.Sprintf("%s-%s-backup", b.Options.name, step), "backup", podSpec)
+}
+
+// restoreJob runs the given container, which reads the given file from the
+// backup volume. When the backup is stored in S3, the file is downloaded by
+// an init container first
+func (b *Backup) restoreJob(step, fileName string, container v1.Container, volumes []v1.Volume) *batchv1.Job {
+	container.Name = "restore"
+	container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: backupVolumeName, MountPath: backupMountPath, ReadOnly: b.Options.s3 == nil})
+
+	podSpec := v1.PodSpec{
+		RestartPolicy: v1.RestartPolicyNever,
+		Volumes:       append(volumes, b.backupVolume(true)),
+		Containers:    []v1.Container{container},
+	}
+	if b.Options.s3 != nil {
+		podSpec.InitContainers = []v1.Container{
+			b.s3Container("download", fmt.Sprintf("s3 cp \"%s\" %s/%s", b.s3URL(fileName), backupMountPath, fileName)),
+		}
+	}
+
+	return b.job(fmt.Sprintf("%s-%s-restore", b.Options.name, step), "restore", podSpec)
+}
+
+func (b *Backup) job(name, component string, podSpec v1.PodSpec) *batchv1.Job {
+	var backoffLimit int32 = 2
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: b.labels(component),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: b.labels(component),
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+func (b *Backup) backupVolume(readOnly bool) v1.Volume {
+	if b.Options.s3 != nil {
+		return v1.Volume{
+			Name:         backupVolumeName,
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		}
+	}
+
+	return v1.Volume{
+		Name: backupVolumeName,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				ClaimName: b.Options.persistentVolumeClaim.Name,
+				ReadOnly:  readOnly,
+			},
+		},
+	}
+}
+
+func (b *Backup) s3URL(fileName string) string {
+	return fmt.Sprintf("s3://${AWS_BUCKET}/%s/%s", b.Options.s3.Path, fileName)
+}
+
+func (b *Backup) s3Container(name, awsArgs string) v1.Container {
+	secretName := b.Options.s3.ConfigurationSecretName
+	return v1.Container{
+		Name:    name,
+		Image:   b.Options.s3Image,
+		Command: []string{"/bin/sh", "-c"},
+		Args: []string{
+			fmt.Sprintf("aws ${AWS_HOSTNAME:+--endpoint-url \"${AWS_PROTOCOL:-https}://${AWS_HOSTNAME}\"} %s", awsArgs),
+		},
+		Env: []v1.EnvVar{
+			envVarFromSecret("AWS_ACCESS_KEY_ID", secretName, AwsAccessKeyID),
+			envVarFromSecret("AWS_SECRET_ACCESS_KEY", secretName, AwsSecretAccessKey),
+			envVarFromSecret("AWS_BUCKET", secretName, AwsBucket),
+			envVarFromSecret("AWS_DEFAULT_REGION", secretName, AwsRegion),
+			envVarFromSecretOptional("AWS_PROTOCOL", secretName, AwsProtocol),
+			envVarFromSecretOptional("AWS_HOSTNAME", secretName, AwsHostname),
+		},
+		VolumeMounts: []v1.VolumeMount{
+			v1.VolumeMount{Name: backupVolumeName, MountPath: backupMountPath},
+		},
+	}
+}
+
+func (b *Backup) systemDatabaseContainer(script string) v1.Container {
+	database := b.Options.systemDatabase
+
+	passwordEnvVarName := "MYSQL_PWD"
+	if database.Type == BackupSystemDatabaseTypePostgreSQL {
+		passwordEnvVarName = "PGPASSWORD"
+	}
+
+	return v1.Container{
+		Image:   database.Image,
+		Command: []string{"/bin/bash", "-c"},
+		Args:    []string{"set -eo pipefail; " + script},
+		Env: []v1.EnvVar{
+			envVarFromValue("DB_HOST", database.Host),
+			envVarFromValue("DB_NAME", database.DatabaseName),
+			envVarFromSecret("DB_USER", SystemSecretSystemDatabaseSecretName, SystemSecretSystemDatabaseUserFieldName),
+			envVarFromSecret(passwordEnvVarName, SystemSecretSystemDatabaseSecretName, SystemSecretSystemDatabasePasswordFieldName),
+		},
+	}
+}
+
+func (b *Backup) systemDatabaseBackupScript() string {
+	if b.Options.systemDatabase.Type == BackupSystemDatabaseTypePostgreSQL {
+		return fmt.Sprintf("pg_dump --clean --if-exists -h \"$DB_HOST\" -U \"$DB_USER\" \"$DB_NAME\" | gzip > %s/%s", backupMountPath, BackupSystemDatabaseFileName)
+	}
+	return fmt.Sprintf("mysqldump --single-transaction --routines --triggers -h \"$DB_HOST\" -u \"$DB_USER\" \"$DB_NAME\" | gzip > %s/%s", backupMountPath, BackupSystemDatabaseFileName)
+}
+
+func (b *Backup) systemDatabaseRestoreScript() string {
+	if b.Options.systemDatabase.Type == BackupSystemDatabaseTypePostgreSQL {
+		return fmt.Sprintf("gunzip -c %s/%s | psql -v ON_ERROR_STOP=1 -h \"$DB_HOST\" -U \"$DB_USER\" \"$DB_NAME\"", backupMountPath, BackupSystemDatabaseFileName)
+	}
+	return fmt.Sprintf("gunzip -c %s/%s | mysql -h \"$DB_HOST\" -u \"$DB_USER\" \"$DB_NAME\"", backupMountPath, BackupSystemDatabaseFileName)
+}
+
+// redisBackupContainer requests a snapshot of the running redis server
+func (b *Backup) redisBackupContainer(redis *BackupRedis, fileName string) v1.Container {
+	return v1.Container{
+		Image:   redis.Image,
+		Command: []string{"/bin/bash", "-c"},
+		Args: []string{
+			fmt.Sprintf("redis-cli -h \"%s\" -p \"%s\" --rdb %s/%s", redis.Host, redis.Port, backupMountPath, fileName),
+		},
+	}
+}
+
+// redisRestoreContainer replaces the data of the stopped redis server with
+// the snapshot. Redis is configured with append only files, so the snapshot
+// is loaded by a temporary server that rewrites the append only file from it
+func (b *Backup) redisRestoreContainer(redis *BackupRedis, fileName string) v1.Container {
+	script := fmt.Sprintf(`set -e
+cp %s/%s /var/lib/redis/data/dump.rdb
+rm -f /var/lib/redis/data/appendonly.aof
+redis-server --dir /var/lib/redis/data --dbfilename dump.rdb --appendonly no --port 6380 --daemonize yes
+until redis-cli -p 6380 ping; do sleep 1; done
+redis-cli -p 6380 config set appendonly yes
+until redis-cli -p 6380 info persistence | grep -q "aof_rewrite_in_progress:0" && redis-cli -p 6380 info persistence | grep -q "aof_rewrite_scheduled:0"; do sleep 1; done
+redis-cli -p 6380 shutdown
+`, backupMountPath, fileName)
+
+	return v1.Container{
+		Image:   redis.Image,
+		Command: []string{"/bin/bash", "-c"},
+		Args:    []string{script},
+		VolumeMounts: []v1.VolumeMount{
+			v1.VolumeMount{Name: "redis-storage", MountPath: "/var/lib/redis/data"},
+		},
+	}
+}
+
+func (b *Backup) redisVolumes(redis *BackupRedis) []v1.Volume {
+	return []v1.Volume{
+		v1.Volume{
+			Name: "redis-storage",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: redis.PersistentVolumeClaimName,
+				},
+			},
+		},
+	}
+}
+
+func (b *Backup) systemStorageContainer(script string, readOnly bool) v1.Container {
+	return v1.Container{
+		Image:   b.Options.systemImage,
+		Command: []string{"/bin/bash", "-c"},
+		Args:    []string{script},
+		VolumeMounts: []v1.VolumeMount{
+			v1.VolumeMount{Name: "system-storage", MountPath: "/system-storage", ReadOnly: readOnly},
+		},
+	}
+}
+
+func (b *Backup) systemStorageVolumes() []v1.Volume {
+	return []v1.Volume{
+		v1.Volume{
+			Name: "system-storage",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: b.Options.systemStoragePVCName,
+				},
+			},
+		},
+	}
+}
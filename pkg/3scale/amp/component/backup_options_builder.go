@@ -0,0 +1,144 @@
+package component
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	BackupSystemDatabaseTypeMySQL      = "mysql"
+	BackupSystemDatabaseTypePostgreSQL = "postgresql"
+)
+
+// BackupPersistentVolumeClaim is the PersistentVolumeClaim where a backup
+// is stored
+type BackupPersistentVolumeClaim struct {
+	Name             string
+	StorageClassName *string
+	VolumeName       string
+	StorageRequest   resource.Quantity
+}
+
+// BackupS3 is the S3 bucket location where a backup is stored
+type BackupS3 struct {
+	ConfigurationSecretName string
+	Path                    string
+}
+
+// BackupSystemDatabase is the connection to the internal system database
+type BackupSystemDatabase struct {
+	Type         string
+	Image        string
+	Host         string
+	DatabaseName string
+}
+
+// BackupRedis is the connection to an internal redis
+type BackupRedis struct {
+	Image                     string
+	Host                      string
+	Port                      string
+	PersistentVolumeClaimName string
+	DeploymentName            string
+}
+
+type BackupOptions struct {
+	// backup required options
+	// Name of the APIManagerBackup or APIManagerRestore. Used as prefix
+	// of the names of the jobs
+	name     string
+	appLabel string
+
+	// backup non-required options
+	// Union type. Only one of the fields can be set
+	persistentVolumeClaim *BackupPersistentVolumeClaim
+	s3                    *BackupS3
+
+	// Nil when the databases are external
+	systemDatabase *BackupSystemDatabase
+	backendRedis   *BackupRedis
+	systemRedis    *BackupRedis
+	// Empty when System file storage is not a PersistentVolumeClaim
+	systemStoragePVCName string
+	systemImage          string
+	s3Image              string
+}
+
+type BackupOptionsBuilder struct {
+	options BackupOptions
+}
+
+func (b *BackupOptionsBuilder) Name(name string) {
+	b.options.name = name
+}
+
+func (b *BackupOptionsBuilder) AppLabel(appLabel string) {
+	b.options.appLabel = appLabel
+}
+
+func (b *BackupOptionsBuilder) PersistentVolumeClaim(pvc BackupPersistentVolumeClaim) {
+	b.options.persistentVolumeClaim = &pvc
+}
+
+func (b *BackupOptionsBuilder) S3(s3 BackupS3) {
+	b.options.s3 = &s3
+}
+
+func (b *BackupOptionsBuilder) SystemDatabase(systemDatabase BackupSystemDatabase) {
+	b.options.systemDatabase = &systemDatabase
+}
+
+func (b *BackupOptionsBuilder) BackendRedis(backendRedis BackupRedis) {
+	b.options.backendRedis = &backendRedis
+}
+
+func (b *BackupOptionsBuilder) SystemRedis(systemRedis BackupRedis) {
+	b.options.systemRedis = &systemRedis
+}
+
+func (b *BackupOptionsBuilder) SystemStoragePVCName(pvcName string) {
+	b.options.systemStoragePVCName = pvcName
+}
+
+func (b *BackupOptionsBuilder) SystemImage(image string) {
+	b.options.systemImage = image
+}
+
+func (b *BackupOptionsBuilder) S3Image(image string) {
+	b.options.s3Image = image
+}
+
+func (b *BackupOptionsBuilder) Build() (*BackupOptions, error) {
+	err := b.setRequiredOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	b.setNonRequiredOptions()
+
+	return &b.options, nil
+}
+
+func (b *BackupOptionsBuilder) setRequiredOptions() error {
+	if b.options.name == "" {
+		return fmt.Errorf("no name has been provided")
+	}
+	if b.options.appLabel == "" {
+		return fmt.Errorf("no AppLabel has been provided")
+	}
+	if (b.options.persistentVolumeClaim == nil) == (b.options.s3 == nil) {
+		return fmt.Errorf("one and only one of PersistentVolumeClaim or S3 backup location has to be provided")
+	}
+	if b.options.systemStoragePVCName != "" && b.options.systemImage == "" {
+		return fmt.Errorf("no System image has been provided")
+	}
+
+	return nil
+}
+
+func (b *BackupOptionsBuilder) setNonRequiredOptions() {
+	if b.options.s3Image == "" {
+		b.options.s3Image = BackupS3ImageURL()
+	}
+}
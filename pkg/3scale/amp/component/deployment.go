@@ -0,0 +1,234 @@
+package component
+
+import (
+	appsv1 "github.com/openshift/api/apps/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Name of the container of the job that runs the pre deployment hook
+	// of a DeploymentConfig when it is rendered as a Deployment. Previous
+	// versions ran it as an init container of the pods with the same name
+	PreHookContainerName = "pre-hook"
+)
+
+// ImageStreamTagImages returns the image referenced by every tag of the
+// given ImageStreams indexed by "<imagestream>:<tag>". Tags pointing to
+// another tag of the same ImageStream are resolved to the final image
+func ImageStreamTagImages(imageStreams []*imagev1.ImageStream) map[string]string {
+	images := map[string]string{}
+	for _, imageStream := range imageStreams {
+		tags := map[string]*v1.ObjectReference{}
+		for idx := range imageStream.Spec.Tags {
+			tags[imageStream.Spec.Tags[idx].Name] = imageStream.Spec.Tags[idx].From
+		}
+
+		for tagName := range tags {
+			from := tags[tagName]
+			// a tag chain cannot be longer than the number of tags
+			for hops := 0; from != nil && from.Kind == "ImageStreamTag" && hops < len(tags); hops++ {
+				from = tags[from.Name]
+			}
+			if from != nil && from.Kind == "DockerImage" {
+				images[imageStream.Name+":"+tagName] = from.Name
+			}
+		}
+	}
+	return images
+}
+
+// DeploymentFromDeploymentConfig renders the given DeploymentConfig as an
+// apps/v1 Deployment. The images of the containers referenced by
+// ImageStreamTag triggers are replaced by the images found in the given
+// "<imagestream>:<tag>" index. The pre deployment hook, if any, is not part
+// of the Deployment, it is run once per rollout by the job returned by
+// PreHookJob. Post deployment hooks are not supported and are ignored
+func DeploymentFromDeploymentConfig(dc *appsv1.DeploymentConfig, images map[string]string) *k8sappsv1.Deployment {
+	template := dc.Spec.Template.DeepCopy()
+	if template == nil {
+		template = &v1.PodTemplateSpec{}
+	}
+
+	for _, trigger := range dc.Spec.Triggers {
+		if trigger.Type != appsv1.DeploymentTriggerOnImageChange || trigger.ImageChangeParams == nil {
+			continue
+		}
+		image, ok := images[trigger.ImageChangeParams.From.Name]
+		if !ok {
+			continue
+		}
+		for _, containerName := range trigger.ImageChangeParams.ContainerNames {
+			setContainerImage(template.Spec.InitContainers, containerName, image)
+			setContainerImage(template.Spec.Containers, containerName, image)
+		}
+	}
+
+	replicas := dc.Spec.Replicas
+
+	return &k8sappsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: *dc.ObjectMeta.DeepCopy(),
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas:        &replicas,
+			Selector:        &metav1.LabelSelector{MatchLabels: dc.Spec.Selector},
+			Template:        *template,
+			Strategy:        deploymentStrategy(dc.Spec.Strategy),
+			MinReadySeconds: dc.Spec.MinReadySeconds,
+		},
+	}
+}
+
+func setContainerImage(containers []v1.Container, containerName, image string) {
+	for idx := range containers {
+		if containers[idx].Name == containerName {
+			containers[idx].Image = image
+		}
+	}
+}
+
+// DeploymentConfigPreHook returns the exec new pod pre deployment hook of the
+// rolling or recreate strategy of the DeploymentConfig, nil when there is none
+func DeploymentConfigPreHook(dc *appsv1.DeploymentConfig) *appsv1.ExecNewPodHook {
+	strategy := dc.Spec.Strategy
+	if strategy.RollingParams != nil && strategy.RollingParams.Pre != nil {
+		return strategy.RollingParams.Pre.ExecNewPod
+	}
+	if strategy.RecreateParams != nil && strategy.RecreateParams.Pre != nil {
+		return strategy.RecreateParams.Pre.ExecNewPod
+	}
+	return nil
+}
+
+// PreHookJob returns the job running the pre deployment hook of the given
+// DeploymentConfig before the given pod template of its Deployment is rolled
+// out, nil when the DeploymentConfig has no pre deployment hook. The pod of
+// the job only has the volumes listed in the hook, and does not have the
+// labels of the template so it is not selected by the services
+func PreHookJob(dc *appsv1.DeploymentConfig, template *v1.PodTemplateSpec, name string) *batchv1.Job {
+	hook := DeploymentConfigPreHook(dc)
+	if hook == nil {
+		return nil
+	}
+	container := preHookContainer(hook, template.Spec.Containers)
+	if container == nil {
+		return nil
+	}
+
+	volumeNames := map[string]bool{}
+	for _, volumeName := range hook.Volumes {
+		volumeNames[volumeName] = true
+	}
+	volumes := []v1.Volume{}
+	for _, volume := range template.Spec.Volumes {
+		if volumeNames[volume.Name] {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	labels := map[string]string{}
+	for key, value := range dc.Labels {
+		labels[key] = value
+	}
+	labels["threescale_component_element"] = PreHookContainerName
+
+	// the job is retried like a hook with the Retry failure policy
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy:      v1.RestartPolicyNever,
+					Containers:         []v1.Container{*container},
+					Volumes:            volumes,
+					ServiceAccountName: template.Spec.ServiceAccountName,
+					ImagePullSecrets:   template.Spec.ImagePullSecrets,
+					NodeSelector:       template.Spec.NodeSelector,
+					Tolerations:        template.Spec.Tolerations,
+					Affinity:           template.Spec.Affinity,
+					PriorityClassName:  template.Spec.PriorityClassName,
+				},
+			},
+		},
+	}
+}
+
+// preHookContainer builds a container that runs the hook command with the
+// image and environment of the container referenced by the hook. Only the
+// volumes listed in the hook are mounted
+func preHookContainer(hook *appsv1.ExecNewPodHook, containers []v1.Container) *v1.Container {
+	var hookContainer *v1.Container
+	for idx := range containers {
+		if containers[idx].Name == hook.ContainerName {
+			hookContainer = &containers[idx]
+		}
+	}
+	if hookContainer == nil {
+		return nil
+	}
+
+	volumeNames := map[string]bool{}
+	for _, volumeName := range hook.Volumes {
+		volumeNames[volumeName] = true
+	}
+	volumeMounts := []v1.VolumeMount{}
+	for _, volumeMount := range hookContainer.VolumeMounts {
+		if volumeNames[volumeMount.Name] {
+			volumeMounts = append(volumeMounts, volumeMount)
+		}
+	}
+
+	env := []v1.EnvVar{}
+	hookEnvNames := map[string]bool{}
+	for _, envVar := range hook.Env {
+		hookEnvNames[envVar.Name] = true
+	}
+	for _, envVar := range hookContainer.Env {
+		if !hookEnvNames[envVar.Name] {
+			env = append(env, envVar)
+		}
+	}
+	env = append(env, hook.Env...)
+
+	return &v1.Container{
+		Name:            PreHookContainerName,
+		Image:           hookContainer.Image,
+		Command:         hook.Command,
+		Env:             env,
+		EnvFrom:         hookContainer.EnvFrom,
+		VolumeMounts:    volumeMounts,
+		ImagePullPolicy: hookContainer.ImagePullPolicy,
+	}
+}
+
+func deploymentStrategy(strategy appsv1.DeploymentStrategy) k8sappsv1.DeploymentStrategy {
+	if strategy.Type == appsv1.DeploymentStrategyTypeRecreate {
+		return k8sappsv1.DeploymentStrategy{Type: k8sappsv1.RecreateDeploymentStrategyType}
+	}
+
+	rollingUpdate := &k8sappsv1.RollingUpdateDeployment{}
+	if strategy.RollingParams != nil {
+		rollingUpdate.MaxUnavailable = strategy.RollingParams.MaxUnavailable
+		rollingUpdate.MaxSurge = strategy.RollingParams.MaxSurge
+	}
+	return k8sappsv1.DeploymentStrategy{
+		Type:          k8sappsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: rollingUpdate,
+	}
+}
@@ -0,0 +1,194 @@
+package component
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	IngressClassAnnotation = "kubernetes.io/ingress.class"
+
+	// ExposedEndpointLabel identifies the external endpoint a Route or an
+	// Ingress belongs to
+	ExposedEndpointLabel = "threescale_component_element"
+
+	// wildcardHostPrefix is the prefix of the hosts that expose all the
+	// subdomains of a domain
+	wildcardHostPrefix = "*."
+)
+
+type Exposure struct {
+	Options *ExposureOptions
+}
+
+func NewExposure(options *ExposureOptions) *Exposure {
+	return &Exposure{Options: options}
+}
+
+type exposedEndpoint struct {
+	name        string
+	component   string
+	serviceName string
+	servicePort string
+	options     *EndpointExposure
+}
+
+func (e *Exposure) endpoints() []exposedEndpoint {
+	return []exposedEndpoint{
+		{name: "system-master", component: "system", serviceName: "system-master", servicePort: "http", options: e.Options.master},
+		{name: "system-provider", component: "system", serviceName: "system-provider", servicePort: "http", options: e.Options.provider},
+		{name: "system-developer", component: "system", serviceName: "system-developer", servicePort: "http", options: e.Options.developer},
+		{name: "apicast-staging", component: "apicast", serviceName: "apicast-staging", servicePort: "gateway", options: e.Options.apicastStaging},
+		{name: "apicast-production", component: "apicast", serviceName: "apicast-production", servicePort: "gateway", options: e.Options.apicastProduction},
+		{name: "backend", component: "backend", serviceName: "backend-listener", servicePort: "http", options: e.Options.backend},
+	}
+}
+
+// Routes returns the OpenShift Routes of all the external endpoints. A
+// Route only admits one host, so every additional host of an endpoint gets
+// its own Route, named after the host because the wildcard policy of a
+// Route cannot be changed
+func (e *Exposure) Routes() []*routev1.Route {
+	routes := []*routev1.Route{}
+	for _, endpoint := range e.endpoints() {
+		routes = append(routes, e.route(endpoint, endpoint.name, endpoint.options.Host))
+		for _, host := range endpoint.options.AdditionalHosts {
+			name := fmt.Sprintf("%s-%x", endpoint.name, sha256.Sum256([]byte(host)))[:len(endpoint.name)+11]
+			routes = append(routes, e.route(endpoint, name, host))
+		}
+	}
+	return routes
+}
+
+// Ingresses returns the Kubernetes Ingresses of all the external endpoints
+func (e *Exposure) Ingresses() []*v1beta1.Ingress {
+	ingresses := []*v1beta1.Ingress{}
+	for _, endpoint := range e.endpoints() {
+		ingresses = append(ingresses, e.ingress(endpoint))
+	}
+	return ingresses
+}
+
+func (e *Exposure) route(endpoint exposedEndpoint, name, host string) *routev1.Route {
+	// OpenShift Routes claim all the subdomains of the domain of their host
+	// with the Subdomain wildcard policy
+	wildcardPolicy := routev1.WildcardPolicyNone
+	if strings.HasPrefix(host, wildcardHostPrefix) {
+		host = "wildcard." + strings.TrimPrefix(host, wildcardHostPrefix)
+		wildcardPolicy = routev1.WildcardPolicySubdomain
+	}
+
+	return &routev1.Route{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Route",
+			APIVersion: "route.openshift.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: e.labels(endpoint),
+		},
+		Spec: routev1.RouteSpec{
+			Host: host,
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: endpoint.serviceName,
+			},
+			Port: &routev1.RoutePort{
+				TargetPort: intstr.FromString(endpoint.servicePort),
+			},
+			TLS:            e.routeTLSConfig(endpoint.options),
+			WildcardPolicy: wildcardPolicy,
+		},
+	}
+}
+
+func (e *Exposure) labels(endpoint exposedEndpoint) map[string]string {
+	return map[string]string{
+		"app":                  e.Options.appLabel,
+		"threescale_component": endpoint.component,
+		ExposedEndpointLabel:   endpoint.name,
+	}
+}
+
+func (e *Exposure) routeTLSConfig(options *EndpointExposure) *routev1.TLSConfig {
+	if options.Termination == routev1.TLSTerminationPassthrough {
+		return &routev1.TLSConfig{
+			Termination:                   routev1.TLSTerminationPassthrough,
+			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyNone,
+		}
+	}
+
+	tlsConfig := &routev1.TLSConfig{
+		Termination:                   options.Termination,
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyAllow,
+		Certificate:                   options.Certificate,
+		Key:                           options.Key,
+		CACertificate:                 options.CACertificate,
+	}
+	if options.Termination == routev1.TLSTerminationReencrypt {
+		tlsConfig.DestinationCACertificate = options.DestinationCACertificate
+	}
+	return tlsConfig
+}
+
+func (e *Exposure) ingress(endpoint exposedEndpoint) *v1beta1.Ingress {
+	annotations := map[string]string{}
+	if e.Options.ingressClass != nil {
+		annotations[IngressClassAnnotation] = *e.Options.ingressClass
+	}
+
+	hosts := append([]string{endpoint.options.Host}, endpoint.options.AdditionalHosts...)
+
+	rules := []v1beta1.IngressRule{}
+	for _, host := range hosts {
+		rules = append(rules, v1beta1.IngressRule{
+			Host: host,
+			IngressRuleValue: v1beta1.IngressRuleValue{
+				HTTP: &v1beta1.HTTPIngressRuleValue{
+					Paths: []v1beta1.HTTPIngressPath{
+						v1beta1.HTTPIngressPath{
+							Backend: v1beta1.IngressBackend{
+								ServiceName: endpoint.serviceName,
+								ServicePort: intstr.FromString(endpoint.servicePort),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	// Without a certificate secret the endpoint is served over plain HTTP
+	// by the ingress controller
+	var tls []v1beta1.IngressTLS
+	if endpoint.options.CertificateSecretName != "" {
+		tls = []v1beta1.IngressTLS{
+			v1beta1.IngressTLS{
+				Hosts:      hosts,
+				SecretName: endpoint.options.CertificateSecretName,
+			},
+		}
+	}
+
+	return &v1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        endpoint.name,
+			Labels:      e.labels(endpoint),
+			Annotations: annotations,
+		},
+		Spec: v1beta1.IngressSpec{
+			TLS:   tls,
+			Rules: rules,
+		},
+	}
+}
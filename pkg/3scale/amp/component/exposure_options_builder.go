@@ -0,0 +1,140 @@
+package component
+
+import (
+	"fmt"
+
+	routev1 "github.com/openshift/api/route/v1"
+)
+
+// EndpointExposure contains the settings used to expose an external
+// endpoint through a Route or an Ingress
+type EndpointExposure struct {
+	Host string
+	// Other hosts served by the endpoint. A host starting with "*." serves
+	// all the subdomains of the domain
+	AdditionalHosts []string
+	Termination     routev1.TLSTerminationType
+	// Name of the secret with the certificate. Used by Ingresses
+	CertificateSecretName string
+	// PEM encoded contents of the certificate secret. Used by Routes
+	Certificate              string
+	Key                      string
+	CACertificate            string
+	DestinationCACertificate string
+}
+
+type ExposureOptions struct {
+	// exposure required options
+	appLabel       string
+	tenantName     string
+	wildcardDomain string
+	masterName     string
+
+	// exposure non-required options
+	ingressClass      *string
+	master            *EndpointExposure
+	provider          *EndpointExposure
+	developer         *EndpointExposure
+	apicastStaging    *EndpointExposure
+	apicastProduction *EndpointExposure
+	backend           *EndpointExposure
+}
+
+type ExposureOptionsBuilder struct {
+	options ExposureOptions
+}
+
+func (e *ExposureOptionsBuilder) AppLabel(appLabel string) {
+	e.options.appLabel = appLabel
+}
+
+func (e *ExposureOptionsBuilder) TenantName(tenantName string) {
+	e.options.tenantName = tenantName
+}
+
+func (e *ExposureOptionsBuilder) WildcardDomain(wildcardDomain string) {
+	e.options.wildcardDomain = wildcardDomain
+}
+
+func (e *ExposureOptionsBuilder) MasterName(masterName string) {
+	e.options.masterName = masterName
+}
+
+func (e *ExposureOptionsBuilder) IngressClass(ingressClass string) {
+	e.options.ingressClass = &ingressClass
+}
+
+func (e *ExposureOptionsBuilder) Master(endpoint EndpointExposure) {
+	e.options.master = &endpoint
+}
+
+func (e *ExposureOptionsBuilder) Provider(endpoint EndpointExposure) {
+	e.options.provider = &endpoint
+}
+
+func (e *ExposureOptionsBuilder) Developer(endpoint EndpointExposure) {
+	e.options.developer = &endpoint
+}
+
+func (e *ExposureOptionsBuilder) ApicastStaging(endpoint EndpointExposure) {
+	e.options.apicastStaging = &endpoint
+}
+
+func (e *ExposureOptionsBuilder) ApicastProduction(endpoint EndpointExposure) {
+	e.options.apicastProduction = &endpoint
+}
+
+func (e *ExposureOptionsBuilder) Backend(endpoint EndpointExposure) {
+	e.options.backend = &endpoint
+}
+
+func (e *ExposureOptionsBuilder) Build() (*ExposureOptions, error) {
+	err := e.setRequiredOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	e.setNonRequiredOptions()
+
+	return &e.options, nil
+}
+
+func (e *ExposureOptionsBuilder) setRequiredOptions() error {
+	if e.options.appLabel == "" {
+		return fmt.Errorf("no AppLabel has been provided")
+	}
+	if e.options.tenantName == "" {
+		return fmt.Errorf("no tenant name has been provided")
+	}
+	if e.options.wildcardDomain == "" {
+		return fmt.Errorf("no wildcard domain has been provided")
+	}
+	if e.options.masterName == "" {
+		return fmt.Errorf("no master name has been provided")
+	}
+
+	return nil
+}
+
+func (e *ExposureOptionsBuilder) setNonRequiredOptions() {
+	tenantName := e.options.tenantName
+	e.options.master = e.defaultEndpoint(e.options.master, e.options.masterName)
+	e.options.provider = e.defaultEndpoint(e.options.provider, tenantName+"-admin")
+	e.options.developer = e.defaultEndpoint(e.options.developer, tenantName)
+	e.options.apicastStaging = e.defaultEndpoint(e.options.apicastStaging, "api-"+tenantName+"-apicast-staging")
+	e.options.apicastProduction = e.defaultEndpoint(e.options.apicastProduction, "api-"+tenantName+"-apicast-production")
+	e.options.backend = e.defaultEndpoint(e.options.backend, "backend-"+tenantName)
+}
+
+func (e *ExposureOptionsBuilder) defaultEndpoint(endpoint *EndpointExposure, subdomain string) *EndpointExposure {
+	if endpoint == nil {
+		endpoint = &EndpointExposure{}
+	}
+	if endpoint.Host == "" {
+		endpoint.Host = subdomain + "." + e.options.wildcardDomain
+	}
+	if endpoint.Termination == "" {
+		endpoint.Termination = routev1.TLSTerminationEdge
+	}
+	return endpoint
+}
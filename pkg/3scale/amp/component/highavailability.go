@@ -160,6 +160,8 @@ func (ha *HighAvailability) UpdateDatabasesURLS(objects []common.KubernetesObjec
 				secret.StringData[SystemSecretSystemRedisSentinelRole] = ha.Options.systemRedisSentinelsRole
 				secret.StringData[SystemSecretSystemRedisMessageBusSentinelHosts] = ha.Options.systemMessageBusRedisSentinelsHosts
 				secret.StringData[SystemSecretSystemRedisMessageBusSentinelRole] = ha.Options.systemMessageBusRedisSentinelsRole
+				secret.StringData[SystemSecretSystemRedisSentinelPassword] = ha.Options.systemRedisSentinelsPassword
+				secret.StringData[SystemSecretSystemRedisMessageBusSentinelPassword] = ha.Options.systemMessageBusRedisSentinelsPassword
 			case "backend-redis":
 				secret.StringData["REDIS_STORAGE_URL"] = ha.Options.backendRedisStorageEndpoint
 				secret.StringData["REDIS_QUEUES_URL"] = ha.Options.backendRedisQueuesEndpoint
@@ -167,6 +169,8 @@ func (ha *HighAvailability) UpdateDatabasesURLS(objects []common.KubernetesObjec
 				secret.StringData[BackendSecretBackendRedisStorageSentinelRoleFieldName] = ha.Options.backendRedisStorageSentinelRole
 				secret.StringData[BackendSecretBackendRedisQueuesSentinelHostsFieldName] = ha.Options.backendRedisQueuesSentinelHosts
 				secret.StringData[BackendSecretBackendRedisQueuesSentinelRoleFieldName] = ha.Options.backendRedisQueuesSentinelRole
+				secret.StringData[BackendSecretBackendRedisStorageSentinelPasswordFieldName] = ha.Options.backendRedisStorageSentinelPassword
+				secret.StringData[BackendSecretBackendRedisQueuesSentinelPasswordFieldName] = ha.Options.backendRedisQueuesSentinelPassword
 			}
 		}
 	}
@@ -20,6 +20,11 @@ type HighAvailabilityOptions struct {
 	systemMessageBusRedisURL            string
 	systemMessageBusRedisSentinelsHosts string
 	systemMessageBusRedisSentinelsRole  string
+
+	backendRedisQueuesSentinelPassword     string
+	backendRedisStorageSentinelPassword    string
+	systemRedisSentinelsPassword           string
+	systemMessageBusRedisSentinelsPassword string
 }
 
 type HighAvailabilityOptionsBuilder struct {
@@ -82,6 +87,22 @@ func (ha *HighAvailabilityOptionsBuilder) BackendRedisStorageSentinelRole(role s
 	ha.options.backendRedisStorageSentinelRole = role
 }
 
+func (ha *HighAvailabilityOptionsBuilder) BackendRedisQueuesSentinelPassword(password string) {
+	ha.options.backendRedisQueuesSentinelPassword = password
+}
+
+func (ha *HighAvailabilityOptionsBuilder) BackendRedisStorageSentinelPassword(password string) {
+	ha.options.backendRedisStorageSentinelPassword = password
+}
+
+func (ha *HighAvailabilityOptionsBuilder) SystemRedisSentinelsPassword(password string) {
+	ha.options.systemRedisSentinelsPassword = password
+}
+
+func (ha *HighAvailabilityOptionsBuilder) SystemMessageBusRedisSentinelsPassword(password string) {
+	ha.options.systemMessageBusRedisSentinelsPassword = password
+}
+
 func (ha *HighAvailabilityOptionsBuilder) Build() (*HighAvailabilityOptions, error) {
 
 	err := ha.setRequiredOptions()
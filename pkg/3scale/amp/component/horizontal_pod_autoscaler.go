@@ -0,0 +1,69 @@
+package component
+
+import (
+	"k8s.io/api/autoscaling/v2beta2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultAutoscalingTargetCPUUtilizationPercentage int32 = 80
+)
+
+// Autoscaling contains the settings of the HorizontalPodAutoscaler
+// targeting a DeploymentConfig
+type Autoscaling struct {
+	MinReplicas                       int32
+	MaxReplicas                       int32
+	TargetCPUUtilizationPercentage    *int32
+	TargetMemoryUtilizationPercentage *int32
+}
+
+func horizontalPodAutoscaler(deploymentConfigName string, labels map[string]string, autoscaling *Autoscaling) *v2beta2.HorizontalPodAutoscaler {
+	minReplicas := autoscaling.MinReplicas
+
+	metrics := []v2beta2.MetricSpec{}
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, resourceUtilizationMetric(v1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceUtilizationMetric(v1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+	if len(metrics) == 0 {
+		metrics = append(metrics, resourceUtilizationMetric(v1.ResourceCPU, DefaultAutoscalingTargetCPUUtilizationPercentage))
+	}
+
+	return &v2beta2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2beta2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   deploymentConfigName,
+			Labels: labels,
+		},
+		Spec: v2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: v2beta2.CrossVersionObjectReference{
+				APIVersion: "apps.openshift.io/v1",
+				Kind:       "DeploymentConfig",
+				Name:       deploymentConfigName,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+func resourceUtilizationMetric(resourceName v1.ResourceName, averageUtilization int32) v2beta2.MetricSpec {
+	return v2beta2.MetricSpec{
+		Type: v2beta2.ResourceMetricSourceType,
+		Resource: &v2beta2.ResourceMetricSource{
+			Name: resourceName,
+			Target: v2beta2.MetricTarget{
+				Type:               v2beta2.UtilizationMetricType,
+				AverageUtilization: &averageUtilization,
+			},
+		},
+	}
+}
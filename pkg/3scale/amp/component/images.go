@@ -39,3 +39,7 @@ func SystemMemcachedImageURL() string {
 func ZyncPostgreSQLImageURL() string {
 	return "centos/postgresql-10-centos7"
 }
+
+func BackupS3ImageURL() string {
+	return "amazon/aws-cli"
+}
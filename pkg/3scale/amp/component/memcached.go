@@ -115,6 +115,10 @@ func (m *Memcached) DeploymentConfig() *appsv1.DeploymentConfig {
 							ImagePullPolicy: v1.PullIfNotPresent,
 						},
 					},
+					NodeSelector:      m.Options.podPlacement.NodeSelector,
+					Tolerations:       m.Options.podPlacement.Tolerations,
+					Affinity:          m.Options.podPlacement.Affinity,
+					PriorityClassName: m.Options.podPlacement.PriorityClassName,
 				}},
 		},
 	}
@@ -0,0 +1,48 @@
+package component
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PVCOptions configures the PersistentVolumeClaim of a stateful component
+type PVCOptions struct {
+	StorageClass   *string
+	StorageRequest *resource.Quantity
+	AccessModes    []v1.PersistentVolumeAccessMode
+	Selector       *metav1.LabelSelector
+}
+
+// withPVCDefaults returns a copy of the options with the storage request
+// and the access modes defaulted when they are not set
+func withPVCDefaults(options *PVCOptions, storageRequest string, accessMode v1.PersistentVolumeAccessMode) *PVCOptions {
+	res := PVCOptions{}
+	if options != nil {
+		res = *options
+	}
+
+	if res.StorageRequest == nil {
+		defaultStorageRequest := resource.MustParse(storageRequest)
+		res.StorageRequest = &defaultStorageRequest
+	}
+
+	if len(res.AccessModes) == 0 {
+		res.AccessModes = []v1.PersistentVolumeAccessMode{accessMode}
+	}
+
+	return &res
+}
+
+func (o *PVCOptions) persistentVolumeClaimSpec() v1.PersistentVolumeClaimSpec {
+	return v1.PersistentVolumeClaimSpec{
+		StorageClassName: o.StorageClass,
+		AccessModes:      o.AccessModes,
+		Selector:         o.Selector,
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{
+				v1.ResourceStorage: *o.StorageRequest,
+			},
+		},
+	}
+}
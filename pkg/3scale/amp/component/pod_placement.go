@@ -0,0 +1,14 @@
+package component
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// PodPlacement contains the scheduling settings applied to the pod
+// template of a DeploymentConfig
+type PodPlacement struct {
+	NodeSelector      map[string]string
+	Tolerations       []v1.Toleration
+	Affinity          *v1.Affinity
+	PriorityClassName string
+}
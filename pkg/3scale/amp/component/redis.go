@@ -6,7 +6,6 @@ import (
 	appsv1 "github.com/openshift/api/apps/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -141,6 +140,10 @@ func (redis *Redis) buildPodTemplateSpec() *v1.PodTemplateSpec {
 			ServiceAccountName: "amp", //TODO make this configurable via flag
 			Volumes:            redis.buildPodVolumes(),
 			Containers:         redis.buildPodContainers(),
+			NodeSelector:       redis.Options.backendRedisPodPlacement.NodeSelector,
+			Tolerations:        redis.Options.backendRedisPodPlacement.Tolerations,
+			Affinity:           redis.Options.backendRedisPodPlacement.Affinity,
+			PriorityClassName:  redis.Options.backendRedisPodPlacement.PriorityClassName,
 		},
 		ObjectMeta: redis.buildPodObjectMeta(),
 	}
@@ -405,8 +408,7 @@ func (redis *Redis) BackendPVC() *v1.PersistentVolumeClaim {
 	return &v1.PersistentVolumeClaim{
 		ObjectMeta: redis.buildPVCObjectMeta(),
 		TypeMeta:   redis.buildPVCTypeMeta(),
-		Spec:       redis.buildPVCSpec(),
-		// TODO be able to configure StorageClass in case one wants to be used
+		Spec:       redis.Options.backendRedisPVCOptions.persistentVolumeClaimSpec(),
 	}
 }
 
@@ -432,19 +434,6 @@ func (redis *Redis) buildPVCTypeMeta() metav1.TypeMeta {
 	}
 }
 
-func (redis *Redis) buildPVCSpec() v1.PersistentVolumeClaimSpec {
-	return v1.PersistentVolumeClaimSpec{
-		AccessModes: []v1.PersistentVolumeAccessMode{
-			v1.ReadWriteOnce, // TODO be able to configure this because we have different volume access modes for different claims
-		},
-		Resources: v1.ResourceRequirements{
-			Requests: v1.ResourceList{
-				v1.ResourceStorage: resource.MustParse("1Gi"),
-			},
-		},
-	}
-}
-
 func (redis *Redis) BackendImageStream() *imagev1.ImageStream {
 	return &imagev1.ImageStream{
 		ObjectMeta: metav1.ObjectMeta{
@@ -606,6 +595,10 @@ func (redis *Redis) SystemDeploymentConfig() *appsv1.DeploymentConfig {
 							ImagePullPolicy:        v1.PullIfNotPresent,
 						},
 					},
+					NodeSelector:      redis.Options.systemRedisPodPlacement.NodeSelector,
+					Tolerations:       redis.Options.systemRedisPodPlacement.Tolerations,
+					Affinity:          redis.Options.systemRedisPodPlacement.Affinity,
+					PriorityClassName: redis.Options.systemRedisPodPlacement.PriorityClassName,
 				}},
 		},
 	}
@@ -653,14 +646,7 @@ func (redis *Redis) SystemPVC() *v1.PersistentVolumeClaim {
 				"app":                          redis.Options.appLabel,
 			},
 		},
-		Spec: v1.PersistentVolumeClaimSpec{
-			AccessModes: []v1.PersistentVolumeAccessMode{
-				v1.PersistentVolumeAccessMode("ReadWriteOnce"),
-			},
-			Resources: v1.ResourceRequirements{
-				Requests: v1.ResourceList{"storage": resource.MustParse("1Gi")},
-			},
-		},
+		Spec: redis.Options.systemRedisPVCOptions.persistentVolumeClaimSpec(),
 	}
 }
 
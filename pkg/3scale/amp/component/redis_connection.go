@@ -0,0 +1,158 @@
+package component
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	RedisTLSVolumeName = "redis-tls"
+	RedisTLSMountPath  = "/tls/redis"
+)
+
+// RedisConnectionEnvVarNames are the environment variables with the redis sentinel
+// passwords and CA files. Unlike the rest of the environment, they are kept in sync
+// in the existing deployment configs
+var RedisConnectionEnvVarNames = []string{
+	"CONFIG_REDIS_SENTINEL_PASSWORD",
+	"CONFIG_QUEUES_SENTINEL_PASSWORD",
+	"CONFIG_REDIS_CA_FILE",
+	"CONFIG_QUEUES_CA_FILE",
+	"REDIS_SENTINEL_PASSWORD",
+	"MESSAGE_BUS_REDIS_SENTINEL_PASSWORD",
+	"BACKEND_REDIS_SENTINEL_PASSWORD",
+	"REDIS_CA_FILE",
+	"MESSAGE_BUS_REDIS_CA_FILE",
+	"BACKEND_REDIS_CA_FILE",
+}
+
+// redisCAFile is the CA bundle of a redis TLS connection. It is read from a
+// secret key and mounted as a file of the redis TLS volume
+type redisCAFile struct {
+	envVar     string
+	secretName string
+	secretKey  string
+	fileName   string
+}
+
+// redisTLSEnvVars returns the environment variables with the paths of the CA files
+func redisTLSEnvVars(files []redisCAFile) []v1.EnvVar {
+	result := []v1.EnvVar{}
+	for _, file := range files {
+		result = append(result, envVarFromValue(file.envVar, path.Join(RedisTLSMountPath, file.fileName)))
+	}
+	return result
+}
+
+// redisTLSVolumes returns the projected volume with the CA files, none when
+// there aren't any
+func redisTLSVolumes(files []redisCAFile) []v1.Volume {
+	if len(files) == 0 {
+		return []v1.Volume{}
+	}
+
+	sources := []v1.VolumeProjection{}
+	for _, file := range files {
+		sources = append(sources, v1.VolumeProjection{
+			Secret: &v1.SecretProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: file.secretName},
+				Items:                []v1.KeyToPath{v1.KeyToPath{Key: file.secretKey, Path: file.fileName}},
+			},
+		})
+	}
+
+	return []v1.Volume{
+		v1.Volume{
+			Name: RedisTLSVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					Sources:     sources,
+					DefaultMode: &[]int32{420}[0],
+				},
+			},
+		},
+	}
+}
+
+// redisTLSVolumeMounts returns the mount of the redis TLS volume, none when
+// there aren't any CA files
+func redisTLSVolumeMounts(files []redisCAFile) []v1.VolumeMount {
+	if len(files) == 0 {
+		return []v1.VolumeMount{}
+	}
+	return []v1.VolumeMount{
+		v1.VolumeMount{
+			Name:      RedisTLSVolumeName,
+			MountPath: RedisTLSMountPath,
+			ReadOnly:  true,
+		},
+	}
+}
+
+// ValidateRedisConnection checks the settings of a connection to an external redis.
+// With sentinel hosts, the host of the URL is the name of the master the sentinels
+// monitor. A CA bundle is only used by rediss:// URLs
+func ValidateRedisConnection(redisURL, sentinelHosts, sentinelRole, sslCA string) error {
+	// url errors are not wrapped, they include the password of the URL
+	u, err := url.Parse(redisURL)
+	if err != nil {
+		return fmt.Errorf("invalid redis URL: it can't be parsed")
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return fmt.Errorf("invalid redis URL scheme '%s': it has to be redis or rediss", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("invalid redis URL: no host or sentinel master name")
+	}
+
+	if sentinelHosts != "" {
+		for _, host := range strings.Split(sentinelHosts, ",") {
+			err = validateSentinelHost(strings.TrimSpace(host))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if sentinelRole != "" && sentinelRole != "master" && sentinelRole != "slave" {
+		return fmt.Errorf("invalid sentinel role '%s': it has to be master or slave", sentinelRole)
+	}
+
+	if sslCA != "" {
+		if u.Scheme != "rediss" {
+			return fmt.Errorf("invalid redis URL scheme '%s': a CA bundle requires rediss", u.Scheme)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(sslCA)) {
+			return fmt.Errorf("invalid redis CA bundle: no PEM certificates found")
+		}
+	}
+
+	return nil
+}
+
+// validateSentinelHost checks a sentinel host, either a host:port address or a
+// redis URL with the password of the sentinel
+func validateSentinelHost(host string) error {
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return fmt.Errorf("invalid sentinel host: it can't be parsed")
+		}
+		if u.Hostname() == "" {
+			return fmt.Errorf("invalid sentinel host: no host")
+		}
+		return nil
+	}
+
+	_, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return fmt.Errorf("invalid sentinel host: %s", err)
+	}
+	return nil
+}
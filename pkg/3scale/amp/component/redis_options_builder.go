@@ -17,6 +17,10 @@ type RedisOptions struct {
 	// non-required options
 	backendRedisContainerResourceRequirements *v1.ResourceRequirements
 	systemRedisContainerResourceRequirements  *v1.ResourceRequirements
+	backendRedisPodPlacement                  *PodPlacement
+	systemRedisPodPlacement                   *PodPlacement
+	backendRedisPVCOptions                    *PVCOptions
+	systemRedisPVCOptions                     *PVCOptions
 	insecureImportPolicy                      bool
 }
 
@@ -36,6 +40,22 @@ func (r *RedisOptionsBuilder) BackendRedisContainerResourceRequirements(resource
 	r.options.backendRedisContainerResourceRequirements = &resourceRequirements
 }
 
+func (r *RedisOptionsBuilder) BackendRedisPodPlacement(podPlacement PodPlacement) {
+	r.options.backendRedisPodPlacement = &podPlacement
+}
+
+func (r *RedisOptionsBuilder) SystemRedisPodPlacement(podPlacement PodPlacement) {
+	r.options.systemRedisPodPlacement = &podPlacement
+}
+
+func (r *RedisOptionsBuilder) BackendRedisPVCOptions(options PVCOptions) {
+	r.options.backendRedisPVCOptions = &options
+}
+
+func (r *RedisOptionsBuilder) SystemRedisPVCOptions(options PVCOptions) {
+	r.options.systemRedisPVCOptions = &options
+}
+
 func (r *RedisOptionsBuilder) AMPRelease(ampRelease string) {
 	r.options.ampRelease = ampRelease
 }
@@ -91,6 +111,17 @@ func (r *RedisOptionsBuilder) setNonRequiredOptions() {
 	if r.options.systemRedisContainerResourceRequirements == nil {
 		r.options.systemRedisContainerResourceRequirements = r.defaultSystemRedisContainerResourceRequirements()
 	}
+
+	if r.options.backendRedisPodPlacement == nil {
+		r.options.backendRedisPodPlacement = &PodPlacement{}
+	}
+
+	if r.options.systemRedisPodPlacement == nil {
+		r.options.systemRedisPodPlacement = &PodPlacement{}
+	}
+
+	r.options.backendRedisPVCOptions = withPVCDefaults(r.options.backendRedisPVCOptions, "1Gi", v1.ReadWriteOnce)
+	r.options.systemRedisPVCOptions = withPVCDefaults(r.options.systemRedisPVCOptions, "1Gi", v1.ReadWriteOnce)
 }
 
 func (r *RedisOptionsBuilder) defaultBackendRedisContainerResourceRequirements() *v1.ResourceRequirements {
@@ -8,7 +8,6 @@ import (
 
 	appsv1 "github.com/openshift/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -49,6 +48,10 @@ const (
 	SystemSecretSystemRedisSentinelRole                = "SENTINEL_ROLE"
 	SystemSecretSystemRedisMessageBusSentinelHosts     = "MESSAGE_BUS_SENTINEL_HOSTS"
 	SystemSecretSystemRedisMessageBusSentinelRole      = "MESSAGE_BUS_SENTINEL_ROLE"
+	SystemSecretSystemRedisSentinelPassword            = "SENTINEL_PASSWORD"
+	SystemSecretSystemRedisMessageBusSentinelPassword  = "MESSAGE_BUS_SENTINEL_PASSWORD"
+	SystemSecretSystemRedisSSLCA                       = "SSL_CA"
+	SystemSecretSystemRedisMessageBusSSLCA             = "MESSAGE_BUS_SSL_CA"
 )
 
 const (
@@ -219,11 +222,55 @@ func (system *System) SystemRedisEnvVars() []v1.EnvVar {
 		envVarFromSecret("REDIS_SENTINEL_ROLE", SystemSecretSystemRedisSecretName, SystemSecretSystemRedisSentinelRole),
 		envVarFromSecret("MESSAGE_BUS_REDIS_SENTINEL_HOSTS", SystemSecretSystemRedisSecretName, SystemSecretSystemRedisMessageBusSentinelHosts),
 		envVarFromSecret("MESSAGE_BUS_REDIS_SENTINEL_ROLE", SystemSecretSystemRedisSecretName, SystemSecretSystemRedisMessageBusSentinelRole),
+		envVarFromSecretOptional("REDIS_SENTINEL_PASSWORD", SystemSecretSystemRedisSecretName, SystemSecretSystemRedisSentinelPassword),
+		envVarFromSecretOptional("MESSAGE_BUS_REDIS_SENTINEL_PASSWORD", SystemSecretSystemRedisSecretName, SystemSecretSystemRedisMessageBusSentinelPassword),
 	)
+	result = append(result, redisTLSEnvVars(system.systemRedisCAFiles())...)
 
 	return result
 }
 
+// systemRedisCAFiles returns the CA bundles of the system redis TLS connections
+func (system *System) systemRedisCAFiles() []redisCAFile {
+	result := []redisCAFile{}
+	if *system.Options.redisSSLCA != "" {
+		result = append(result, redisCAFile{
+			envVar:     "REDIS_CA_FILE",
+			secretName: SystemSecretSystemRedisSecretName,
+			secretKey:  SystemSecretSystemRedisSSLCA,
+			fileName:   "system-redis-ca.crt",
+		})
+	}
+	if *system.Options.messageBusRedisSSLCA != "" {
+		result = append(result, redisCAFile{
+			envVar:     "MESSAGE_BUS_REDIS_CA_FILE",
+			secretName: SystemSecretSystemRedisSecretName,
+			secretKey:  SystemSecretSystemRedisMessageBusSSLCA,
+			fileName:   "system-redis-message-bus-ca.crt",
+		})
+	}
+	return result
+}
+
+// backendRedisCAFiles returns the CA bundle of the backend redis storage TLS connection
+func (system *System) backendRedisCAFiles() []redisCAFile {
+	result := []redisCAFile{}
+	if *system.Options.backendRedisSSLCA != "" {
+		result = append(result, redisCAFile{
+			envVar:     "BACKEND_REDIS_CA_FILE",
+			secretName: BackendSecretBackendRedisSecretName,
+			secretKey:  BackendSecretBackendRedisStorageSSLCAFieldName,
+			fileName:   "backend-redis-storage-ca.crt",
+		})
+	}
+	return result
+}
+
+// redisCAFiles returns the CA bundles of all the redis TLS connections of system
+func (system *System) redisCAFiles() []redisCAFile {
+	return append(system.systemRedisCAFiles(), system.backendRedisCAFiles()...)
+}
+
 func (system *System) buildSystemBaseEnv() []v1.EnvVar {
 	result := []v1.EnvVar{}
 
@@ -304,11 +351,14 @@ func (system *System) buildSystemAppPreHookEnv() []v1.EnvVar {
 }
 
 func (system *System) BackendRedisEnvVars() []v1.EnvVar {
-	return []v1.EnvVar{
+	result := []v1.EnvVar{
 		envVarFromSecret("BACKEND_REDIS_URL", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageURLFieldName),
 		envVarFromSecret("BACKEND_REDIS_SENTINEL_HOSTS", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelHostsFieldName),
 		envVarFromSecret("BACKEND_REDIS_SENTINEL_ROLE", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelRoleFieldName),
+		envVarFromSecretOptional("BACKEND_REDIS_SENTINEL_PASSWORD", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelPasswordFieldName),
 	}
+	result = append(result, redisTLSEnvVars(system.backendRedisCAFiles())...)
+	return result
 }
 
 func (system *System) EnvironmentConfigMap() *v1.ConfigMap {
@@ -427,6 +477,10 @@ func (system *System) RedisSecret() *v1.Secret {
 			SystemSecretSystemRedisMessageBusSentinelRole:      *system.Options.messageBusRedisSentinelRole,
 			SystemSecretSystemRedisNamespace:                   *system.Options.redisNamespace,
 			SystemSecretSystemRedisMessageBusRedisNamespace:    *system.Options.messageBusRedisNamespace,
+			SystemSecretSystemRedisSentinelPassword:            *system.Options.redisSentinelPassword,
+			SystemSecretSystemRedisMessageBusSentinelPassword:  *system.Options.messageBusRedisSentinelPassword,
+			SystemSecretSystemRedisSSLCA:                       *system.Options.redisSSLCA,
+			SystemSecretSystemRedisMessageBusSSLCA:             *system.Options.messageBusRedisSSLCA,
 		},
 		Type: v1.SecretTypeOpaque,
 	}
@@ -534,6 +588,7 @@ func (system *System) appPodVolumes() []v1.Volume {
 	}
 
 	res = append(res, systemConfigVolume)
+	res = append(res, redisTLSVolumes(system.redisCAFiles())...)
 	return res
 }
 
@@ -542,6 +597,9 @@ func (system *System) volumeNamesForSystemAppPreHookPod() []string {
 	if system.Options.pvcFileStorageOptions != nil {
 		res = append(res, SystemFileStoragePVCName)
 	}
+	if len(system.redisCAFiles()) > 0 {
+		res = append(res, RedisTLSVolumeName)
+	}
 	return res
 }
 
@@ -755,6 +813,10 @@ func (system *System) AppDeploymentConfig() *appsv1.DeploymentConfig {
 						},
 					},
 					ServiceAccountName: "amp",
+					NodeSelector:       system.Options.appPodPlacement.NodeSelector,
+					Tolerations:        system.Options.appPodPlacement.Tolerations,
+					Affinity:           system.Options.appPodPlacement.Affinity,
+					PriorityClassName:  system.Options.appPodPlacement.PriorityClassName,
 				}},
 		},
 	}
@@ -809,6 +871,7 @@ func (system *System) SidekiqPodVolumes() []v1.Volume {
 	}
 
 	res = append(res, systemConfigVolume)
+	res = append(res, redisTLSVolumes(system.redisCAFiles())...)
 	return res
 }
 
@@ -867,7 +930,8 @@ func (system *System) SidekiqDeploymentConfig() *appsv1.DeploymentConfig {
 								"-c",
 								"bundle exec sh -c \"until rake boot:redis && curl --output /dev/null --silent --fail --head http://system-master:3000/status; do sleep $SLEEP_SECONDS; done\"",
 							},
-							Env: append(system.SystemRedisEnvVars(), envVarFromValue("SLEEP_SECONDS", "1")),
+							Env:          append(system.SystemRedisEnvVars(), envVarFromValue("SLEEP_SECONDS", "1")),
+							VolumeMounts: redisTLSVolumeMounts(system.systemRedisCAFiles()),
 						},
 					},
 					Containers: []v1.Container{
@@ -882,6 +946,10 @@ func (system *System) SidekiqDeploymentConfig() *appsv1.DeploymentConfig {
 						},
 					},
 					ServiceAccountName: "amp",
+					NodeSelector:       system.Options.sidekiqPodPlacement.NodeSelector,
+					Tolerations:        system.Options.sidekiqPodPlacement.Tolerations,
+					Affinity:           system.Options.sidekiqPodPlacement.Affinity,
+					PriorityClassName:  system.Options.sidekiqPodPlacement.PriorityClassName,
 				}},
 		},
 	}
@@ -909,6 +977,7 @@ func (system *System) appCommonContainerVolumeMounts(systemStorageReadonly bool)
 		res = append(res, system.systemStorageVolumeMount(systemStorageReadonly))
 	}
 	res = append(res, system.systemConfigVolumeMount())
+	res = append(res, redisTLSVolumeMounts(system.redisCAFiles())...)
 
 	return res
 }
@@ -939,6 +1008,7 @@ func (system *System) sidekiqContainerVolumeMounts() []v1.VolumeMount {
 	}
 	res = append(res, systemTmpVolumeMount)
 	res = append(res, system.systemConfigVolumeMount())
+	res = append(res, redisTLSVolumeMounts(system.redisCAFiles())...)
 	return res
 }
 
@@ -956,17 +1026,7 @@ func (system *System) SharedStorage() *v1.PersistentVolumeClaim {
 				"threescale_component_element": "app",
 			},
 		},
-		Spec: v1.PersistentVolumeClaimSpec{
-			StorageClassName: system.Options.pvcFileStorageOptions.StorageClass,
-			AccessModes: []v1.PersistentVolumeAccessMode{
-				v1.ReadWriteMany,
-			},
-			Resources: v1.ResourceRequirements{
-				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse("100Mi"),
-				},
-			},
-		},
+		Spec: system.Options.pvcFileStorageOptions.persistentVolumeClaimSpec(),
 	}
 }
 
@@ -1261,7 +1321,7 @@ func (system *System) SphinxDeploymentConfig() *appsv1.DeploymentConfig {
 							},
 						},
 					},
-					Volumes: []v1.Volume{
+					Volumes: append([]v1.Volume{
 						v1.Volume{
 							Name: "system-sphinx-database",
 							VolumeSource: v1.VolumeSource{
@@ -1270,19 +1330,19 @@ func (system *System) SphinxDeploymentConfig() *appsv1.DeploymentConfig {
 								},
 							},
 						},
-					},
+					}, redisTLSVolumes(system.systemRedisCAFiles())...),
 					Containers: []v1.Container{
 						v1.Container{
 							Name:            "system-sphinx",
 							Image:           "amp-system:latest",
 							ImagePullPolicy: v1.PullIfNotPresent,
 							Args:            []string{"rake", "openshift:thinking_sphinx:start"},
-							VolumeMounts: []v1.VolumeMount{
+							VolumeMounts: append([]v1.VolumeMount{
 								v1.VolumeMount{
 									Name:      "system-sphinx-database",
 									MountPath: "/opt/system/db/sphinx",
 								},
-							},
+							}, redisTLSVolumeMounts(system.systemRedisCAFiles())...),
 							Env: system.buildSystemSphinxEnv(),
 							LivenessProbe: &v1.Probe{
 								Handler: v1.Handler{
@@ -1296,6 +1356,10 @@ func (system *System) SphinxDeploymentConfig() *appsv1.DeploymentConfig {
 							Resources: *system.Options.sphinxContainerResourceRequirements,
 						},
 					},
+					NodeSelector:      system.Options.sphinxPodPlacement.NodeSelector,
+					Tolerations:       system.Options.sphinxPodPlacement.Tolerations,
+					Affinity:          system.Options.sphinxPodPlacement.Affinity,
+					PriorityClassName: system.Options.sphinxPodPlacement.PriorityClassName,
 				},
 			},
 		},
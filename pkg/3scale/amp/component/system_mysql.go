@@ -5,7 +5,6 @@ import (
 
 	appsv1 "github.com/openshift/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -102,11 +101,8 @@ func (mysql *SystemMysql) PersistentVolumeClaim() *v1.PersistentVolumeClaim {
 			Name:   "mysql-storage",
 			Labels: map[string]string{"threescale_component": "system", "threescale_component_element": "mysql", "app": mysql.Options.appLabel},
 		},
-		Spec: v1.PersistentVolumeClaimSpec{
-			AccessModes: []v1.PersistentVolumeAccessMode{
-				v1.PersistentVolumeAccessMode("ReadWriteOnce"),
-			},
-			Resources: v1.ResourceRequirements{Requests: v1.ResourceList{"storage": resource.MustParse("1Gi")}}}}
+		Spec: mysql.Options.pvcOptions.persistentVolumeClaimSpec(),
+	}
 }
 
 func (mysql *SystemMysql) DeploymentConfig() *appsv1.DeploymentConfig {
@@ -185,15 +181,7 @@ func (mysql *SystemMysql) DeploymentConfig() *appsv1.DeploymentConfig {
 								envVarFromValue("MYSQL_LOWER_CASE_TABLE_NAMES", "1"),
 								envVarFromValue("MYSQL_DEFAULTS_FILE", "/etc/my-extra/my.cnf"),
 							},
-							Resources: v1.ResourceRequirements{
-								Limits: v1.ResourceList{
-									v1.ResourceMemory: resource.MustParse("2Gi"),
-								},
-								Requests: v1.ResourceList{
-									v1.ResourceCPU:    resource.MustParse("250m"),
-									v1.ResourceMemory: resource.MustParse("512Mi"),
-								},
-							},
+							Resources: *mysql.Options.containerResourceRequirements,
 							VolumeMounts: []v1.VolumeMount{
 								v1.VolumeMount{
 									Name:      "mysql-storage",
@@ -234,6 +222,10 @@ func (mysql *SystemMysql) DeploymentConfig() *appsv1.DeploymentConfig {
 							ImagePullPolicy: v1.PullIfNotPresent,
 						},
 					},
+					NodeSelector:      mysql.Options.podPlacement.NodeSelector,
+					Tolerations:       mysql.Options.podPlacement.Tolerations,
+					Affinity:          mysql.Options.podPlacement.Affinity,
+					PriorityClassName: mysql.Options.podPlacement.PriorityClassName,
 				},
 			},
 		},
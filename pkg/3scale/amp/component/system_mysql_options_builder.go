@@ -18,6 +18,8 @@ type SystemMysqlOptions struct {
 
 	// non-required options
 	containerResourceRequirements *v1.ResourceRequirements
+	podPlacement                  *PodPlacement
+	pvcOptions                    *PVCOptions
 }
 
 type SystemMysqlOptionsBuilder struct {
@@ -52,6 +54,14 @@ func (m *SystemMysqlOptionsBuilder) ContainerResourceRequirements(resourceRequir
 	m.options.containerResourceRequirements = &resourceRequirements
 }
 
+func (m *SystemMysqlOptionsBuilder) PodPlacement(podPlacement PodPlacement) {
+	m.options.podPlacement = &podPlacement
+}
+
+func (m *SystemMysqlOptionsBuilder) PVCOptions(options PVCOptions) {
+	m.options.pvcOptions = &options
+}
+
 func (m *SystemMysqlOptionsBuilder) Build() (*SystemMysqlOptions, error) {
 	err := m.setRequiredOptions()
 	if err != nil {
@@ -90,6 +100,12 @@ func (m *SystemMysqlOptionsBuilder) setNonRequiredOptions() {
 	if m.options.containerResourceRequirements == nil {
 		m.options.containerResourceRequirements = m.defaultContainerResourceRequirements()
 	}
+
+	if m.options.podPlacement == nil {
+		m.options.podPlacement = &PodPlacement{}
+	}
+
+	m.options.pvcOptions = withPVCDefaults(m.options.pvcOptions, "1Gi", v1.ReadWriteOnce)
 }
 
 func (m *SystemMysqlOptionsBuilder) defaultContainerResourceRequirements() *v1.ResourceRequirements {
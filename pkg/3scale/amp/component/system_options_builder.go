@@ -21,10 +21,6 @@ type SystemSMTPSecretOptions struct {
 	Username          string
 }
 
-type PVCFileStorageOptions struct {
-	StorageClass *string
-}
-
 type SystemOptions struct {
 	// systemNonRequiredOptions
 	memcachedServers                       *string
@@ -37,6 +33,11 @@ type SystemOptions struct {
 	messageBusRedisSentinelHosts           *string
 	messageBusRedisSentinelRole            *string
 	messageBusRedisNamespace               *string
+	redisSentinelPassword                  *string
+	messageBusRedisSentinelPassword        *string
+	redisSSLCA                             *string
+	messageBusRedisSSLCA                   *string
+	backendRedisSSLCA                      *string
 	apicastSystemMasterProxyConfigEndpoint *string
 	apicastSystemMasterBaseURL             *string
 	adminEmail                             *string
@@ -48,11 +49,15 @@ type SystemOptions struct {
 	sphinxContainerResourceRequirements       *v1.ResourceRequirements
 
 	s3FileStorageOptions  *S3FileStorageOptions
-	pvcFileStorageOptions *PVCFileStorageOptions
+	pvcFileStorageOptions *PVCOptions
 
 	appReplicas     *int32
 	sidekiqReplicas *int32
 
+	appPodPlacement     *PodPlacement
+	sidekiqPodPlacement *PodPlacement
+	sphinxPodPlacement  *PodPlacement
+
 	// systemRequiredOptions
 	adminAccessToken    string
 	adminPassword       string
@@ -190,6 +195,28 @@ func (s *SystemOptionsBuilder) MessageBusRedisNamespace(namespace *string) {
 	s.options.messageBusRedisNamespace = namespace
 }
 
+func (s *SystemOptionsBuilder) RedisSentinelPassword(password *string) {
+	s.options.redisSentinelPassword = password
+}
+
+func (s *SystemOptionsBuilder) MessageBusRedisSentinelPassword(password *string) {
+	s.options.messageBusRedisSentinelPassword = password
+}
+
+func (s *SystemOptionsBuilder) RedisSSLCA(ca *string) {
+	s.options.redisSSLCA = ca
+}
+
+func (s *SystemOptionsBuilder) MessageBusRedisSSLCA(ca *string) {
+	s.options.messageBusRedisSSLCA = ca
+}
+
+// BackendRedisSSLCA is the CA bundle of the backend redis storage, kept in the
+// backend-redis secret. System mounts it when set
+func (s *SystemOptionsBuilder) BackendRedisSSLCA(ca *string) {
+	s.options.backendRedisSSLCA = ca
+}
+
 func (s *SystemOptionsBuilder) ApicastSystemMasterProxyConfigEndpoint(endpoint *string) {
 	s.options.apicastSystemMasterProxyConfigEndpoint = endpoint
 }
@@ -222,7 +249,7 @@ func (s *SystemOptionsBuilder) S3FileStorageOptions(options S3FileStorageOptions
 	s.options.s3FileStorageOptions = &options
 }
 
-func (s *SystemOptionsBuilder) PVCFileStorageOptions(options PVCFileStorageOptions) {
+func (s *SystemOptionsBuilder) PVCFileStorageOptions(options PVCOptions) {
 	s.options.pvcFileStorageOptions = &options
 }
 
@@ -238,6 +265,18 @@ func (s *SystemOptionsBuilder) SystemSMTPSecretOptions(options SystemSMTPSecretO
 	s.options.smtpSecretOptions = options
 }
 
+func (s *SystemOptionsBuilder) AppPodPlacement(podPlacement PodPlacement) {
+	s.options.appPodPlacement = &podPlacement
+}
+
+func (s *SystemOptionsBuilder) SidekiqPodPlacement(podPlacement PodPlacement) {
+	s.options.sidekiqPodPlacement = &podPlacement
+}
+
+func (s *SystemOptionsBuilder) SphinxPodPlacement(podPlacement PodPlacement) {
+	s.options.sphinxPodPlacement = &podPlacement
+}
+
 func (s *SystemOptionsBuilder) Build() (*SystemOptions, error) {
 	err := s.setRequiredOptions()
 	if err != nil {
@@ -315,6 +354,11 @@ func (s *SystemOptionsBuilder) setNonRequiredOptions() {
 		s.options.eventHooksURL = &defaultEventHooksURL
 	}
 
+	// file storage options are only set when the PVC file storage is used
+	if s.options.pvcFileStorageOptions != nil {
+		s.options.pvcFileStorageOptions = withPVCDefaults(s.options.pvcFileStorageOptions, "100Mi", v1.ReadWriteMany)
+	}
+
 	s.setRedisDefaultsOptions()
 
 	if s.options.apicastSystemMasterProxyConfigEndpoint == nil {
@@ -358,6 +402,18 @@ func (s *SystemOptionsBuilder) setNonRequiredOptions() {
 		var defaultSidekiqReplicas int32 = 1
 		s.options.sidekiqReplicas = &defaultSidekiqReplicas
 	}
+
+	if s.options.appPodPlacement == nil {
+		s.options.appPodPlacement = &PodPlacement{}
+	}
+
+	if s.options.sidekiqPodPlacement == nil {
+		s.options.sidekiqPodPlacement = &PodPlacement{}
+	}
+
+	if s.options.sphinxPodPlacement == nil {
+		s.options.sphinxPodPlacement = &PodPlacement{}
+	}
 }
 
 func (s *SystemOptionsBuilder) setRedisDefaultsOptions() {
@@ -369,6 +425,11 @@ func (s *SystemOptionsBuilder) setRedisDefaultsOptions() {
 	defaultRedisSentinelRole := ""
 	defaultMessageBusRedisSentinelHosts := ""
 	defaultMessageBusRedisSentinelRole := ""
+	defaultRedisSentinelPassword := ""
+	defaultMessageBusRedisSentinelPassword := ""
+	defaultRedisSSLCA := ""
+	defaultMessageBusRedisSSLCA := ""
+	defaultBackendRedisSSLCA := ""
 
 	if s.options.redisURL == nil {
 		s.options.redisURL = &defaultRedisURL
@@ -401,6 +462,26 @@ func (s *SystemOptionsBuilder) setRedisDefaultsOptions() {
 	if s.options.messageBusRedisNamespace == nil {
 		s.options.messageBusRedisNamespace = &defaultMessageBusRedisNamespace
 	}
+
+	if s.options.redisSentinelPassword == nil {
+		s.options.redisSentinelPassword = &defaultRedisSentinelPassword
+	}
+
+	if s.options.messageBusRedisSentinelPassword == nil {
+		s.options.messageBusRedisSentinelPassword = &defaultMessageBusRedisSentinelPassword
+	}
+
+	if s.options.redisSSLCA == nil {
+		s.options.redisSSLCA = &defaultRedisSSLCA
+	}
+
+	if s.options.messageBusRedisSSLCA == nil {
+		s.options.messageBusRedisSSLCA = &defaultMessageBusRedisSSLCA
+	}
+
+	if s.options.backendRedisSSLCA == nil {
+		s.options.backendRedisSSLCA = &defaultBackendRedisSSLCA
+	}
 }
 
 func (s *SystemOptionsBuilder) defaultAppMasterContainerResourceRequirements() *v1.ResourceRequirements {
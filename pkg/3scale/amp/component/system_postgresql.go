@@ -5,7 +5,6 @@ import (
 
 	appsv1 "github.com/openshift/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -72,16 +71,7 @@ func (p *SystemPostgreSQL) DataPersistentVolumeClaim() *v1.PersistentVolumeClaim
 			Name:   "postgresql-data",
 			Labels: map[string]string{"threescale_component": "system", "threescale_component_element": "postgresql", "app": p.Options.appLabel},
 		},
-		Spec: v1.PersistentVolumeClaimSpec{
-			AccessModes: []v1.PersistentVolumeAccessMode{
-				v1.PersistentVolumeAccessMode("ReadWriteOnce"),
-			},
-			Resources: v1.ResourceRequirements{
-				Requests: v1.ResourceList{
-					"storage": resource.MustParse("1Gi"),
-				},
-			},
-		},
+		Spec: p.Options.pvcOptions.persistentVolumeClaimSpec(),
 	}
 }
 
@@ -188,6 +178,10 @@ func (p *SystemPostgreSQL) DeploymentConfig() *appsv1.DeploymentConfig {
 							ImagePullPolicy: v1.PullIfNotPresent,
 						},
 					},
+					NodeSelector:      p.Options.podPlacement.NodeSelector,
+					Tolerations:       p.Options.podPlacement.Tolerations,
+					Affinity:          p.Options.podPlacement.Affinity,
+					PriorityClassName: p.Options.podPlacement.PriorityClassName,
 				},
 			},
 		},
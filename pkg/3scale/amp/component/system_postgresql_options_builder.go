@@ -10,6 +10,8 @@ import (
 type SystemPostgreSQLOptions struct {
 	//systemPostgreSQLNonRequiredOptions
 	containerResourceRequirements *v1.ResourceRequirements
+	podPlacement                  *PodPlacement
+	pvcOptions                    *PVCOptions
 
 	//systemPostgreSQLRequiredOptions
 	ampRelease   string
@@ -49,6 +51,14 @@ func (b *SystemPostgreSQLOptionsBuilder) ContainerResourceRequirements(resourceR
 	b.options.containerResourceRequirements = &resourceRequirements
 }
 
+func (b *SystemPostgreSQLOptionsBuilder) PodPlacement(podPlacement PodPlacement) {
+	b.options.podPlacement = &podPlacement
+}
+
+func (b *SystemPostgreSQLOptionsBuilder) PVCOptions(options PVCOptions) {
+	b.options.pvcOptions = &options
+}
+
 func (b *SystemPostgreSQLOptionsBuilder) Build() (*SystemPostgreSQLOptions, error) {
 	err := b.setRequiredOptions()
 	if err != nil {
@@ -84,6 +94,12 @@ func (b *SystemPostgreSQLOptionsBuilder) setNonRequiredOptions() {
 	if b.options.containerResourceRequirements == nil {
 		b.options.containerResourceRequirements = b.defaultContainerResourceRequirements()
 	}
+
+	if b.options.podPlacement == nil {
+		b.options.podPlacement = &PodPlacement{}
+	}
+
+	b.options.pvcOptions = withPVCDefaults(b.options.pvcOptions, "1Gi", v1.ReadWriteOnce)
 }
 
 func (m *SystemPostgreSQLOptionsBuilder) defaultContainerResourceRequirements() *v1.ResourceRequirements {
@@ -17,6 +17,15 @@ const (
 	ZyncSecretDatabaseURLFieldName         = "DATABASE_URL"
 	ZyncSecretDatabasePasswordFieldName    = "ZYNC_DATABASE_PASSWORD"
 	ZyncSecretAuthenticationTokenFieldName = "ZYNC_AUTHENTICATION_TOKEN"
+	// ZyncSecretPreviousAuthenticationTokenFieldName keeps the replaced token
+	// while a credential rotation is in progress, so zync accepts both
+	ZyncSecretPreviousAuthenticationTokenFieldName = "ZYNC_PREVIOUS_AUTHENTICATION_TOKEN"
+
+	ZyncDatabaseDataVolumeName = "zync-database-data"
+
+	// ZyncDisableRoutesCreationEnvVarName stops zync-que from creating Routes
+	// for the tenants and the API products
+	ZyncDisableRoutesCreationEnvVarName = "DISABLE_K8S_ROUTES_CREATION"
 )
 
 type Zync struct {
@@ -54,6 +63,10 @@ func (zync *Zync) Objects() []common.KubernetesObject {
 		databaseService,
 		secret,
 	}
+
+	if databasePVC := zync.DatabasePVC(); databasePVC != nil {
+		objects = append(objects, databasePVC)
+	}
 	return objects
 }
 
@@ -314,6 +327,10 @@ func (zync *Zync) DeploymentConfig() *appsv1.DeploymentConfig {
 							Resources: *zync.Options.containerResourceRequirements,
 						},
 					},
+					NodeSelector:      zync.Options.zyncPodPlacement.NodeSelector,
+					Tolerations:       zync.Options.zyncPodPlacement.Tolerations,
+					Affinity:          zync.Options.zyncPodPlacement.Affinity,
+					PriorityClassName: zync.Options.zyncPodPlacement.PriorityClassName,
 				},
 			},
 		},
@@ -327,6 +344,7 @@ func (zync *Zync) commonZyncEnvVars() []v1.EnvVar {
 		envVarFromSecret("DATABASE_URL", "zync", "DATABASE_URL"),
 		envVarFromSecret("SECRET_KEY_BASE", "zync", "SECRET_KEY_BASE"),
 		envVarFromSecret("ZYNC_AUTHENTICATION_TOKEN", "zync", "ZYNC_AUTHENTICATION_TOKEN"),
+		envVarFromSecretOptional("ZYNC_PREVIOUS_AUTHENTICATION_TOKEN", ZyncSecretName, ZyncSecretPreviousAuthenticationTokenFieldName),
 		v1.EnvVar{
 			Name: "POD_NAME",
 			ValueFrom: &v1.EnvVarSource{
@@ -347,6 +365,14 @@ func (zync *Zync) commonZyncEnvVars() []v1.EnvVar {
 		},
 	}
 }
+func (zync *Zync) queEnvVars() []v1.EnvVar {
+	env := zync.commonZyncEnvVars()
+	if zync.Options.routeCreationDisabled {
+		env = append(env, envVarFromValue(ZyncDisableRoutesCreationEnvVarName, "1"))
+	}
+	return env
+}
+
 func (zync *Zync) QueDeploymentConfig() *appsv1.DeploymentConfig {
 	return &appsv1.DeploymentConfig{
 		TypeMeta: metav1.TypeMeta{
@@ -437,9 +463,13 @@ func (zync *Zync) QueDeploymentConfig() *appsv1.DeploymentConfig {
 								v1.ContainerPort{Name: "metrics", ContainerPort: 9394, Protocol: v1.ProtocolTCP},
 							},
 							Resources: *zync.Options.queContainerResourceRequirements,
-							Env:       zync.commonZyncEnvVars(),
+							Env:       zync.queEnvVars(),
 						},
 					},
+					NodeSelector:      zync.Options.zyncQuePodPlacement.NodeSelector,
+					Tolerations:       zync.Options.zyncQuePodPlacement.Tolerations,
+					Affinity:          zync.Options.zyncQuePodPlacement.Affinity,
+					PriorityClassName: zync.Options.zyncQuePodPlacement.PriorityClassName,
 				},
 			},
 		},
@@ -553,18 +583,61 @@ func (zync *Zync) DatabaseDeploymentConfig() *appsv1.DeploymentConfig {
 						},
 					},
 					Volumes: []v1.Volume{
-						v1.Volume{
-							Name: "zync-database-data",
-							VolumeSource: v1.VolumeSource{
-								EmptyDir: &v1.EmptyDirVolumeSource{
-									Medium: v1.StorageMediumDefault,
-								},
-							},
-						},
+						zync.databaseDataVolume(),
 					},
+					NodeSelector:      zync.Options.databasePodPlacement.NodeSelector,
+					Tolerations:       zync.Options.databasePodPlacement.Tolerations,
+					Affinity:          zync.Options.databasePodPlacement.Affinity,
+					PriorityClassName: zync.Options.databasePodPlacement.PriorityClassName,
+				},
+			},
+		},
+	}
+}
+
+func (zync *Zync) databaseDataVolume() v1.Volume {
+	if zync.Options.databasePVCOptions != nil {
+		return v1.Volume{
+			Name: ZyncDatabaseDataVolumeName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: ZyncDatabaseDataVolumeName,
 				},
 			},
+		}
+	}
+
+	return v1.Volume{
+		Name: ZyncDatabaseDataVolumeName,
+		VolumeSource: v1.VolumeSource{
+			EmptyDir: &v1.EmptyDirVolumeSource{
+				Medium: v1.StorageMediumDefault,
+			},
+		},
+	}
+}
+
+// DatabasePVC returns the PersistentVolumeClaim of the zync database, or nil
+// when the zync database uses an emptyDir volume
+func (zync *Zync) DatabasePVC() *v1.PersistentVolumeClaim {
+	if zync.Options.databasePVCOptions == nil {
+		return nil
+	}
+
+	return &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ZyncDatabaseDataVolumeName,
+			Labels: map[string]string{
+				"app":                          zync.Options.appLabel,
+				"threescale_component":         "zync",
+				"threescale_component_element": "database",
+			},
 		},
+		Spec: zync.Options.databasePVCOptions.persistentVolumeClaimSpec(),
 	}
 }
 
@@ -15,6 +15,11 @@ type ZyncOptions struct {
 	databaseContainerResourceRequirements *v1.ResourceRequirements
 	zyncReplicas                          *int32
 	zyncQueReplicas                       *int32
+	zyncPodPlacement                      *PodPlacement
+	zyncQuePodPlacement                   *PodPlacement
+	databasePodPlacement                  *PodPlacement
+	databasePVCOptions                    *PVCOptions
+	routeCreationDisabled                 bool
 
 	// zyncRequiredOptions
 	appLabel            string
@@ -67,6 +72,30 @@ func (z *ZyncOptionsBuilder) ZyncQueReplicas(replicas int32) {
 	z.options.zyncQueReplicas = &replicas
 }
 
+func (z *ZyncOptionsBuilder) ZyncPodPlacement(podPlacement PodPlacement) {
+	z.options.zyncPodPlacement = &podPlacement
+}
+
+func (z *ZyncOptionsBuilder) ZyncQuePodPlacement(podPlacement PodPlacement) {
+	z.options.zyncQuePodPlacement = &podPlacement
+}
+
+func (z *ZyncOptionsBuilder) DatabasePodPlacement(podPlacement PodPlacement) {
+	z.options.databasePodPlacement = &podPlacement
+}
+
+// DatabasePVCOptions stores the zync database in a PersistentVolumeClaim
+// instead of an emptyDir volume
+func (z *ZyncOptionsBuilder) DatabasePVCOptions(options PVCOptions) {
+	z.options.databasePVCOptions = &options
+}
+
+// RouteCreationDisabled stops zync from creating the Routes of the tenants
+// and the API products, like when the operator manages them
+func (z *ZyncOptionsBuilder) RouteCreationDisabled(disabled bool) {
+	z.options.routeCreationDisabled = disabled
+}
+
 func (z *ZyncOptionsBuilder) Build() (*ZyncOptions, error) {
 	err := z.setRequiredOptions()
 	if err != nil {
@@ -122,6 +151,22 @@ func (z *ZyncOptionsBuilder) setNonRequiredOptions() {
 		var defaultZyncQueReplicas int32 = 1
 		z.options.zyncQueReplicas = &defaultZyncQueReplicas
 	}
+
+	if z.options.zyncPodPlacement == nil {
+		z.options.zyncPodPlacement = &PodPlacement{}
+	}
+
+	if z.options.zyncQuePodPlacement == nil {
+		z.options.zyncQuePodPlacement = &PodPlacement{}
+	}
+
+	if z.options.databasePodPlacement == nil {
+		z.options.databasePodPlacement = &PodPlacement{}
+	}
+
+	if z.options.databasePVCOptions != nil {
+		z.options.databasePVCOptions = withPVCDefaults(z.options.databasePVCOptions, "1Gi", v1.ReadWriteOnce)
+	}
 }
 
 func (z *ZyncOptionsBuilder) defaultContainerResourceRequirements() *v1.ResourceRequirements {
@@ -19,6 +19,7 @@ func (o *OperatorApicastOptionsProvider) GetApicastOptions() (*component.Apicast
 
 	o.setResourceRequirementsOptions(&optProv)
 	o.setReplicas(&optProv)
+	o.setPodPlacementOptions(&optProv)
 	res, err := optProv.Build()
 	if err != nil {
 		return nil, fmt.Errorf("unable to create Apicast Options - %s", err)
@@ -31,9 +32,29 @@ func (o *OperatorApicastOptionsProvider) setResourceRequirementsOptions(b *compo
 		b.StagingResourceRequirements(v1.ResourceRequirements{})
 		b.ProductionResourceRequirements(v1.ResourceRequirements{})
 	}
+
+	if o.APIManagerSpec.Apicast.StagingSpec.Resources != nil {
+		b.StagingResourceRequirements(*o.APIManagerSpec.Apicast.StagingSpec.Resources)
+	}
+	if o.APIManagerSpec.Apicast.ProductionSpec.Resources != nil {
+		b.ProductionResourceRequirements(*o.APIManagerSpec.Apicast.ProductionSpec.Resources)
+	}
 }
 
 func (o *OperatorApicastOptionsProvider) setReplicas(b *component.ApicastOptionsBuilder) {
 	b.StagingReplicas(int32(*o.APIManagerSpec.Apicast.StagingSpec.Replicas))
 	b.ProductionReplicas(int32(*o.APIManagerSpec.Apicast.ProductionSpec.Replicas))
+
+	productionAutoscaling := o.APIManagerSpec.Apicast.ProductionSpec.Autoscaling
+	if productionAutoscaling.IsEnabled() {
+		autoscaling := autoscalingFromSpec(productionAutoscaling, int32(*o.APIManagerSpec.Apicast.ProductionSpec.Replicas))
+		b.ProductionAutoscaling(autoscaling)
+		// Initial replicas of the DeploymentConfig, afterwards managed by the HPA
+		b.ProductionReplicas(autoscaling.MinReplicas)
+	}
+}
+
+func (o *OperatorApicastOptionsProvider) setPodPlacementOptions(b *component.ApicastOptionsBuilder) {
+	b.StagingPodPlacement(podPlacementFromSpec(o.APIManagerSpec.Apicast.StagingSpec.PodPlacementSpec))
+	b.ProductionPodPlacement(podPlacementFromSpec(o.APIManagerSpec.Apicast.ProductionSpec.PodPlacementSpec))
 }
@@ -48,6 +48,37 @@ func (r *ApicastStagingDCReconciler) IsUpdateNeeded(desired, existing *appsv1.De
 	tmpUpdate := DeploymentConfigReconcileReplicas(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
+	return update
+}
+
+type ApicastProductionDCReconciler struct {
+	BaseAPIManagerLogicReconciler
+}
+
+func NewApicastProductionDCReconciler(baseAPIManagerLogicReconciler BaseAPIManagerLogicReconciler) *ApicastProductionDCReconciler {
+	return &ApicastProductionDCReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *ApicastProductionDCReconciler) IsUpdateNeeded(desired, existing *appsv1.DeploymentConfig) bool {
+	update := false
+
+	// replicas are managed by the HorizontalPodAutoscaler when autoscaling is enabled
+	if !r.apiManager.IsApicastProductionAutoscalingEnabled() {
+		tmpUpdate := DeploymentConfigReconcileReplicas(desired, existing, r.Logger())
+		update = update || tmpUpdate
+	}
+
+	tmpUpdate := DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	tmpUpdate = DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
@@ -108,6 +139,11 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	err = r.reconcileHorizontalPodAutoscaler(apicast.ProductionHorizontalPodAutoscaler(), r.apiManager.IsApicastProductionAutoscalingEnabled())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -126,7 +162,7 @@ func (r *ApicastReconciler) reconcileStagingDeploymentConfig(desiredDeploymentCo
 }
 
 func (r *ApicastReconciler) reconcileProductionDeploymentConfig(desiredDeploymentConfig *appsv1.DeploymentConfig) error {
-	reconciler := NewDeploymentConfigBaseReconciler(r.BaseAPIManagerLogicReconciler, NewApicastDCReconciler(r.BaseAPIManagerLogicReconciler))
+	reconciler := NewDeploymentConfigBaseReconciler(r.BaseAPIManagerLogicReconciler, NewApicastProductionDCReconciler(r.BaseAPIManagerLogicReconciler))
 	return reconciler.Reconcile(desiredDeploymentConfig)
 }
 
@@ -24,6 +24,7 @@ func (o *OperatorBackendOptionsProvider) GetBackendOptions() (*component.Backend
 
 	o.setResourceRequirementsOptions(&optProv)
 	o.setReplicas(&optProv)
+	o.setPodPlacementOptions(&optProv)
 
 	res, err := optProv.Build()
 	if err != nil {
@@ -55,13 +56,23 @@ func (o *OperatorBackendOptionsProvider) setResourceRequirementsOptions(b *compo
 		b.WorkerResourceRequirements(v1.ResourceRequirements{})
 		b.CronResourceRequirements(v1.ResourceRequirements{})
 	}
+
+	if o.APIManagerSpec.Backend.ListenerSpec.Resources != nil {
+		b.ListenerResourceRequirements(*o.APIManagerSpec.Backend.ListenerSpec.Resources)
+	}
+	if o.APIManagerSpec.Backend.WorkerSpec.Resources != nil {
+		b.WorkerResourceRequirements(*o.APIManagerSpec.Backend.WorkerSpec.Resources)
+	}
+	if o.APIManagerSpec.Backend.CronSpec.Resources != nil {
+		b.CronResourceRequirements(*o.APIManagerSpec.Backend.CronSpec.Resources)
+	}
 }
 
 func (o *OperatorBackendOptionsProvider) setBackendInternalApiOptions(b *component.BackendOptionsBuilder) error {
 	defaultSystemBackendUsername := "3scale_api_user"
 	defaultSystemBackendPassword := oprand.String(8)
 
-	currSecret, err := helper.GetSecret(component.BackendSecretInternalApiSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.BackendSecretInternalApiSecretName, o.Namespace, o.Client)
 	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
@@ -75,20 +86,26 @@ func (o *OperatorBackendOptionsProvider) setBackendInternalApiOptions(b *compone
 }
 
 func (o *OperatorBackendOptionsProvider) setBackendListenerOptions(b *component.BackendOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.BackendSecretBackendListenerSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.BackendSecretBackendListenerSecretName, o.Namespace, o.Client)
 	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
 	secretData := currSecret.Data
 	b.ListenerServiceEndpoint(helper.GetSecretDataValue(secretData, component.BackendSecretBackendListenerServiceEndpointFieldName))
-	b.ListenerRouteEndpoint(helper.GetSecretDataValue(secretData, component.BackendSecretBackendListenerRouteEndpointFieldName))
+	routeEndpoint := helper.GetSecretDataValue(secretData, component.BackendSecretBackendListenerRouteEndpointFieldName)
+	if routeEndpoint == nil && o.APIManagerSpec.Exposure != nil &&
+		o.APIManagerSpec.Exposure.Backend != nil && o.APIManagerSpec.Exposure.Backend.Host != nil {
+		endpoint := "https://" + *o.APIManagerSpec.Exposure.Backend.Host
+		routeEndpoint = &endpoint
+	}
+	b.ListenerRouteEndpoint(routeEndpoint)
 
 	return nil
 }
 
 func (o *OperatorBackendOptionsProvider) setBackendRedisOptions(b *component.BackendOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.BackendSecretBackendRedisSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.BackendSecretBackendRedisSecretName, o.Namespace, o.Client)
 	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
@@ -100,6 +117,10 @@ func (o *OperatorBackendOptionsProvider) setBackendRedisOptions(b *component.Bac
 	b.RedisStorageSentinelRole(helper.GetSecretDataValue(secretData, component.BackendSecretBackendRedisStorageSentinelRoleFieldName))
 	b.RedisQueuesSentinelHosts(helper.GetSecretDataValue(secretData, component.BackendSecretBackendRedisQueuesSentinelHostsFieldName))
 	b.RedisQueuesSentinelRole(helper.GetSecretDataValue(secretData, component.BackendSecretBackendRedisQueuesSentinelRoleFieldName))
+	b.RedisStorageSentinelPassword(helper.GetSecretDataValue(secretData, component.BackendSecretBackendRedisStorageSentinelPasswordFieldName))
+	b.RedisQueuesSentinelPassword(helper.GetSecretDataValue(secretData, component.BackendSecretBackendRedisQueuesSentinelPasswordFieldName))
+	b.RedisStorageSSLCA(helper.GetSecretDataValue(secretData, component.BackendSecretBackendRedisStorageSSLCAFieldName))
+	b.RedisQueuesSSLCA(helper.GetSecretDataValue(secretData, component.BackendSecretBackendRedisQueuesSSLCAFieldName))
 
 	return nil
 }
@@ -108,4 +129,18 @@ func (o *OperatorBackendOptionsProvider) setReplicas(b *component.BackendOptions
 	b.ListenerReplicas(int32(*o.APIManagerSpec.Backend.ListenerSpec.Replicas))
 	b.WorkerReplicas(int32(*o.APIManagerSpec.Backend.WorkerSpec.Replicas))
 	b.CronReplicas(int32(*o.APIManagerSpec.Backend.CronSpec.Replicas))
+
+	listenerAutoscaling := o.APIManagerSpec.Backend.ListenerSpec.Autoscaling
+	if listenerAutoscaling.IsEnabled() {
+		autoscaling := autoscalingFromSpec(listenerAutoscaling, int32(*o.APIManagerSpec.Backend.ListenerSpec.Replicas))
+		b.ListenerAutoscaling(autoscaling)
+		// Initial replicas of the DeploymentConfig, afterwards managed by the HPA
+		b.ListenerReplicas(autoscaling.MinReplicas)
+	}
+}
+
+func (o *OperatorBackendOptionsProvider) setPodPlacementOptions(b *component.BackendOptionsBuilder) {
+	b.ListenerPodPlacement(podPlacementFromSpec(o.APIManagerSpec.Backend.ListenerSpec.PodPlacementSpec))
+	b.WorkerPodPlacement(podPlacementFromSpec(o.APIManagerSpec.Backend.WorkerSpec.PodPlacementSpec))
+	b.CronPodPlacement(podPlacementFromSpec(o.APIManagerSpec.Backend.CronSpec.PodPlacementSpec))
 }
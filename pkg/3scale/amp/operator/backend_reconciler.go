@@ -24,9 +24,15 @@ func (r *BackendWorkerDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Dep
 	tmpUpdate := DeploymentConfigReconcileReplicas(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	tmpUpdate = DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcileRedisConnection(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -43,12 +49,25 @@ func NewBackendListenerDCReconciler(baseAPIManagerLogicReconciler BaseAPIManager
 func (r *BackendListenerDCReconciler) IsUpdateNeeded(desired, existing *appsv1.DeploymentConfig) bool {
 	update := false
 
-	tmpUpdate := DeploymentConfigReconcileReplicas(desired, existing, r.Logger())
+	// replicas are managed by the HorizontalPodAutoscaler when autoscaling is enabled
+	if !r.apiManager.IsBackendListenerAutoscalingEnabled() {
+		tmpUpdate := DeploymentConfigReconcileReplicas(desired, existing, r.Logger())
+		update = update || tmpUpdate
+	}
+
+	tmpUpdate := DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
 	tmpUpdate = DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcileRedisConnection(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
+	// accepts the previous password while the credential is rotated
+	tmpUpdate = DeploymentConfigReconcileContainerEnvVar(desired, existing, "CONFIG_INTERNAL_API_PREVIOUS_PASSWORD", r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -68,9 +87,15 @@ func (r *BackendCronDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Deplo
 	tmpUpdate := DeploymentConfigReconcileReplicas(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	tmpUpdate = DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcileRedisConnection(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -108,9 +133,13 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.reconcileListenerRoute(backend.ListenerRoute())
-	if err != nil {
-		return reconcile.Result{}, err
+	// the route is managed by the ExposureReconciler when the exposure is set.
+	// Routes are not available in the Kubernetes platform
+	if !r.apiManager.IsExposureManaged() && !r.apiManager.IsKubernetesPlatform() {
+		err = r.reconcileListenerRoute(backend.ListenerRoute())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
 	err = r.reconcileWorkerDeploymentConfig(backend.WorkerDeploymentConfig())
@@ -153,6 +182,11 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	err = r.reconcileHorizontalPodAutoscaler(backend.ListenerHorizontalPodAutoscaler(), r.apiManager.IsBackendListenerAutoscalingEnabled())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -0,0 +1,183 @@
+package operator
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type OperatorBackupOptionsProvider struct {
+	APIManager *appsv1alpha1.APIManager
+	// Name of the APIManagerBackup or APIManagerRestore
+	Name string
+	// Name of the APIManagerBackup whose data is stored or restored
+	BackupName  string
+	Destination *appsv1alpha1.APIManagerBackupDestinationSpec
+	Client      k8sclient.Client
+}
+
+// BackupPersistentVolumeClaimName returns the name of the
+// PersistentVolumeClaim where the given backup is stored
+func BackupPersistentVolumeClaimName(backupName string) string {
+	return fmt.Sprintf("%s-backup", backupName)
+}
+
+func (o *OperatorBackupOptionsProvider) GetBackupOptions() (*component.BackupOptions, error) {
+	b := component.BackupOptionsBuilder{}
+	b.Name(o.Name)
+	b.AppLabel(*o.APIManager.Spec.AppLabel)
+
+	if o.Destination.PersistentVolumeClaim != nil {
+		pvcSpec := o.Destination.PersistentVolumeClaim
+		pvc := component.BackupPersistentVolumeClaim{
+			Name:             BackupPersistentVolumeClaimName(o.BackupName),
+			StorageClassName: pvcSpec.StorageClassName,
+			VolumeName:       helper.GetStringPointerValueOrDefault(pvcSpec.VolumeName, ""),
+		}
+		if pvcSpec.StorageRequest != nil {
+			storageRequest, err := resource.ParseQuantity(*pvcSpec.StorageRequest)
+			if err != nil {
+				return nil, err
+			}
+			pvc.StorageRequest = storageRequest
+		}
+		b.PersistentVolumeClaim(pvc)
+	}
+
+	if o.Destination.SimpleStorageService != nil {
+		s3Spec := o.Destination.SimpleStorageService
+		b.S3(component.BackupS3{
+			ConfigurationSecretName: s3Spec.ConfigurationSecretRef.Name,
+			Path:                    strings.Trim(helper.GetStringPointerValueOrDefault(s3Spec.Path, o.BackupName), "/"),
+		})
+	}
+
+	images, err := apiManagerImages(o.APIManager, o.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	// External databases are managed, and backed up, by the user
+	if !o.APIManager.IsExternalDatabaseEnabled() {
+		err = o.setDatabaseOptions(&b, images)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if o.isSystemStoragePVC() {
+		b.SystemStoragePVCName("system-storage")
+		b.SystemImage(images["amp-system:latest"])
+	}
+
+	return b.Build()
+}
+
+// BackupSecrets returns the existing secrets stored by the backup, indexed by their
+// APIManager secret name. The secrets with a secret source are read from the source
+func (o *OperatorBackupOptionsProvider) BackupSecrets() (map[string]*v1.Secret, error) {
+	secrets := map[string]*v1.Secret{}
+	for _, secretName := range component.BackupSecretNames() {
+		secret, err := getAPIManagerSecret(&o.APIManager.Spec, secretName, o.APIManager.Namespace, o.Client)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		secrets[secretName] = secret
+	}
+	return secrets, nil
+}
+
+func (o *OperatorBackupOptionsProvider) setDatabaseOptions(b *component.BackupOptionsBuilder, images map[string]string) error {
+	namespace := o.APIManager.Namespace
+
+	systemDatabaseSecret, err := getAPIManagerSecret(&o.APIManager.Spec, component.SystemSecretSystemDatabaseSecretName, namespace, o.Client)
+	if err != nil {
+		return err
+	}
+	systemDatabaseURL, err := secretURL(systemDatabaseSecret.Data, component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName)
+	if err != nil {
+		return err
+	}
+	systemDatabase := component.BackupSystemDatabase{
+		Type:         component.BackupSystemDatabaseTypeMySQL,
+		Image:        images["system-mysql:latest"],
+		Host:         systemDatabaseURL.Hostname(),
+		DatabaseName: strings.TrimPrefix(systemDatabaseURL.Path, "/"),
+	}
+	if o.isSystemDatabasePostgreSQL() {
+		systemDatabase.Type = component.BackupSystemDatabaseTypePostgreSQL
+		systemDatabase.Image = images["system-postgresql:latest"]
+	}
+	b.SystemDatabase(systemDatabase)
+
+	backendRedisSecret, err := getAPIManagerSecret(&o.APIManager.Spec, component.BackendSecretBackendRedisSecretName, namespace, o.Client)
+	if err != nil {
+		return err
+	}
+	backendRedisURL, err := secretURL(backendRedisSecret.Data, component.BackendSecretBackendRedisSecretName, component.BackendSecretBackendRedisStorageURLFieldName)
+	if err != nil {
+		return err
+	}
+	b.BackendRedis(component.BackupRedis{
+		Image:                     images["backend-redis:latest"],
+		Host:                      backendRedisURL.Hostname(),
+		Port:                      redisPort(backendRedisURL),
+		PersistentVolumeClaimName: "backend-redis-storage",
+		DeploymentName:            "backend-redis",
+	})
+
+	systemRedisSecret, err := getAPIManagerSecret(&o.APIManager.Spec, component.SystemSecretSystemRedisSecretName, namespace, o.Client)
+	if err != nil {
+		return err
+	}
+	systemRedisURL, err := secretURL(systemRedisSecret.Data, component.SystemSecretSystemRedisSecretName, component.SystemSecretSystemRedisURLFieldName)
+	if err != nil {
+		return err
+	}
+	b.SystemRedis(component.BackupRedis{
+		Image:                     images["system-redis:latest"],
+		Host:                      systemRedisURL.Hostname(),
+		Port:                      redisPort(systemRedisURL),
+		PersistentVolumeClaimName: "system-redis-storage",
+		DeploymentName:            "system-redis",
+	})
+
+	return nil
+}
+
+func (o *OperatorBackupOptionsProvider) isSystemDatabasePostgreSQL() bool {
+	system := o.APIManager.Spec.System
+	return system != nil && system.DatabaseSpec != nil && system.DatabaseSpec.PostgreSQL != nil
+}
+
+func (o *OperatorBackupOptionsProvider) isSystemStoragePVC() bool {
+	system := o.APIManager.Spec.System
+	return system == nil || system.FileStorageSpec == nil ||
+		(system.FileStorageSpec.S3 == nil && system.FileStorageSpec.DeprecatedS3 == nil)
+}
+
+func secretURL(secretData map[string][]byte, secretName, fieldName string) (*url.URL, error) {
+	rawURL := helper.GetSecretDataValueOrDefault(secretData, fieldName, "")
+	if rawURL == "" {
+		return nil, fmt.Errorf("secret '%s' field '%s' is missing", secretName, fieldName)
+	}
+	return url.Parse(rawURL)
+}
+
+func redisPort(redisURL *url.URL) string {
+	if redisURL.Port() != "" {
+		return redisURL.Port()
+	}
+	return "6379"
+}
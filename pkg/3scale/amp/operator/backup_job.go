@@ -0,0 +1,170 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type BackupJobState string
+
+const (
+	BackupJobRunning   BackupJobState = "Running"
+	BackupJobSucceeded BackupJobState = "Succeeded"
+	BackupJobFailed    BackupJobState = "Failed"
+)
+
+// EnsureBackupJob creates the given job, owned by the given backup or
+// restore, unless it already exists, and returns the state of the job
+func EnsureBackupJob(cl client.Client, scheme *runtime.Scheme, owner metav1.Object, job *batchv1.Job) (BackupJobState, error) {
+	existing := &batchv1.Job{}
+	err := cl.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: owner.GetNamespace()}, existing)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+
+		job.Namespace = owner.GetNamespace()
+		err = controllerutil.SetControllerReference(owner, job, scheme)
+		if err != nil {
+			return "", err
+		}
+		return BackupJobRunning, cl.Create(context.TODO(), job)
+	}
+
+	return jobState(existing), nil
+}
+
+// jobState returns the state of the given job from its conditions
+func jobState(job *batchv1.Job) BackupJobState {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return BackupJobSucceeded
+		case batchv1.JobFailed:
+			return BackupJobFailed
+		}
+	}
+	return BackupJobRunning
+}
+
+// StopBackupDeployment scales the given DeploymentConfig, or Deployment in
+// the Kubernetes platform, to zero replicas and returns true once its pods
+// are stopped. The replicas it had are recorded in an annotation, so the
+// APIManager controller doesn't scale it up and it can be started again
+func StopBackupDeployment(cl client.Client, namespace, name string, kubernetesPlatform bool) (bool, error) {
+	obj, replicas, err := getBackupDeployment(cl, namespace, name, kubernetesPlatform)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := obj.GetAnnotations()[appsv1alpha1.RestoreStoppedReplicasAnnotation]; !ok {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[appsv1alpha1.RestoreStoppedReplicasAnnotation] = strconv.Itoa(int(replicas))
+		obj.SetAnnotations(annotations)
+		setBackupDeploymentReplicas(obj, 0)
+		return false, cl.Update(context.TODO(), obj)
+	}
+
+	if replicas != 0 {
+		setBackupDeploymentReplicas(obj, 0)
+		return false, cl.Update(context.TODO(), obj)
+	}
+	return backupDeploymentRunningReplicas(obj) == 0, nil
+}
+
+// StartBackupDeployment scales the given DeploymentConfig, or Deployment in
+// the Kubernetes platform, stopped by StopBackupDeployment back to the
+// replicas it had
+func StartBackupDeployment(cl client.Client, namespace, name string, kubernetesPlatform bool) error {
+	obj, _, err := getBackupDeployment(cl, namespace, name, kubernetesPlatform)
+	if err != nil {
+		return err
+	}
+
+	annotations := obj.GetAnnotations()
+	value, ok := annotations[appsv1alpha1.RestoreStoppedReplicasAnnotation]
+	if !ok {
+		return nil
+	}
+	replicas, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation of %s: %s", appsv1alpha1.RestoreStoppedReplicasAnnotation, name, err)
+	}
+
+	delete(annotations, appsv1alpha1.RestoreStoppedReplicasAnnotation)
+	obj.SetAnnotations(annotations)
+	setBackupDeploymentReplicas(obj, int32(replicas))
+	return cl.Update(context.TODO(), obj)
+}
+
+func getBackupDeployment(cl client.Client, namespace, name string, kubernetesPlatform bool) (common.KubernetesObject, int32, error) {
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+
+	if kubernetesPlatform {
+		deployment := &k8sappsv1.Deployment{}
+		err := cl.Get(context.TODO(), key, deployment)
+		if err != nil {
+			return nil, 0, err
+		}
+		var replicas int32 = 1
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		return deployment, replicas, nil
+	}
+
+	dc := &appsv1.DeploymentConfig{}
+	err := cl.Get(context.TODO(), key, dc)
+	if err != nil {
+		return nil, 0, err
+	}
+	return dc, dc.Spec.Replicas, nil
+}
+
+func setBackupDeploymentReplicas(obj common.KubernetesObject, replicas int32) {
+	switch deployment := obj.(type) {
+	case *k8sappsv1.Deployment:
+		deployment.Spec.Replicas = &replicas
+	case *appsv1.DeploymentConfig:
+		deployment.Spec.Replicas = replicas
+	}
+}
+
+func backupDeploymentRunningReplicas(obj common.KubernetesObject) int32 {
+	switch deployment := obj.(type) {
+	case *k8sappsv1.Deployment:
+		return deployment.Status.Replicas
+	case *appsv1.DeploymentConfig:
+		return deployment.Status.Replicas
+	}
+	return 0
+}
+
+// BackupJobFailedError is returned when a backup or restore job fails
+type BackupJobFailedError struct {
+	JobName string
+}
+
+func (e *BackupJobFailedError) Error() string {
+	return fmt.Sprintf("job %s failed. Check the logs of its pods for details", e.JobName)
+}
@@ -3,6 +3,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/api/policy/v1beta1"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
@@ -93,3 +94,13 @@ func (r *BaseAPIManagerLogicReconciler) reconcilePodDisruptionBudget(desiredPDB
 	reconciler := NewPodDisruptionBudgetReconciler(*r)
 	return reconciler.Reconcile(desiredPDB)
 }
+
+func (r *BaseAPIManagerLogicReconciler) reconcileHorizontalPodAutoscaler(desiredHPA *v2beta2.HorizontalPodAutoscaler, enabled bool) error {
+	if r.apiManager.IsKubernetesPlatform() {
+		desiredHPA.Spec.ScaleTargetRef.APIVersion = "apps/v1"
+		desiredHPA.Spec.ScaleTargetRef.Kind = "Deployment"
+	}
+
+	reconciler := NewHorizontalPodAutoscalerReconciler(*r)
+	return reconciler.Reconcile(desiredHPA, enabled)
+}
@@ -0,0 +1,530 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// CredentialRotationSecretName is the secret keeping the previous access token
+// while the components are rolled out with the new one
+const CredentialRotationSecretName = "credential-rotation"
+
+// credentialRotationWaitPeriod is how often the rollouts of a rotation are checked
+const credentialRotationWaitPeriod = 10 * time.Second
+
+type rotatedCredential struct {
+	credential appsv1alpha1.RotatedCredential
+	secretName string
+	field      string
+	// Length of the value generated by the operator
+	length int
+	// Name prefix of the deployment configs validating the credential. They are
+	// rolled out before the rest of the deployment configs using the credential,
+	// which are paused meanwhile
+	acceptorPrefix string
+	// Field of the secret keeping the previous value while the credential is rotated.
+	// The acceptors validate both values until it is removed in the Revoking phase, so
+	// the clients still running with the previous value are not rejected
+	previousField string
+	// Scopes of the access token created in 3scale. Access tokens are validated
+	// by 3scale, which accepts both tokens until the previous one is deleted
+	accessTokenScopes []string
+}
+
+// rotatedCredentials are the credentials that can be rotated, in the order the requested
+// rotations are applied. The credentials validated by backend and zync go first
+var rotatedCredentials = []rotatedCredential{
+	{
+		credential:     appsv1alpha1.RotatedCredentialBackendInternalAPI,
+		secretName:     component.BackendSecretInternalApiSecretName,
+		field:          component.BackendSecretInternalApiPasswordFieldName,
+		length:         8,
+		acceptorPrefix: "backend-",
+		previousField:  component.BackendSecretInternalApiPreviousPasswordFieldName,
+	},
+	{
+		credential:     appsv1alpha1.RotatedCredentialZyncAuthenticationToken,
+		secretName:     component.ZyncSecretName,
+		field:          component.ZyncSecretAuthenticationTokenFieldName,
+		length:         16,
+		acceptorPrefix: "zync",
+		previousField:  component.ZyncSecretPreviousAuthenticationTokenFieldName,
+	},
+	{
+		credential: appsv1alpha1.RotatedCredentialSystemAppSecretKeyBase,
+		secretName: component.SystemSecretSystemAppSecretName,
+		field:      component.SystemSecretSystemAppSecretKeyBaseFieldName,
+		length:     128,
+	},
+	{
+		credential:        appsv1alpha1.RotatedCredentialMasterAccessToken,
+		secretName:        component.SystemSecretSystemSeedSecretName,
+		field:             component.SystemSecretSystemSeedMasterAccessTokenFieldName,
+		accessTokenScopes: []string{"account_management"},
+	},
+	{
+		credential:        appsv1alpha1.RotatedCredentialAdminAccessToken,
+		secretName:        component.SystemSecretSystemSeedSecretName,
+		field:             component.SystemSecretSystemSeedAdminAccessTokenFieldName,
+		accessTokenScopes: []string{"account_management", "stats", "policy_registry"},
+	},
+}
+
+// credentialConsumer is a deployment config, or a deployment in the Kubernetes
+// platform, using a rotated credential
+type credentialConsumer struct {
+	object common.KubernetesObject
+	// deployment config sharing the pod template of the object
+	dc             *appsv1.DeploymentConfig
+	paused         *bool
+	rolloutPending bool
+}
+
+// CredentialRotationReconciler rotates the credentials requested in the credentialRotation
+// spec, one at a time. The progress is kept in the status so a rotation is resumed after
+// a failure or a restart of the operator:
+//   - Accepting: the new credential is written into its secret, next to the previous
+//     one, and the components validating it are rolled out accepting both. Access
+//     tokens are created in 3scale instead
+//   - Distributing: the rest of the components using the credential are rolled out
+//   - Revoking: the previous value is removed from the secret and the components
+//     validating it are rolled out again. The previous access token is deleted from 3scale
+type CredentialRotationReconciler struct {
+	BaseAPIManagerLogicReconciler
+}
+
+// blank assignment to verify that CredentialRotationReconciler implements LogicReconciler
+var _ LogicReconciler = &CredentialRotationReconciler{}
+
+func NewCredentialRotationReconciler(baseAPIManagerLogicReconciler BaseAPIManagerLogicReconciler) *CredentialRotationReconciler {
+	return &CredentialRotationReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *CredentialRotationReconciler) Reconcile() (reconcile.Result, error) {
+	status := &appsv1alpha1.CredentialRotationStatus{}
+	if r.apiManager.Status.CredentialRotation != nil {
+		status = r.apiManager.Status.CredentialRotation.DeepCopy()
+	}
+
+	if status.Credential == "" {
+		credential := r.nextRotation(status)
+		if credential == nil {
+			return reconcile.Result{}, nil
+		}
+
+		r.Logger().Info("Rotating credential", "Credential", credential.credential)
+		err := r.startRotation(credential)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		status.Credential = credential.credential
+		status.Phase = appsv1alpha1.CredentialRotationPhaseAccepting
+		err = r.updateStatus(status)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	credential := findRotatedCredential(status.Credential)
+	if credential == nil {
+		return reconcile.Result{}, fmt.Errorf("unknown rotated credential '%s'", status.Credential)
+	}
+
+	consumers, err := r.credentialConsumers(credential)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if status.Phase == appsv1alpha1.CredentialRotationPhaseAccepting {
+		rolledOut, err := r.rolledOut(acceptors(credential, consumers))
+		if err != nil || !rolledOut {
+			return reconcile.Result{RequeueAfter: credentialRotationWaitPeriod}, err
+		}
+		status.Phase = appsv1alpha1.CredentialRotationPhaseDistributing
+		err = r.updateStatus(status)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if status.Phase == appsv1alpha1.CredentialRotationPhaseDistributing {
+		err = r.setPaused(consumers, false)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		rolledOut, err := r.rolledOut(consumers)
+		if err != nil || !rolledOut {
+			return reconcile.Result{RequeueAfter: credentialRotationWaitPeriod}, err
+		}
+		status.Phase = appsv1alpha1.CredentialRotationPhaseRevoking
+		err = r.updateStatus(status)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if credential.previousField != "" {
+		err = r.revokePreviousValue(credential)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		rolledOut, err := r.rolledOut(acceptors(credential, consumers))
+		if err != nil || !rolledOut {
+			return reconcile.Result{RequeueAfter: credentialRotationWaitPeriod}, err
+		}
+	}
+
+	if credential.accessTokenScopes != nil {
+		err = r.revokeAccessToken(credential)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	r.Logger().Info("Credential rotated", "Credential", credential.credential)
+	status.Applied.SetRotation(credential.credential, r.apiManager.Spec.CredentialRotation.Rotation(credential.credential))
+	status.Credential = ""
+	status.Phase = ""
+	now := metav1.Now()
+	status.LastRotationTime = &now
+	err = r.updateStatus(status)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Next requested rotation
+	return reconcile.Result{Requeue: true}, nil
+}
+
+// nextRotation returns the first credential whose requested rotation has not been applied
+func (r *CredentialRotationReconciler) nextRotation(status *appsv1alpha1.CredentialRotationStatus) *rotatedCredential {
+	for idx := range rotatedCredentials {
+		credential := &rotatedCredentials[idx]
+		if r.apiManager.Spec.CredentialRotation.Rotation(credential.credential) != status.Applied.Rotation(credential.credential) {
+			return credential
+		}
+	}
+	return nil
+}
+
+func findRotatedCredential(name appsv1alpha1.RotatedCredential) *rotatedCredential {
+	for idx := range rotatedCredentials {
+		if rotatedCredentials[idx].credential == name {
+			return &rotatedCredentials[idx]
+		}
+	}
+	return nil
+}
+
+// startRotation writes the new credential into its secret, keeping the previous one
+// for the acceptors. The components using a credential validated by other components
+// are paused until those accept it
+func (r *CredentialRotationReconciler) startRotation(credential *rotatedCredential) error {
+	if r.apiManager.Spec.SecretSource(credential.secretName) != nil {
+		return fmt.Errorf("credential '%s' is read from a secret source, it has to be rotated in its external store", credential.credential)
+	}
+
+	secret, err := helper.GetSecret(credential.secretName, r.apiManager.GetNamespace(), r.Client())
+	if err != nil {
+		return err
+	}
+
+	var value string
+	if credential.accessTokenScopes != nil {
+		value, err = r.createAccessToken(credential, secret)
+		if err != nil {
+			return err
+		}
+	} else {
+		value = oprand.String(credential.length)
+	}
+
+	if credential.acceptorPrefix != "" {
+		consumers, err := r.credentialConsumers(credential)
+		if err != nil {
+			return err
+		}
+		err = r.setPaused(clients(credential, consumers), true)
+		if err != nil {
+			return err
+		}
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if _, ok := secret.Data[credential.previousField]; credential.previousField != "" && !ok {
+		// a previous value left by an interrupted rotation is kept, it is the one
+		// the paused components are running with
+		secret.Data[credential.previousField] = secret.Data[credential.field]
+	}
+	secret.Data[credential.field] = []byte(value)
+	return r.Client().Update(context.TODO(), secret)
+}
+
+// revokePreviousValue removes the previous value of the credential from its secret, so
+// the acceptors stop accepting it once they are rolled out
+func (r *CredentialRotationReconciler) revokePreviousValue(credential *rotatedCredential) error {
+	secret, err := helper.GetSecret(credential.secretName, r.apiManager.GetNamespace(), r.Client())
+	if err != nil {
+		return err
+	}
+	if _, ok := secret.Data[credential.previousField]; !ok {
+		return nil
+	}
+
+	delete(secret.Data, credential.previousField)
+	return r.Client().Update(context.TODO(), secret)
+}
+
+// createAccessToken creates a new access token in 3scale authenticated by the current one,
+// which is kept in the credential rotation secret until it is revoked
+func (r *CredentialRotationReconciler) createAccessToken(credential *rotatedCredential, seedSecret *v1.Secret) (string, error) {
+	previousToken := helper.GetSecretDataValueOrDefault(seedSecret.Data, credential.field, "")
+	if previousToken == "" {
+		return "", fmt.Errorf("secret '%s' has no '%s' to rotate", credential.secretName, credential.field)
+	}
+
+	rotationSecret := &v1.Secret{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: CredentialRotationSecretName, Namespace: r.apiManager.GetNamespace()}, rotationSecret)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+	if errors.IsNotFound(err) {
+		rotationSecret = &v1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Secret",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   CredentialRotationSecretName,
+				Labels: map[string]string{"app": *r.apiManager.Spec.AppLabel},
+			},
+			Data: map[string][]byte{string(credential.credential): []byte(previousToken)},
+			Type: v1.SecretTypeOpaque,
+		}
+		err = r.createResource(rotationSecret)
+		if err != nil {
+			return "", err
+		}
+	} else if _, ok := rotationSecret.Data[string(credential.credential)]; !ok {
+		// a previous token left by an interrupted rotation is kept, so it is revoked
+		if rotationSecret.Data == nil {
+			rotationSecret.Data = map[string][]byte{}
+		}
+		rotationSecret.Data[string(credential.credential)] = []byte(previousToken)
+		err = r.Client().Update(context.TODO(), rotationSecret)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	tokenClient, err := r.accessTokenClient(credential, seedSecret, previousToken)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("3scale-operator %s %d", credential.credential, r.apiManager.Spec.CredentialRotation.Rotation(credential.credential))
+	token, err := tokenClient.CreateAccessToken(name, credential.accessTokenScopes)
+	if err != nil {
+		return "", err
+	}
+	return token.Value, nil
+}
+
+// revokeAccessToken deletes the previous access token from 3scale, authenticated by the new one
+func (r *CredentialRotationReconciler) revokeAccessToken(credential *rotatedCredential) error {
+	rotationSecret := &v1.Secret{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: CredentialRotationSecretName, Namespace: r.apiManager.GetNamespace()}, rotationSecret)
+	if err != nil && errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	previousToken, ok := rotationSecret.Data[string(credential.credential)]
+	if !ok {
+		return nil
+	}
+
+	seedSecret, err := helper.GetSecret(credential.secretName, r.apiManager.GetNamespace(), r.Client())
+	if err != nil {
+		return err
+	}
+	tokenClient, err := r.accessTokenClient(credential, seedSecret, helper.GetSecretDataValueOrDefault(seedSecret.Data, credential.field, ""))
+	if err != nil {
+		return err
+	}
+	err = tokenClient.DeleteAccessToken(string(previousToken))
+	if err != nil && !helper.IsDeveloperNotFound(err) {
+		return err
+	}
+
+	delete(rotationSecret.Data, string(credential.credential))
+	if len(rotationSecret.Data) == 0 {
+		return r.Client().Delete(context.TODO(), rotationSecret)
+	}
+	return r.Client().Update(context.TODO(), rotationSecret)
+}
+
+// accessTokenClient returns a client of the portal of the access token. The requests
+// are sent to the system services, which don't need the routes to be reachable
+func (r *CredentialRotationReconciler) accessTokenClient(credential *rotatedCredential, seedSecret *v1.Secret, accessToken string) (*helper.AccessTokenClient, error) {
+	if credential.credential == appsv1alpha1.RotatedCredentialMasterAccessToken {
+		// the system-master container serves the master portal for any host
+		return helper.AccessTokenClientFromURLString(fmt.Sprintf("http://system-master.%s.svc:3000", r.apiManager.GetNamespace()), "", accessToken, nil)
+	}
+
+	return helper.AccessTokenClientFromURLString(fmt.Sprintf("http://system-provider.%s.svc:3000", r.apiManager.GetNamespace()), r.adminPortalHost(), accessToken, nil)
+}
+
+// adminPortalHost returns the host of the admin portal of the default tenant, which
+// system uses to find the tenant of the requests
+func (r *CredentialRotationReconciler) adminPortalHost() string {
+	if r.apiManager.Spec.Exposure != nil && r.apiManager.Spec.Exposure.Provider != nil && r.apiManager.Spec.Exposure.Provider.Host != nil {
+		return *r.apiManager.Spec.Exposure.Provider.Host
+	}
+	return fmt.Sprintf("%s-admin.%s", *r.apiManager.Spec.TenantName, r.apiManager.Spec.WildcardDomain)
+}
+
+// credentialConsumers returns the deployment configs, or the deployments in the Kubernetes
+// platform, of the APIManager using the secret of the credential
+func (r *CredentialRotationReconciler) credentialConsumers(credential *rotatedCredential) ([]credentialConsumer, error) {
+	consumers := []credentialConsumer{}
+
+	if r.apiManager.IsKubernetesPlatform() {
+		deploymentList := &k8sappsv1.DeploymentList{}
+		err := r.Client().List(context.TODO(), deploymentList, client.InNamespace(r.apiManager.GetNamespace()))
+		if err != nil {
+			return nil, err
+		}
+		for idx := range deploymentList.Items {
+			deployment := &deploymentList.Items[idx]
+			consumer := credentialConsumer{
+				object:         deployment,
+				dc:             deploymentConfigView(deployment),
+				paused:         &deployment.Spec.Paused,
+				rolloutPending: DeploymentRolloutPending(deployment),
+			}
+			if r.isOwned(deployment) && usesSecret(consumer.dc, credential.secretName) {
+				consumers = append(consumers, consumer)
+			}
+		}
+		return consumers, nil
+	}
+
+	dcList := &appsv1.DeploymentConfigList{}
+	err := r.Client().List(context.TODO(), dcList, client.InNamespace(r.apiManager.GetNamespace()))
+	if err != nil {
+		return nil, err
+	}
+	for idx := range dcList.Items {
+		dc := &dcList.Items[idx]
+		if r.isOwned(dc) && usesSecret(dc, credential.secretName) {
+			consumers = append(consumers, credentialConsumer{
+				object:         dc,
+				dc:             dc,
+				paused:         &dc.Spec.Paused,
+				rolloutPending: DeploymentConfigRolloutPending(dc),
+			})
+		}
+	}
+	return consumers, nil
+}
+
+func (r *CredentialRotationReconciler) isOwned(obj metav1.Object) bool {
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		if ownerRef.UID == r.apiManager.UID {
+			return true
+		}
+	}
+	return false
+}
+
+func usesSecret(dc *appsv1.DeploymentConfig, secretName string) bool {
+	if dc.Spec.Template == nil {
+		return false
+	}
+	secretNames, _ := deploymentConfigConfigReferences(dc)
+	for _, name := range secretNames {
+		if name == secretName {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptors returns the consumers validating the credential
+func acceptors(credential *rotatedCredential, consumers []credentialConsumer) []credentialConsumer {
+	result := []credentialConsumer{}
+	for _, consumer := range consumers {
+		if credential.acceptorPrefix != "" && strings.HasPrefix(consumer.object.GetName(), credential.acceptorPrefix) {
+			result = append(result, consumer)
+		}
+	}
+	return result
+}
+
+// clients returns the consumers sending the credential to the acceptors
+func clients(credential *rotatedCredential, consumers []credentialConsumer) []credentialConsumer {
+	result := []credentialConsumer{}
+	for _, consumer := range consumers {
+		if credential.acceptorPrefix == "" || !strings.HasPrefix(consumer.object.GetName(), credential.acceptorPrefix) {
+			result = append(result, consumer)
+		}
+	}
+	return result
+}
+
+// rolledOut returns true when the pods of all the consumers run the current content of
+// their secrets. The pod templates are updated by the component reconcilers
+func (r *CredentialRotationReconciler) rolledOut(consumers []credentialConsumer) (bool, error) {
+	for _, consumer := range consumers {
+		configHash, err := DeploymentConfigConfigHash(r.Client(), r.apiManager.GetNamespace(), consumer.dc)
+		if err != nil {
+			return false, err
+		}
+		if consumer.dc.Spec.Template.Annotations[ConfigHashAnnotation] != configHash || consumer.rolloutPending || *consumer.paused {
+			r.Logger().Info("Waiting for the rollout of the credential", "Name", consumer.object.GetName())
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *CredentialRotationReconciler) setPaused(consumers []credentialConsumer, paused bool) error {
+	for _, consumer := range consumers {
+		if *consumer.paused == paused {
+			continue
+		}
+		*consumer.paused = paused
+		r.Logger().Info(fmt.Sprintf("Setting paused to %t in %s", paused, ObjectInfo(consumer.object)))
+		err := r.Client().Update(context.TODO(), consumer.object)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CredentialRotationReconciler) updateStatus(status *appsv1alpha1.CredentialRotationStatus) error {
+	r.apiManager.Status.CredentialRotation = status
+	return r.Client().Status().Update(context.TODO(), r.apiManager)
+}
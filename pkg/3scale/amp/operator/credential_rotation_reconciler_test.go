@@ -0,0 +1,223 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	appsv1 "github.com/openshift/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCredentialRotationReconcilerSharedSecret(t *testing.T) {
+	var (
+		name                 = "example-apimanager"
+		namespace            = "operator-unittest"
+		log                  = logf.Log.WithName("operator_test")
+		appLabel             = "someLabel"
+		tenantName           = "someTenant"
+		oldPassword          = "oldpassw"
+		rotation       int64 = 1
+		wildcardDomain       = "test.3scale.net"
+	)
+
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       "apimanager-uid",
+		},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				AppLabel:       &appLabel,
+				TenantName:     &tenantName,
+				WildcardDomain: wildcardDomain,
+			},
+			CredentialRotation: &appsv1alpha1.CredentialRotationSpec{
+				BackendInternalAPI: rotation,
+			},
+		},
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: component.BackendSecretInternalApiSecretName, Namespace: namespace},
+		Data: map[string][]byte{
+			component.BackendSecretInternalApiUsernameFieldName: []byte("3scale_api_user"),
+			component.BackendSecretInternalApiPasswordFieldName: []byte(oldPassword),
+		},
+	}
+
+	consumerDC := func(dcName string) *appsv1.DeploymentConfig {
+		return &appsv1.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      dcName,
+				Namespace: namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "APIManager", Name: name, UID: apimanager.UID},
+				},
+			},
+			Spec: appsv1.DeploymentConfigSpec{
+				Template: &v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{
+							{
+								Name: dcName,
+								Env: []v1.EnvVar{
+									{
+										Name: "CONFIG_INTERNAL_API_PASSWORD",
+										ValueFrom: &v1.EnvVarSource{
+											SecretKeyRef: &v1.SecretKeySelector{
+												LocalObjectReference: v1.LocalObjectReference{Name: component.BackendSecretInternalApiSecretName},
+												Key:                  component.BackendSecretInternalApiPasswordFieldName,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	objs := []runtime.Object{apimanager, secret, consumerDC("backend-listener"), consumerDC("system-app")}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+	err := appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+
+	reconcileRotation := func() {
+		cr := &appsv1alpha1.APIManager{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, cr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reconciler := NewCredentialRotationReconciler(NewBaseAPIManagerLogicReconciler(NewBaseLogicReconciler(baseReconciler), cr))
+		_, err = reconciler.Reconcile()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertStatus := func(phase appsv1alpha1.CredentialRotationPhase, applied int64) {
+		cr := &appsv1alpha1.APIManager{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, cr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cr.Status.CredentialRotation == nil {
+			t.Fatal("credential rotation status not set")
+		}
+		if cr.Status.CredentialRotation.Phase != phase {
+			t.Fatalf("unexpected phase. Expected: '%s'. Got: '%s'", phase, cr.Status.CredentialRotation.Phase)
+		}
+		if cr.Status.CredentialRotation.Applied.BackendInternalAPI != applied {
+			t.Fatalf("unexpected applied rotation. Expected: %d. Got: %d", applied, cr.Status.CredentialRotation.Applied.BackendInternalAPI)
+		}
+	}
+
+	getDC := func(dcName string) *appsv1.DeploymentConfig {
+		dc := &appsv1.DeploymentConfig{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: dcName, Namespace: namespace}, dc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dc
+	}
+
+	// the deployment config reconciler sets the hash of the current secrets
+	rollOut := func(dcName string) {
+		dc := getDC(dcName)
+		configHash, err := DeploymentConfigConfigHash(cl, namespace, dc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dc.Spec.Template.Annotations = map[string]string{ConfigHashAnnotation: configHash}
+		err = cl.Update(context.TODO(), dc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reconcileRotation()
+	assertStatus(appsv1alpha1.CredentialRotationPhaseAccepting, 0)
+
+	updatedSecret := &v1.Secret{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: namespace}, updatedSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newPassword := string(updatedSecret.Data[component.BackendSecretInternalApiPasswordFieldName])
+	if newPassword == oldPassword || len(newPassword) != 8 {
+		t.Fatalf("unexpected rotated password: '%s'", newPassword)
+	}
+	previousPassword := string(updatedSecret.Data[component.BackendSecretInternalApiPreviousPasswordFieldName])
+	if previousPassword != oldPassword {
+		t.Fatalf("previous password not kept for backend. Expected: '%s'. Got: '%s'", oldPassword, previousPassword)
+	}
+	if getDC("backend-listener").Spec.Paused {
+		t.Fatal("backend-listener paused")
+	}
+	if !getDC("system-app").Spec.Paused {
+		t.Fatal("system-app not paused while backend accepts the new password")
+	}
+
+	// waits for backend
+	reconcileRotation()
+	assertStatus(appsv1alpha1.CredentialRotationPhaseAccepting, 0)
+
+	rollOut("backend-listener")
+	reconcileRotation()
+	assertStatus(appsv1alpha1.CredentialRotationPhaseDistributing, 0)
+	if getDC("system-app").Spec.Paused {
+		t.Fatal("system-app still paused after backend accepted the new password")
+	}
+
+	rollOut("system-app")
+	reconcileRotation()
+	assertStatus(appsv1alpha1.CredentialRotationPhaseRevoking, 0)
+
+	updatedSecret = &v1.Secret{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: namespace}, updatedSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := updatedSecret.Data[component.BackendSecretInternalApiPreviousPasswordFieldName]; ok {
+		t.Fatal("previous password not removed after all the components use the new one")
+	}
+
+	// waits for backend to stop accepting the previous password
+	rollOut("backend-listener")
+	reconcileRotation()
+	assertStatus("", rotation)
+
+	// nothing else to rotate
+	reconcileRotation()
+	assertStatus("", rotation)
+
+	dcList := &appsv1.DeploymentConfigList{}
+	err = cl.List(context.TODO(), dcList, client.InNamespace(namespace))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dc := range dcList.Items {
+		if dc.Spec.Paused {
+			t.Fatalf("deployment config %s left paused", dc.Name)
+		}
+	}
+}
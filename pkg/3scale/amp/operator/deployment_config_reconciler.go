@@ -2,18 +2,35 @@ package operator
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"hash"
+	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "github.com/openshift/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ConfigHashAnnotation is the pod template annotation with the hash of the secrets
+// and configmaps consumed by the pods. Changing their content changes the pod
+// template, so the pods are rolled out with the new content
+const ConfigHashAnnotation = "apps.3scale.net/config-hash"
+
 type DeploymentConfigReconciler interface {
 	IsUpdateNeeded(desired, existing *appsv1.DeploymentConfig) bool
 }
@@ -31,9 +48,20 @@ func NewDeploymentConfigBaseReconciler(baseAPIManagerLogicReconciler BaseAPIMana
 }
 
 func (r *DeploymentConfigBaseReconciler) Reconcile(desired *appsv1.DeploymentConfig) error {
+	applySecretSources(&r.apiManager.Spec, desired)
+
+	err := r.setConfigHash(desired)
+	if err != nil {
+		return err
+	}
+
+	if r.apiManager.IsKubernetesPlatform() {
+		return r.reconcileDeployment(desired)
+	}
+
 	objectInfo := ObjectInfo(desired)
 	existing := &appsv1.DeploymentConfig{}
-	err := r.Client().Get(
+	err = r.Client().Get(
 		context.TODO(),
 		types.NamespacedName{Name: desired.Name, Namespace: r.apiManager.GetNamespace()},
 		existing)
@@ -61,6 +89,94 @@ func (r *DeploymentConfigBaseReconciler) Reconcile(desired *appsv1.DeploymentCon
 	return nil
 }
 
+// reconcileDeployment reconciles the desired DeploymentConfig rendered as a
+// Deployment, for platforms where DeploymentConfigs are not available
+func (r *DeploymentConfigBaseReconciler) reconcileDeployment(desired *appsv1.DeploymentConfig) error {
+	images, err := r.deploymentImages()
+	if err != nil {
+		return err
+	}
+
+	reconciler := NewDeploymentBaseReconciler(r.BaseAPIManagerLogicReconciler, NewDeploymentConfigAdapterReconciler(r.reconciler, r.Logger()))
+	if component.DeploymentConfigPreHook(desired) != nil {
+		reconciler.rolloutGate = func(template *v1.PodTemplateSpec) (bool, error) {
+			return r.preHookCompleted(desired, template)
+		}
+	}
+	return reconciler.Reconcile(component.DeploymentFromDeploymentConfig(desired, images))
+}
+
+// preHookCompleted runs the pre deployment hook of the desired deployment config as a
+// job before the given pod template is rolled out, and returns true once the job has
+// succeeded. The job is named after the hash of the template, so the hook runs once
+// per rollout, like in a DeploymentConfig, instead of once per pod
+func (r *DeploymentConfigBaseReconciler) preHookCompleted(desired *appsv1.DeploymentConfig, template *v1.PodTemplateSpec) (bool, error) {
+	templateHash, err := podTemplateHash(template)
+	if err != nil {
+		return false, err
+	}
+	job := component.PreHookJob(desired, template, fmt.Sprintf("%s-%s", preHookJobPrefix(desired), templateHash))
+	if job == nil {
+		return true, nil
+	}
+
+	existing := &batchv1.Job{}
+	err = r.Client().Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: r.apiManager.GetNamespace()}, existing)
+	if err != nil && errors.IsNotFound(err) {
+		return false, r.createResource(job)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch jobState(existing) {
+	case BackupJobRunning:
+		r.Logger().Info(fmt.Sprintf("Waiting for the pre deployment hook job %s", job.Name))
+		return false, nil
+	case BackupJobFailed:
+		return false, fmt.Errorf("pre deployment hook job %s failed. Check the logs of its pods for details and delete it to run the hook again", job.Name)
+	}
+
+	return true, r.deletePreviousPreHookJobs(desired, job.Name)
+}
+
+// deletePreviousPreHookJobs deletes the hook jobs of the previous rollouts
+func (r *DeploymentConfigBaseReconciler) deletePreviousPreHookJobs(desired *appsv1.DeploymentConfig, currentJobName string) error {
+	jobList := &batchv1.JobList{}
+	err := r.Client().List(context.TODO(), jobList,
+		client.InNamespace(r.apiManager.GetNamespace()),
+		client.MatchingLabels{"threescale_component_element": component.PreHookContainerName})
+	if err != nil {
+		return err
+	}
+
+	for idx := range jobList.Items {
+		job := &jobList.Items[idx]
+		if job.Name == currentJobName || !strings.HasPrefix(job.Name, preHookJobPrefix(desired)+"-") {
+			continue
+		}
+		r.Logger().Info(fmt.Sprintf("Delete object %s", ObjectInfo(job)))
+		err = r.Client().Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func preHookJobPrefix(dc *appsv1.DeploymentConfig) string {
+	return fmt.Sprintf("%s-%s", dc.Name, component.PreHookContainerName)
+}
+
+// podTemplateHash returns a short hash identifying the content of the pod template
+func podTemplateHash(template *v1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:10], nil
+}
+
 func (r *DeploymentConfigBaseReconciler) isUpdateNeeded(desired, existing *appsv1.DeploymentConfig) (bool, error) {
 	updated := helper.EnsureObjectMeta(&existing.ObjectMeta, &desired.ObjectMeta)
 
@@ -74,9 +190,180 @@ func (r *DeploymentConfigBaseReconciler) isUpdateNeeded(desired, existing *appsv
 	updatedTmp = r.reconciler.IsUpdateNeeded(desired, existing)
 	updated = updated || updatedTmp
 
+	updatedTmp = DeploymentConfigReconcileSecretSources(desired, existing, r.Logger())
+	updated = updated || updatedTmp
+
+	updatedTmp = DeploymentConfigReconcileConfigHash(desired, existing, r.Logger())
+	updated = updated || updatedTmp
+
 	return updated, nil
 }
 
+// setConfigHash sets the hash of the secrets and configmaps consumed by the desired
+// pod template. The objects not created yet are left out, the pods can't start
+// without them and are rolled out once they are created
+func (r *DeploymentConfigBaseReconciler) setConfigHash(desired *appsv1.DeploymentConfig) error {
+	if desired.Spec.Template == nil {
+		return nil
+	}
+
+	configHash, err := DeploymentConfigConfigHash(r.Client(), r.apiManager.GetNamespace(), desired)
+	if err != nil {
+		return err
+	}
+
+	if desired.Spec.Template.Annotations == nil {
+		desired.Spec.Template.Annotations = map[string]string{}
+	}
+	desired.Spec.Template.Annotations[ConfigHashAnnotation] = configHash
+	return nil
+}
+
+// DeploymentConfigConfigHash returns the hash of the current content of the secrets and
+// configmaps consumed by the deployment config
+func DeploymentConfigConfigHash(cl client.Client, namespace string, dc *appsv1.DeploymentConfig) (string, error) {
+	secretNames, configMapNames := deploymentConfigConfigReferences(dc)
+	configHash := sha256.New()
+
+	for _, name := range secretNames {
+		secret := &v1.Secret{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, secret)
+		if err != nil && errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		writeConfigHash(configHash, "Secret/"+name, secret.Data)
+	}
+
+	for _, name := range configMapNames {
+		configMap := &v1.ConfigMap{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, configMap)
+		if err != nil && errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		data := map[string][]byte{}
+		for key, value := range configMap.Data {
+			data[key] = []byte(value)
+		}
+		for key, value := range configMap.BinaryData {
+			data[key] = value
+		}
+		writeConfigHash(configHash, "ConfigMap/"+name, data)
+	}
+
+	return fmt.Sprintf("%x", configHash.Sum(nil)), nil
+}
+
+func writeConfigHash(configHash hash.Hash, object string, data map[string][]byte) {
+	keys := []string{}
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(configHash, "%s\n", object)
+	for _, key := range keys {
+		fmt.Fprintf(configHash, "%s:%d:", key, len(data[key]))
+		configHash.Write(data[key])
+	}
+}
+
+// deploymentConfigConfigReferences returns the sorted names of the secrets and configmaps
+// consumed by the containers, volumes and pre deployment hook of the deployment config.
+// Image pull secrets are not consumed by the pods
+func deploymentConfigConfigReferences(dc *appsv1.DeploymentConfig) ([]string, []string) {
+	secrets := map[string]bool{}
+	configMaps := map[string]bool{}
+
+	addEnv := func(env []v1.EnvVar, envFrom []v1.EnvFromSource) {
+		for _, envVar := range env {
+			if envVar.ValueFrom == nil {
+				continue
+			}
+			if envVar.ValueFrom.SecretKeyRef != nil {
+				secrets[envVar.ValueFrom.SecretKeyRef.Name] = true
+			}
+			if envVar.ValueFrom.ConfigMapKeyRef != nil {
+				configMaps[envVar.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+		}
+		for _, source := range envFrom {
+			if source.SecretRef != nil {
+				secrets[source.SecretRef.Name] = true
+			}
+			if source.ConfigMapRef != nil {
+				configMaps[source.ConfigMapRef.Name] = true
+			}
+		}
+	}
+
+	podSpec := &dc.Spec.Template.Spec
+	for _, container := range podSpec.InitContainers {
+		addEnv(container.Env, container.EnvFrom)
+	}
+	for _, container := range podSpec.Containers {
+		addEnv(container.Env, container.EnvFrom)
+	}
+	if hook := component.DeploymentConfigPreHook(dc); hook != nil {
+		addEnv(hook.Env, nil)
+	}
+
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil {
+			secrets[volume.Secret.SecretName] = true
+		}
+		if volume.ConfigMap != nil {
+			configMaps[volume.ConfigMap.Name] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					secrets[source.Secret.Name] = true
+				}
+				if source.ConfigMap != nil {
+					configMaps[source.ConfigMap.Name] = true
+				}
+			}
+		}
+	}
+
+	return sortedKeys(secrets), sortedKeys(configMaps)
+}
+
+func sortedKeys(values map[string]bool) []string {
+	result := []string{}
+	for value := range values {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// DeploymentConfigReconcileConfigHash sets the hash of the secrets and configmaps of
+// the desired pod template in the existing one, rolling out the pods when it changes
+func DeploymentConfigReconcileConfigHash(desired, existing *appsv1.DeploymentConfig, logger logr.Logger) bool {
+	if desired.Spec.Template == nil || existing.Spec.Template == nil {
+		return false
+	}
+
+	desiredHash, ok := desired.Spec.Template.Annotations[ConfigHashAnnotation]
+	if !ok || existing.Spec.Template.Annotations[ConfigHashAnnotation] == desiredHash {
+		return false
+	}
+
+	logger.Info(fmt.Sprintf("%s secrets or configmaps have changed, rolling out", ObjectInfo(desired)))
+	if existing.Spec.Template.Annotations == nil {
+		existing.Spec.Template.Annotations = map[string]string{}
+	}
+	existing.Spec.Template.Annotations[ConfigHashAnnotation] = desiredHash
+	return true
+}
+
 func DeploymentConfigReconcileContainerResources(desired, existing *appsv1.DeploymentConfig, logger logr.Logger) bool {
 	desiredName := ObjectInfo(desired)
 	update := false
@@ -108,6 +395,11 @@ func DeploymentConfigReconcileReplicas(desired, existing *appsv1.DeploymentConfi
 	desiredName := ObjectInfo(desired)
 	update := false
 
+	// An APIManagerRestore stopped it, and scales it back when finished
+	if _, ok := existing.Annotations[appsv1alpha1.RestoreStoppedReplicasAnnotation]; ok {
+		return false
+	}
+
 	if desired.Spec.Replicas != existing.Spec.Replicas {
 		logger.Info(fmt.Sprintf("%s spec.replicas differs, recreating dc", desiredName))
 		existing.Spec.Replicas = desired.Spec.Replicas
@@ -117,6 +409,321 @@ func DeploymentConfigReconcileReplicas(desired, existing *appsv1.DeploymentConfi
 	return update
 }
 
+func DeploymentConfigReconcilePodPlacement(desired, existing *appsv1.DeploymentConfig, logger logr.Logger) bool {
+	desiredName := ObjectInfo(desired)
+	update := false
+
+	desiredPodSpec := &desired.Spec.Template.Spec
+	existingPodSpec := &existing.Spec.Template.Spec
+
+	if !reflect.DeepEqual(existingPodSpec.NodeSelector, desiredPodSpec.NodeSelector) &&
+		!(len(existingPodSpec.NodeSelector) == 0 && len(desiredPodSpec.NodeSelector) == 0) {
+		logger.Info(fmt.Sprintf("%s spec.template.spec.nodeSelector differs", desiredName))
+		existingPodSpec.NodeSelector = desiredPodSpec.NodeSelector
+		update = true
+	}
+
+	if !reflect.DeepEqual(existingPodSpec.Tolerations, desiredPodSpec.Tolerations) &&
+		!(len(existingPodSpec.Tolerations) == 0 && len(desiredPodSpec.Tolerations) == 0) {
+		logger.Info(fmt.Sprintf("%s spec.template.spec.tolerations differs", desiredName))
+		existingPodSpec.Tolerations = desiredPodSpec.Tolerations
+		update = true
+	}
+
+	if !reflect.DeepEqual(existingPodSpec.Affinity, desiredPodSpec.Affinity) {
+		logger.Info(fmt.Sprintf("%s spec.template.spec.affinity differs", desiredName))
+		existingPodSpec.Affinity = desiredPodSpec.Affinity
+		update = true
+	}
+
+	if existingPodSpec.PriorityClassName != desiredPodSpec.PriorityClassName {
+		logger.Info(fmt.Sprintf("%s spec.template.spec.priorityClassName differs", desiredName))
+		existingPodSpec.PriorityClassName = desiredPodSpec.PriorityClassName
+		update = true
+	}
+
+	return update
+}
+
+// DeploymentConfigReconcileRedisConnection reconciles the redis sentinel passwords and
+// TLS CA files of the containers and the pre deployment hook, and the volume the CA
+// files are mounted from. The rest of the environment and volumes are not reconciled
+func DeploymentConfigReconcileRedisConnection(desired, existing *appsv1.DeploymentConfig, logger logr.Logger) bool {
+	desiredName := ObjectInfo(desired)
+	update := false
+
+	desiredPodSpec := &desired.Spec.Template.Spec
+	existingPodSpec := &existing.Spec.Template.Spec
+
+	volumes, changed := reconcileManagedVolumes(existingPodSpec.Volumes, desiredPodSpec.Volumes, isRedisTLSVolume)
+	if changed {
+		logger.Info(fmt.Sprintf("%s spec.template.spec.volumes redis TLS volume differs", desiredName))
+		existingPodSpec.Volumes = volumes
+		update = true
+	}
+
+	for idx := range existingPodSpec.InitContainers {
+		tmpUpdate := reconcileContainerRedisConnection(desiredPodSpec.InitContainers, &existingPodSpec.InitContainers[idx])
+		if tmpUpdate {
+			logger.Info(fmt.Sprintf("%s spec.template.spec.initContainers[%d] redis connection differs", desiredName, idx))
+		}
+		update = update || tmpUpdate
+	}
+
+	for idx := range existingPodSpec.Containers {
+		tmpUpdate := reconcileContainerRedisConnection(desiredPodSpec.Containers, &existingPodSpec.Containers[idx])
+		if tmpUpdate {
+			logger.Info(fmt.Sprintf("%s spec.template.spec.containers[%d] redis connection differs", desiredName, idx))
+		}
+		update = update || tmpUpdate
+	}
+
+	desiredHook := component.DeploymentConfigPreHook(desired)
+	existingHook := component.DeploymentConfigPreHook(existing)
+	if desiredHook != nil && existingHook != nil {
+		env, changed := reconcileRedisConnectionEnv(existingHook.Env, desiredHook.Env)
+		volumeNames, volumesChanged := reconcileManagedNames(existingHook.Volumes, desiredHook.Volumes, isRedisTLSVolume)
+		if changed || volumesChanged {
+			logger.Info(fmt.Sprintf("%s spec.strategy pre hook redis connection differs", desiredName))
+			existingHook.Env = env
+			existingHook.Volumes = volumeNames
+			update = true
+		}
+	}
+
+	return update
+}
+
+func reconcileContainerRedisConnection(desiredContainers []v1.Container, existing *v1.Container) bool {
+	var desired *v1.Container
+	for idx := range desiredContainers {
+		if desiredContainers[idx].Name == existing.Name {
+			desired = &desiredContainers[idx]
+		}
+	}
+	if desired == nil {
+		return false
+	}
+
+	update := false
+
+	env, changed := reconcileRedisConnectionEnv(existing.Env, desired.Env)
+	if changed {
+		existing.Env = env
+		update = true
+	}
+
+	volumeMounts, changed := reconcileManagedVolumeMounts(existing.VolumeMounts, desired.VolumeMounts, isRedisTLSVolume)
+	if changed {
+		existing.VolumeMounts = volumeMounts
+		update = true
+	}
+
+	return update
+}
+
+// reconcileRedisConnectionEnv returns the existing env with the redis connection
+// variables of the desired env. Existing variables keep their position
+func reconcileRedisConnectionEnv(existing, desired []v1.EnvVar) ([]v1.EnvVar, bool) {
+	redisConnectionVars := map[string]bool{}
+	for _, name := range component.RedisConnectionEnvVarNames {
+		redisConnectionVars[name] = true
+	}
+	return reconcileManagedEnv(existing, desired, func(name string) bool {
+		return redisConnectionVars[name]
+	})
+}
+
+// reconcileManagedEnv returns the existing env with the managed variables of the
+// desired env. Existing variables keep their position, new ones are appended
+func reconcileManagedEnv(existing, desired []v1.EnvVar, managed func(string) bool) ([]v1.EnvVar, bool) {
+	desiredVars := map[string]v1.EnvVar{}
+	for _, envVar := range desired {
+		if managed(envVar.Name) {
+			desiredVars[envVar.Name] = envVar
+		}
+	}
+
+	result := []v1.EnvVar{}
+	kept := map[string]bool{}
+	changed := false
+	for _, envVar := range existing {
+		if !managed(envVar.Name) {
+			result = append(result, envVar)
+			continue
+		}
+		desiredVar, ok := desiredVars[envVar.Name]
+		if !ok {
+			changed = true
+			continue
+		}
+		if !reflect.DeepEqual(envVar, desiredVar) {
+			changed = true
+		}
+		result = append(result, desiredVar)
+		kept[envVar.Name] = true
+	}
+	for _, envVar := range desired {
+		if managed(envVar.Name) && !kept[envVar.Name] {
+			result = append(result, envVar)
+			changed = true
+		}
+	}
+
+	return result, changed
+}
+
+// DeploymentConfigReconcileContainerEnvVar reconciles the environment variable with
+// the given name of the containers. The rest of the environment is not reconciled
+func DeploymentConfigReconcileContainerEnvVar(desired, existing *appsv1.DeploymentConfig, envVarName string, logger logr.Logger) bool {
+	update := false
+
+	desiredContainers := desired.Spec.Template.Spec.Containers
+	existingContainers := existing.Spec.Template.Spec.Containers
+	for idx := range existingContainers {
+		for _, desiredContainer := range desiredContainers {
+			if desiredContainer.Name != existingContainers[idx].Name {
+				continue
+			}
+			env, changed := reconcileManagedEnv(existingContainers[idx].Env, desiredContainer.Env, func(name string) bool {
+				return name == envVarName
+			})
+			if changed {
+				logger.Info(fmt.Sprintf("%s spec.template.spec.containers[%d] env %s differs", ObjectInfo(desired), idx, envVarName))
+				existingContainers[idx].Env = env
+				update = true
+			}
+		}
+	}
+
+	return update
+}
+
+func isRedisTLSVolume(name string) bool {
+	return name == component.RedisTLSVolumeName
+}
+
+// reconcileManagedVolumes returns the existing volumes with the managed volumes of the
+// desired volumes. Existing volumes keep their position, new ones are appended
+func reconcileManagedVolumes(existing, desired []v1.Volume, managed func(string) bool) ([]v1.Volume, bool) {
+	desiredVolumes := map[string]v1.Volume{}
+	for _, volume := range desired {
+		if managed(volume.Name) {
+			desiredVolumes[volume.Name] = volume
+		}
+	}
+
+	result := []v1.Volume{}
+	kept := map[string]bool{}
+	changed := false
+	for _, volume := range existing {
+		if !managed(volume.Name) {
+			result = append(result, volume)
+			continue
+		}
+		desiredVolume, ok := desiredVolumes[volume.Name]
+		if !ok {
+			changed = true
+			continue
+		}
+		if !reflect.DeepEqual(volume, desiredVolume) {
+			changed = true
+		}
+		result = append(result, desiredVolume)
+		kept[volume.Name] = true
+	}
+	for _, volume := range desired {
+		if managed(volume.Name) && !kept[volume.Name] {
+			result = append(result, volume)
+			changed = true
+		}
+	}
+
+	return result, changed
+}
+
+// reconcileManagedVolumeMounts returns the existing volume mounts with the mounts of the
+// managed volumes of the desired volume mounts. Existing mounts keep their position
+func reconcileManagedVolumeMounts(existing, desired []v1.VolumeMount, managed func(string) bool) ([]v1.VolumeMount, bool) {
+	desiredMounts := map[string]v1.VolumeMount{}
+	for _, volumeMount := range desired {
+		if managed(volumeMount.Name) {
+			desiredMounts[volumeMount.Name] = volumeMount
+		}
+	}
+
+	result := []v1.VolumeMount{}
+	kept := map[string]bool{}
+	changed := false
+	for _, volumeMount := range existing {
+		if !managed(volumeMount.Name) {
+			result = append(result, volumeMount)
+			continue
+		}
+		desiredMount, ok := desiredMounts[volumeMount.Name]
+		if !ok {
+			changed = true
+			continue
+		}
+		if !reflect.DeepEqual(volumeMount, desiredMount) {
+			changed = true
+		}
+		result = append(result, desiredMount)
+		kept[volumeMount.Name] = true
+	}
+	for _, volumeMount := range desired {
+		if managed(volumeMount.Name) && !kept[volumeMount.Name] {
+			result = append(result, volumeMount)
+			changed = true
+		}
+	}
+
+	return result, changed
+}
+
+// reconcileManagedNames returns the existing names with the managed names of the
+// desired names. Existing names keep their position
+func reconcileManagedNames(existing, desired []string, managed func(string) bool) ([]string, bool) {
+	desiredNames := map[string]bool{}
+	for _, name := range desired {
+		if managed(name) {
+			desiredNames[name] = true
+		}
+	}
+
+	result := []string{}
+	kept := map[string]bool{}
+	changed := false
+	for _, name := range existing {
+		if managed(name) && !desiredNames[name] {
+			changed = true
+			continue
+		}
+		result = append(result, name)
+		kept[name] = true
+	}
+	for _, name := range desired {
+		if managed(name) && !kept[name] {
+			result = append(result, name)
+			changed = true
+		}
+	}
+
+	return result, changed
+}
+
+// DeploymentConfigRolloutPending returns true when the pods of the deployment config don't
+// run its latest pod template yet
+func DeploymentConfigRolloutPending(dc *appsv1.DeploymentConfig) bool {
+	return rolloutPending(dc.Generation, dc.Status.ObservedGeneration, dc.Spec.Replicas, dc.Status.Replicas, dc.Status.UpdatedReplicas)
+}
+
+// rolloutPending is true when the latest spec has not been observed yet, or some of
+// the desired or running replicas don't have the latest pod template
+func rolloutPending(generation, observedGeneration int64, desiredReplicas, replicas, updatedReplicas int32) bool {
+	return observedGeneration < generation || updatedReplicas < desiredReplicas || replicas > updatedReplicas
+}
+
 type CreateOnlyDCReconciler struct {
 }
 
@@ -2,14 +2,17 @@ package operator
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "github.com/openshift/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -267,6 +270,14 @@ func TestDeploymentConfigReconcileReplicas(t *testing.T) {
 
 		})
 	}
+
+	// The replicas of a DeploymentConfig stopped by a restore are not reconciled
+	existing := dcFactory()
+	existing.Spec.Replicas = 0
+	existing.Annotations = map[string]string{appsv1alpha1.RestoreStoppedReplicasAnnotation: "3"}
+	if DeploymentConfigReconcileReplicas(dcFactory(), existing, logger) || existing.Spec.Replicas != 0 {
+		t.Errorf("expected the replicas of the stopped DeploymentConfig to be kept, got: %d", existing.Spec.Replicas)
+	}
 }
 
 func TestDeploymentConfigReconcileContainerResources(t *testing.T) {
@@ -336,3 +347,381 @@ func TestDeploymentConfigReconcileContainerResources(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentConfigReconcilePodPlacement(t *testing.T) {
+	logger := logf.Log.WithName("operator_test")
+	dcFactory := func(podSpec corev1.PodSpec) *appsv1.DeploymentConfig {
+		return &appsv1.DeploymentConfig{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "DeploymentConfig",
+				APIVersion: "apps.openshift.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myDC",
+				Namespace: "myNS",
+			},
+			Spec: appsv1.DeploymentConfigSpec{
+				Template: &corev1.PodTemplateSpec{
+					Spec: podSpec,
+				},
+			},
+		}
+	}
+
+	placedPodSpec := corev1.PodSpec{
+		NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+		Tolerations: []corev1.Toleration{
+			{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "3scale", Effect: corev1.TaintEffectNoSchedule},
+		},
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		PriorityClassName: "high-priority",
+	}
+
+	cases := []struct {
+		testName        string
+		existingPodSpec corev1.PodSpec
+		desiredPodSpec  corev1.PodSpec
+		expectedResult  bool
+	}{
+		{"NothingToReconcile", corev1.PodSpec{}, corev1.PodSpec{}, false},
+		{"NothingToReconcileWithPlacement", placedPodSpec, placedPodSpec, false},
+		{"EmptyAndNilAreEqual", corev1.PodSpec{NodeSelector: map[string]string{}, Tolerations: []corev1.Toleration{}}, corev1.PodSpec{}, false},
+		{"AddPlacement", corev1.PodSpec{}, placedPodSpec, true},
+		{"RemovePlacement", placedPodSpec, corev1.PodSpec{}, true},
+		{"PriorityClassNameChanged", placedPodSpec, corev1.PodSpec{
+			NodeSelector:      placedPodSpec.NodeSelector,
+			Tolerations:       placedPodSpec.Tolerations,
+			Affinity:          placedPodSpec.Affinity,
+			PriorityClassName: "low-priority",
+		}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			existing := dcFactory(tc.existingPodSpec)
+			desired := dcFactory(tc.desiredPodSpec)
+			update := DeploymentConfigReconcilePodPlacement(desired, existing, logger)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if update && !reflect.DeepEqual(existing.Spec.Template.Spec, desired.Spec.Template.Spec) {
+				subT.Fatal(cmp.Diff(existing.Spec.Template.Spec, desired.Spec.Template.Spec))
+			}
+		})
+	}
+}
+
+func TestDeploymentConfigReconcileRedisConnection(t *testing.T) {
+	logger := logf.Log.WithName("operator_test")
+	dcFactory := func(redisTLS bool) *appsv1.DeploymentConfig {
+		env := []corev1.EnvVar{
+			{Name: "REDIS_URL", Value: "rediss://system-redis/1"},
+		}
+		volumeNames := []string{}
+		podSpec := corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{Name: "system-config", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+		}
+		volumeMounts := []corev1.VolumeMount{{Name: "system-config", MountPath: "/opt/system-extra-configs"}}
+		if redisTLS {
+			env = append(env,
+				corev1.EnvVar{Name: "REDIS_SENTINEL_PASSWORD", Value: "sentinelpass"},
+				corev1.EnvVar{Name: "REDIS_CA_FILE", Value: "/tls/redis/system-redis-ca.crt"},
+			)
+			volumeNames = append(volumeNames, component.RedisTLSVolumeName)
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: component.RedisTLSVolumeName,
+				VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "system-redis"}}},
+					},
+				}},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: component.RedisTLSVolumeName, MountPath: component.RedisTLSMountPath, ReadOnly: true})
+		}
+		podSpec.InitContainers = []corev1.Container{{Name: "check-svc", Env: env, VolumeMounts: volumeMounts}}
+		podSpec.Containers = []corev1.Container{{Name: "system-sidekiq", Env: env, VolumeMounts: volumeMounts}}
+
+		return &appsv1.DeploymentConfig{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "DeploymentConfig",
+				APIVersion: "apps.openshift.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myDC",
+				Namespace: "myNS",
+			},
+			Spec: appsv1.DeploymentConfigSpec{
+				Strategy: appsv1.DeploymentStrategy{
+					RollingParams: &appsv1.RollingDeploymentStrategyParams{
+						Pre: &appsv1.LifecycleHook{
+							ExecNewPod: &appsv1.ExecNewPodHook{Env: env, Volumes: volumeNames},
+						},
+					},
+				},
+				Template: &corev1.PodTemplateSpec{Spec: podSpec},
+			},
+		}
+	}
+
+	cases := []struct {
+		testName       string
+		existingTLS    bool
+		desiredTLS     bool
+		expectedResult bool
+	}{
+		{"NothingToReconcile", false, false, false},
+		{"NothingToReconcileWithTLS", true, true, false},
+		{"AddRedisTLS", false, true, true},
+		{"RemoveRedisTLS", true, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			existing := dcFactory(tc.existingTLS)
+			desired := dcFactory(tc.desiredTLS)
+			update := DeploymentConfigReconcileRedisConnection(desired, existing, logger)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+				subT.Fatal(cmp.Diff(existing.Spec, desired.Spec))
+			}
+		})
+	}
+}
+
+func TestDeploymentConfigBaseReconcilerConfigHash(t *testing.T) {
+	var (
+		name      = "example-apimanager"
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+	)
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1alpha1.APIManagerSpec{},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+	err := appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "system-seed", Namespace: namespace},
+		Data:       map[string][]byte{"MASTER_PASSWORD": []byte("password1")},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "system-environment", Namespace: namespace},
+		Data:       map[string]string{"RAILS_ENV": "production"},
+	}
+	unrelatedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: namespace},
+		Data:       map[string][]byte{"key": []byte("value1")},
+	}
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{secret, configMap, unrelatedSecret}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+
+	baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+	baseLogicReconciler := NewBaseLogicReconciler(baseReconciler)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseLogicReconciler, apimanager)
+	reconciler := NewDeploymentConfigBaseReconciler(baseAPIManagerLogicReconciler, NewCreateOnlyDCReconciler())
+
+	desiredFactory := func() *appsv1.DeploymentConfig {
+		return &appsv1.DeploymentConfig{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "DeploymentConfig",
+				APIVersion: "apps.openshift.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "system-app",
+				Namespace: namespace,
+			},
+			Spec: appsv1.DeploymentConfigSpec{
+				Template: &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "system-master",
+								Env: []corev1.EnvVar{
+									{Name: "MASTER_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "system-seed"}, Key: "MASTER_PASSWORD",
+									}}},
+									{Name: "RAILS_ENV", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "system-environment"}, Key: "RAILS_ENV",
+									}}},
+									{Name: "ZYNC_AUTHENTICATION_TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "zync"}, Key: "ZYNC_AUTHENTICATION_TOKEN",
+									}}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	configHash := func() string {
+		existing := &appsv1.DeploymentConfig{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: "system-app", Namespace: namespace}, existing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return existing.Spec.Template.Annotations[ConfigHashAnnotation]
+	}
+
+	// The missing zync secret is left out of the hash
+	err = reconciler.Reconcile(desiredFactory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	initialHash := configHash()
+	if initialHash == "" {
+		t.Fatalf("%s annotation not set", ConfigHashAnnotation)
+	}
+
+	unrelatedSecret.Data["key"] = []byte("value2")
+	err = cl.Update(context.TODO(), unrelatedSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reconciler.Reconcile(desiredFactory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configHash() != initialHash {
+		t.Errorf("hash changed by a secret not consumed by the pods")
+	}
+
+	secret.Data["MASTER_PASSWORD"] = []byte("password2")
+	err = cl.Update(context.TODO(), secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reconciler.Reconcile(desiredFactory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configHash() == initialHash {
+		t.Errorf("hash not changed by a consumed secret")
+	}
+}
+
+func TestDeploymentConfigConfigReferences(t *testing.T) {
+	secretRef := func(name string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: "key"}}
+	}
+	configMapRef := func(name string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: "key"}}
+	}
+
+	dc := &appsv1.DeploymentConfig{
+		Spec: appsv1.DeploymentConfigSpec{
+			Strategy: appsv1.DeploymentStrategy{
+				RollingParams: &appsv1.RollingDeploymentStrategyParams{
+					Pre: &appsv1.LifecycleHook{
+						ExecNewPod: &appsv1.ExecNewPodHook{
+							Env: []corev1.EnvVar{{Name: "HOOK", ValueFrom: secretRef("hook-secret")}},
+						},
+					},
+				},
+			},
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "pull-secret"}},
+					InitContainers: []corev1.Container{
+						{Name: "init", Env: []corev1.EnvVar{{Name: "A", ValueFrom: secretRef("init-secret")}}},
+					},
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Env: []corev1.EnvVar{
+								{Name: "B", ValueFrom: configMapRef("main-configmap")},
+								{Name: "C", ValueFrom: secretRef("init-secret")},
+								{Name: "D", Value: "plain"},
+							},
+							EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "envfrom-secret"}}}},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{Name: "v1", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "volume-secret"}}},
+						{Name: "v2", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "volume-configmap"}}}},
+						{Name: "v3", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+							{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "projected-secret"}}},
+						}}}},
+					},
+				},
+			},
+		},
+	}
+
+	secrets, configMaps := deploymentConfigConfigReferences(dc)
+	expectedSecrets := []string{"envfrom-secret", "hook-secret", "init-secret", "projected-secret", "volume-secret"}
+	if !reflect.DeepEqual(secrets, expectedSecrets) {
+		t.Errorf("unexpected secrets: %v", secrets)
+	}
+	expectedConfigMaps := []string{"main-configmap", "volume-configmap"}
+	if !reflect.DeepEqual(configMaps, expectedConfigMaps) {
+		t.Errorf("unexpected configmaps: %v", configMaps)
+	}
+
+	// The pre hook of the recreate strategy is referenced too
+	dc.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type:           appsv1.DeploymentStrategyTypeRecreate,
+		RecreateParams: &appsv1.RecreateDeploymentStrategyParams{Pre: dc.Spec.Strategy.RollingParams.Pre},
+	}
+	secrets, _ = deploymentConfigConfigReferences(dc)
+	if !reflect.DeepEqual(secrets, expectedSecrets) {
+		t.Errorf("unexpected secrets with the recreate strategy: %v", secrets)
+	}
+}
+
+func TestRolloutPending(t *testing.T) {
+	cases := []struct {
+		name               string
+		generation         int64
+		observedGeneration int64
+		desiredReplicas    int32
+		replicas           int32
+		updatedReplicas    int32
+		expectedPending    bool
+	}{
+		{"RolledOut", 2, 2, 2, 2, 2, false},
+		{"NotObserved", 3, 2, 2, 2, 2, true},
+		{"Rolling", 2, 2, 2, 3, 1, true},
+		{"NotStarted", 2, 2, 1, 1, 0, true},
+		{"ScaledDown", 2, 2, 0, 0, 0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			pending := rolloutPending(tc.generation, tc.observedGeneration, tc.desiredReplicas, tc.replicas, tc.updatedReplicas)
+			if pending != tc.expectedPending {
+				subT.Errorf("expected pending %t, got %t", tc.expectedPending, pending)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/go-logr/logr"
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type DeploymentReconciler interface {
+	IsUpdateNeeded(desired, existing *k8sappsv1.Deployment) bool
+}
+
+type DeploymentBaseReconciler struct {
+	BaseAPIManagerLogicReconciler
+	reconciler DeploymentReconciler
+	// rolloutGate, when set, is called with the pod template about to be rolled
+	// out. The deployment is not created or updated until it returns true
+	rolloutGate func(template *v1.PodTemplateSpec) (bool, error)
+}
+
+func NewDeploymentBaseReconciler(baseAPIManagerLogicReconciler BaseAPIManagerLogicReconciler, reconciler DeploymentReconciler) *DeploymentBaseReconciler {
+	return &DeploymentBaseReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+		reconciler:                    reconciler,
+	}
+}
+
+func (r *DeploymentBaseReconciler) Reconcile(desired *k8sappsv1.Deployment) error {
+	objectInfo := ObjectInfo(desired)
+	existing := &k8sappsv1.Deployment{}
+	err := r.Client().Get(
+		context.TODO(),
+		types.NamespacedName{Name: desired.Name, Namespace: r.apiManager.GetNamespace()},
+		existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			ready, gateErr := r.rolloutReady(&desired.Spec.Template)
+			if gateErr != nil || !ready {
+				return gateErr
+			}
+			createErr := r.createResource(desired)
+			if createErr != nil {
+				r.Logger().Error(createErr, fmt.Sprintf("Error creating object %s. Requeuing request...", objectInfo))
+				return createErr
+			}
+			return nil
+		}
+		return err
+	}
+
+	existingTemplate := existing.Spec.Template.DeepCopy()
+	update, err := r.isUpdateNeeded(desired, existing)
+	if err != nil {
+		return err
+	}
+
+	if update {
+		if !reflect.DeepEqual(existingTemplate, &existing.Spec.Template) {
+			ready, err := r.rolloutReady(&existing.Spec.Template)
+			if err != nil || !ready {
+				return err
+			}
+		}
+		return r.updateResource(existing)
+	}
+
+	return nil
+}
+
+func (r *DeploymentBaseReconciler) rolloutReady(template *v1.PodTemplateSpec) (bool, error) {
+	if r.rolloutGate == nil {
+		return true, nil
+	}
+	return r.rolloutGate(template)
+}
+
+func (r *DeploymentBaseReconciler) isUpdateNeeded(desired, existing *k8sappsv1.Deployment) (bool, error) {
+	updated := helper.EnsureObjectMeta(&existing.ObjectMeta, &desired.ObjectMeta)
+
+	updatedTmp, err := r.ensureOwnerReference(existing)
+	if err != nil {
+		return false, nil
+	}
+
+	updated = updated || updatedTmp
+
+	updatedTmp = r.reconciler.IsUpdateNeeded(desired, existing)
+	updated = updated || updatedTmp
+
+	return updated, nil
+}
+
+// DeploymentReconcileContainerImages sets the images of the desired
+// containers and init containers in the existing Deployment. In the
+// Kubernetes platform there are no ImageStreams, so image updates are
+// rolled out by changing the Deployment
+func DeploymentReconcileContainerImages(desired, existing *k8sappsv1.Deployment, logger logr.Logger) bool {
+	desiredName := ObjectInfo(desired)
+	update := false
+
+	desiredPodSpec := &desired.Spec.Template.Spec
+	existingPodSpec := &existing.Spec.Template.Spec
+
+	if reconcileContainerImages(desiredPodSpec.InitContainers, existingPodSpec.InitContainers) {
+		logger.Info(fmt.Sprintf("%s spec.template.spec.initContainers images differ", desiredName))
+		update = true
+	}
+
+	if reconcileContainerImages(desiredPodSpec.Containers, existingPodSpec.Containers) {
+		logger.Info(fmt.Sprintf("%s spec.template.spec.containers images differ", desiredName))
+		update = true
+	}
+
+	return update
+}
+
+func reconcileContainerImages(desired, existing []v1.Container) bool {
+	update := false
+	for _, desiredContainer := range desired {
+		for idx := range existing {
+			if existing[idx].Name == desiredContainer.Name && existing[idx].Image != desiredContainer.Image {
+				existing[idx].Image = desiredContainer.Image
+				update = true
+			}
+		}
+	}
+	return update
+}
+
+// DeploymentRolloutPending returns true when the pods of the deployment don't run its
+// latest pod template yet
+func DeploymentRolloutPending(deployment *k8sappsv1.Deployment) bool {
+	var replicas int32 = 1
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return rolloutPending(deployment.Generation, deployment.Status.ObservedGeneration, replicas, deployment.Status.Replicas, deployment.Status.UpdatedReplicas)
+}
+
+// DeploymentConfigAdapterReconciler applies the update rules of a
+// DeploymentConfigReconciler to the Deployment rendered from the
+// DeploymentConfig, so the component specific rules are shared by both
+// platforms. Only the replicas and the pod template are reconciled
+type DeploymentConfigAdapterReconciler struct {
+	reconciler DeploymentConfigReconciler
+	logger     logr.Logger
+}
+
+func NewDeploymentConfigAdapterReconciler(reconciler DeploymentConfigReconciler, logger logr.Logger) *DeploymentConfigAdapterReconciler {
+	return &DeploymentConfigAdapterReconciler{
+		reconciler: reconciler,
+		logger:     logger,
+	}
+}
+
+func (r *DeploymentConfigAdapterReconciler) IsUpdateNeeded(desired, existing *k8sappsv1.Deployment) bool {
+	update := false
+
+	existingDC := deploymentConfigView(existing)
+	desiredDC := deploymentConfigView(desired)
+	tmpUpdate := r.reconciler.IsUpdateNeeded(desiredDC, existingDC)
+	tmpUpdate = DeploymentConfigReconcileSecretSources(desiredDC, existingDC, r.logger) || tmpUpdate
+	tmpUpdate = DeploymentConfigReconcileConfigHash(desiredDC, existingDC, r.logger) || tmpUpdate
+	if tmpUpdate {
+		existing.Spec.Replicas = &existingDC.Spec.Replicas
+		existing.Spec.Template = *existingDC.Spec.Template
+	}
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentReconcileContainerImages(desired, existing, r.logger)
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentRemovePreHookInitContainer(existing, r.logger)
+	update = update || tmpUpdate
+
+	return update
+}
+
+// DeploymentRemovePreHookInitContainer removes the init container that ran the pre
+// deployment hook in every pod in previous versions. The hook is run by a job now
+func DeploymentRemovePreHookInitContainer(existing *k8sappsv1.Deployment, logger logr.Logger) bool {
+	initContainers := []v1.Container{}
+	for _, container := range existing.Spec.Template.Spec.InitContainers {
+		if container.Name != component.PreHookContainerName {
+			initContainers = append(initContainers, container)
+		}
+	}
+	if len(initContainers) == len(existing.Spec.Template.Spec.InitContainers) {
+		return false
+	}
+
+	logger.Info(fmt.Sprintf("%s spec.template.spec.initContainers %s init container removed", ObjectInfo(existing), component.PreHookContainerName))
+	existing.Spec.Template.Spec.InitContainers = initContainers
+	return true
+}
+
+// deploymentConfigView returns a DeploymentConfig sharing the metadata and
+// the pod template of the given Deployment
+func deploymentConfigView(deployment *k8sappsv1.Deployment) *appsv1.DeploymentConfig {
+	var replicas int32 = 1
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return &appsv1.DeploymentConfig{
+		TypeMeta:   deployment.TypeMeta,
+		ObjectMeta: deployment.ObjectMeta,
+		Spec: appsv1.DeploymentConfigSpec{
+			Replicas: replicas,
+			Template: &deployment.Spec.Template,
+		},
+	}
+}
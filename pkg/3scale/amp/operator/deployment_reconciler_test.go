@@ -0,0 +1,321 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	appsv1 "github.com/openshift/api/apps/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestBackendReconcilerKubernetesPlatform(t *testing.T) {
+	var (
+		name      = "example-apimanager"
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+		platform  = appsv1alpha1.PlatformKubernetes
+	)
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				WildcardDomain: "test.3scale.net",
+				Platform:       &platform,
+			},
+		},
+	}
+	_, err := apimanager.SetDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objs := []runtime.Object{apimanager}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+	err = appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = imagev1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = routev1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+
+	baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+	baseLogicReconciler := NewBaseLogicReconciler(baseReconciler)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseLogicReconciler, apimanager)
+
+	backendReconciler := NewBackendReconciler(baseAPIManagerLogicReconciler)
+	_, err = backendReconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, deploymentName := range []string{"backend-listener", "backend-worker", "backend-cron"} {
+		namespacedName := types.NamespacedName{Name: deploymentName, Namespace: namespace}
+
+		deployment := &k8sappsv1.Deployment{}
+		err = cl.Get(context.TODO(), namespacedName, deployment)
+		if err != nil {
+			t.Fatalf("deployment %s: %s", deploymentName, err)
+		}
+		for _, container := range append(deployment.Spec.Template.Spec.InitContainers, deployment.Spec.Template.Spec.Containers...) {
+			if container.Image != BackendImageURL() {
+				t.Errorf("deployment %s container %s image. Expected: %s, got: %s", deploymentName, container.Name, BackendImageURL(), container.Image)
+			}
+		}
+
+		err = cl.Get(context.TODO(), namespacedName, &appsv1.DeploymentConfig{})
+		if !errors.IsNotFound(err) {
+			t.Errorf("deploymentconfig %s should not exist, got: %v", deploymentName, err)
+		}
+	}
+
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "backend", Namespace: namespace}, &routev1.Route{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("backend route should not exist, got: %v", err)
+	}
+}
+
+func testDeployment(namespace string, replicas int32, image string) *k8sappsv1.Deployment {
+	return &k8sappsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myDeployment",
+			Namespace: namespace,
+		},
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						v1.Container{Name: "myContainer", Image: image},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDeploymentConfigAdapterReconciler(t *testing.T) {
+	var (
+		name      = "example-apimanager"
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+	)
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	existing := testDeployment(namespace, 1, "image:1")
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+
+	objs := []runtime.Object{existing}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+
+	baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+	baseLogicReconciler := NewBaseLogicReconciler(baseReconciler)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseLogicReconciler, apimanager)
+
+	adapter := NewDeploymentConfigAdapterReconciler(NewBackendCronDCReconciler(baseAPIManagerLogicReconciler), log)
+	reconciler := NewDeploymentBaseReconciler(baseAPIManagerLogicReconciler, adapter)
+
+	err := reconciler.Reconcile(testDeployment(namespace, 3, "image:2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reconciled := &k8sappsv1.Deployment{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "myDeployment", Namespace: namespace}, reconciled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *reconciled.Spec.Replicas != 3 {
+		t.Errorf("replicas not reconciled. Expected: 3, got: %d", *reconciled.Spec.Replicas)
+	}
+	if reconciled.Spec.Template.Spec.Containers[0].Image != "image:2" {
+		t.Errorf("image not reconciled. Expected: image:2, got: %s", reconciled.Spec.Template.Spec.Containers[0].Image)
+	}
+	if len(reconciled.GetOwnerReferences()) != 1 || reconciled.GetOwnerReferences()[0].Name != name {
+		t.Errorf("reconciled does not have apimanager owner reference")
+	}
+}
+
+func TestDeploymentPreHookJob(t *testing.T) {
+	var (
+		name      = "example-apimanager"
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+		platform  = appsv1alpha1.PlatformKubernetes
+	)
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				WildcardDomain: "test.3scale.net",
+				Platform:       &platform,
+			},
+		},
+	}
+	_, err := apimanager.SetDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desiredDC := func(image string) *appsv1.DeploymentConfig {
+		return &appsv1.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "system-app", Namespace: namespace},
+			Spec: appsv1.DeploymentConfigSpec{
+				Replicas: 2,
+				Strategy: appsv1.DeploymentStrategy{
+					RollingParams: &appsv1.RollingDeploymentStrategyParams{
+						Pre: &appsv1.LifecycleHook{
+							ExecNewPod: &appsv1.ExecNewPodHook{
+								ContainerName: "system-master",
+								Command:       []string{"bash", "-c", "bundle exec rake boot openshift:deploy"},
+							},
+						},
+					},
+				},
+				Template: &v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"deploymentConfig": "system-app"}},
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{{Name: "system-master", Image: image}},
+					},
+				},
+			},
+		}
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+	objs := []runtime.Object{apimanager}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(NewBaseLogicReconciler(baseReconciler), apimanager)
+
+	reconcile := func(image string) {
+		reconciler := NewDeploymentConfigBaseReconciler(baseAPIManagerLogicReconciler, NewBackendCronDCReconciler(baseAPIManagerLogicReconciler))
+		err := reconciler.Reconcile(desiredDC(image))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hookJobs := func() []batchv1.Job {
+		jobList := &batchv1.JobList{}
+		err := cl.List(context.TODO(), jobList, client.InNamespace(namespace))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return jobList.Items
+	}
+
+	completeJobs := func() {
+		for _, job := range hookJobs() {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+			err := cl.Update(context.TODO(), &job)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	deploymentImage := func() string {
+		deployment := &k8sappsv1.Deployment{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: "system-app", Namespace: namespace}, deployment)
+		if errors.IsNotFound(err) {
+			return ""
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(deployment.Spec.Template.Spec.InitContainers) != 0 {
+			t.Errorf("unexpected init containers: %v", deployment.Spec.Template.Spec.InitContainers)
+		}
+		return deployment.Spec.Template.Spec.Containers[0].Image
+	}
+
+	// the deployment is created once the hook has run
+	reconcile("system:1")
+	jobs := hookJobs()
+	if len(jobs) != 1 {
+		t.Fatalf("expected one hook job, got %d", len(jobs))
+	}
+	hookPodSpec := jobs[0].Spec.Template.Spec
+	if hookPodSpec.Containers[0].Image != "system:1" || !reflect.DeepEqual(hookPodSpec.Containers[0].Command, []string{"bash", "-c", "bundle exec rake boot openshift:deploy"}) {
+		t.Errorf("unexpected hook container: %v", hookPodSpec.Containers[0])
+	}
+	if _, ok := jobs[0].Spec.Template.Labels["deploymentConfig"]; ok {
+		t.Error("hook pod selected by the deployment config services")
+	}
+	if image := deploymentImage(); image != "" {
+		t.Fatalf("deployment created before the hook has run")
+	}
+
+	completeJobs()
+	reconcile("system:1")
+	if image := deploymentImage(); image != "system:1" {
+		t.Fatalf("unexpected deployment image: '%s'", image)
+	}
+
+	// reconciling without changes does not run the hook again
+	reconcile("system:1")
+	if len(hookJobs()) != 1 {
+		t.Fatalf("hook run without a rollout")
+	}
+
+	// a rollout runs the hook once before the template is updated
+	reconcile("system:2")
+	if len(hookJobs()) != 2 {
+		t.Fatalf("hook not run for the rollout")
+	}
+	if image := deploymentImage(); image != "system:1" {
+		t.Fatalf("deployment rolled out before the hook has run")
+	}
+
+	completeJobs()
+	reconcile("system:2")
+	if image := deploymentImage(); image != "system:2" {
+		t.Fatalf("unexpected deployment image after the hook has run: '%s'", image)
+	}
+	if len(hookJobs()) != 1 {
+		t.Errorf("hook job of the previous rollout not deleted")
+	}
+}
@@ -0,0 +1,147 @@
+package operator
+
+import (
+	"fmt"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func (o *OperatorExposureOptionsProvider) GetExposureOptions() (*component.ExposureOptions, error) {
+	optProv := component.ExposureOptionsBuilder{}
+	optProv.AppLabel(*o.APIManagerSpec.AppLabel)
+	optProv.TenantName(*o.APIManagerSpec.TenantName)
+	optProv.WildcardDomain(o.APIManagerSpec.WildcardDomain)
+
+	err := o.setMasterNameOption(&optProv)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Exposure Options - %s", err)
+	}
+
+	err = o.setEndpointOptions(&optProv)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Exposure Options - %s", err)
+	}
+
+	res, err := optProv.Build()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Exposure Options - %s", err)
+	}
+	return res, nil
+}
+
+func (o *OperatorExposureOptionsProvider) setMasterNameOption(b *component.ExposureOptionsBuilder) error {
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemSeedSecretName, o.Namespace, o.Client)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	// same default as the one used by the system seed secret
+	b.MasterName(helper.GetSecretDataValueOrDefault(currSecret.Data, component.SystemSecretSystemSeedMasterDomainFieldName, "master"))
+	return nil
+}
+
+func (o *OperatorExposureOptionsProvider) setEndpointOptions(b *component.ExposureOptionsBuilder) error {
+	exposureSpec := o.APIManagerSpec.Exposure
+	if exposureSpec == nil {
+		return nil
+	}
+
+	if exposureSpec.IngressClass != nil {
+		b.IngressClass(*exposureSpec.IngressClass)
+	}
+
+	endpoints := []struct {
+		spec   *appsv1alpha1.EndpointExposureSpec
+		setter func(component.EndpointExposure)
+	}{
+		{exposureSpec.Master, b.Master},
+		{exposureSpec.Provider, b.Provider},
+		{exposureSpec.Developer, b.Developer},
+		{exposureSpec.ApicastStaging, b.ApicastStaging},
+		{exposureSpec.ApicastProduction, b.ApicastProduction},
+		{exposureSpec.Backend, b.Backend},
+	}
+
+	for _, endpoint := range endpoints {
+		if endpoint.spec == nil {
+			continue
+		}
+		endpointExposure, err := o.endpointExposureFromSpec(endpoint.spec)
+		if err != nil {
+			return err
+		}
+		endpoint.setter(*endpointExposure)
+	}
+
+	return nil
+}
+
+func (o *OperatorExposureOptionsProvider) endpointExposureFromSpec(spec *appsv1alpha1.EndpointExposureSpec) (*component.EndpointExposure, error) {
+	endpointExposure := &component.EndpointExposure{}
+	if spec.Host != nil {
+		endpointExposure.Host = *spec.Host
+	}
+	endpointExposure.AdditionalHosts = spec.AdditionalHosts
+
+	if spec.TLS == nil {
+		return endpointExposure, nil
+	}
+
+	endpointExposure.Termination = routev1.TLSTerminationType(spec.TLS.Termination)
+
+	if spec.TLS.CertificateSecretRef != nil {
+		secret, err := helper.GetSecret(spec.TLS.CertificateSecretRef.Name, o.Namespace, o.Client)
+		if err != nil {
+			return nil, err
+		}
+		certificate, key, err := certificateFromSecret(secret, v1.TLSCertKey, v1.TLSPrivateKeyKey)
+		if err != nil {
+			return nil, err
+		}
+		endpointExposure.CertificateSecretName = secret.Name
+		endpointExposure.Certificate = certificate
+		endpointExposure.Key = key
+		endpointExposure.CACertificate = helper.GetSecretDataValueOrDefault(secret.Data, exposureCACertificateKey, "")
+	}
+
+	if spec.TLS.DestinationCACertificateSecretRef != nil {
+		secret, err := helper.GetSecret(spec.TLS.DestinationCACertificateSecretRef.Name, o.Namespace, o.Client)
+		if err != nil {
+			return nil, err
+		}
+		destinationCACertificate, _, err := certificateFromSecret(secret, exposureCACertificateKey, "")
+		if err != nil {
+			return nil, err
+		}
+		endpointExposure.DestinationCACertificate = destinationCACertificate
+	}
+
+	return endpointExposure, nil
+}
+
+const exposureCACertificateKey = "ca.crt"
+
+// certificateFromSecret returns the values of the given certificate and key
+// fields of the secret. The key is not read when keyFieldName is empty
+func certificateFromSecret(secret *v1.Secret, certFieldName, keyFieldName string) (string, string, error) {
+	certificate := helper.GetSecretDataValue(secret.Data, certFieldName)
+	if certificate == nil {
+		return "", "", fmt.Errorf("secret '%s' does not contain the '%s' field", secret.Name, certFieldName)
+	}
+
+	if keyFieldName == "" {
+		return *certificate, "", nil
+	}
+
+	key := helper.GetSecretDataValue(secret.Data, keyFieldName)
+	if key == nil {
+		return "", "", fmt.Errorf("secret '%s' does not contain the '%s' field", secret.Name, keyFieldName)
+	}
+
+	return *certificate, *key, nil
+}
@@ -0,0 +1,201 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	routev1 "github.com/openshift/api/route/v1"
+	"k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type ExposureReconciler struct {
+	BaseAPIManagerLogicReconciler
+}
+
+// blank assignment to verify that ExposureReconciler implements LogicReconciler
+var _ LogicReconciler = &ExposureReconciler{}
+
+func NewExposureReconciler(baseAPIManagerLogicReconciler BaseAPIManagerLogicReconciler) ExposureReconciler {
+	return ExposureReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+// Reconcile manages the Routes or the Ingresses of the external endpoints
+// when the exposure is set in the APIManager spec. Resources of the kind not
+// selected by the exposure type are deleted
+func (r *ExposureReconciler) Reconcile() (reconcile.Result, error) {
+	if !r.apiManager.IsExposureManaged() {
+		return reconcile.Result{}, nil
+	}
+
+	exposure, err := r.exposure()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ingressEnabled := r.apiManager.ExposureType() == appsv1alpha1.ExposureTypeIngress
+	desiredRoutes := exposure.Routes()
+
+	if !ingressEnabled {
+		err = r.deleteZyncRoutes(desiredRoutes)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	for _, route := range desiredRoutes {
+		if ingressEnabled {
+			err = r.deleteRoute(route)
+		} else {
+			err = r.reconcileRoute(route)
+		}
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if ingressEnabled {
+		desiredRoutes = nil
+	}
+	err = r.deleteStaleRoutes(desiredRoutes)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	for _, ingress := range exposure.Ingresses() {
+		err = r.reconcileIngress(ingress, ingressEnabled)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *ExposureReconciler) exposure() (*component.Exposure, error) {
+	optsProvider := OperatorExposureOptionsProvider{APIManagerSpec: &r.apiManager.Spec, Namespace: r.apiManager.Namespace, Client: r.Client()}
+	opts, err := optsProvider.GetExposureOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewExposure(opts), nil
+}
+
+func (r *ExposureReconciler) reconcileRoute(desiredRoute *routev1.Route) error {
+	reconciler := NewRouteBaseReconciler(r.BaseAPIManagerLogicReconciler, NewRouteSpecReconciler())
+	return reconciler.Reconcile(desiredRoute)
+}
+
+func (r *ExposureReconciler) reconcileIngress(desiredIngress *v1beta1.Ingress, enabled bool) error {
+	reconciler := NewIngressReconciler(r.BaseAPIManagerLogicReconciler)
+	return reconciler.Reconcile(desiredIngress, enabled)
+}
+
+// ZyncCreatedByLabel is set by zync on the Routes it creates for the tenants
+// and the API products
+const ZyncCreatedByLabel = "3scale.net/created-by"
+
+// deleteZyncRoutes deletes the Routes created by zync that claim the host of
+// one of the desired Routes. OpenShift only admits the oldest Route of a
+// host, so they would keep the operator Routes from being admitted.
+// Zync keeps creating Routes unless the wildcard hosts of the exposure serve
+// all the tenants, so they are deleted on every reconciliation
+func (r *ExposureReconciler) deleteZyncRoutes(desiredRoutes []*routev1.Route) error {
+	hosts := map[string]bool{}
+	for _, route := range desiredRoutes {
+		hosts[route.Spec.Host] = true
+	}
+
+	routes, err := r.listRoutes(client.MatchingLabels{ZyncCreatedByLabel: "zync"})
+	if err != nil {
+		return err
+	}
+
+	for idx := range routes {
+		if !hosts[routes[idx].Spec.Host] {
+			continue
+		}
+		r.Logger().Info(fmt.Sprintf("Deleting route %s created by zync for host %s", routes[idx].Name, routes[idx].Spec.Host))
+		err = r.deleteResource(&routes[idx])
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteStaleRoutes deletes the Routes of the external endpoints owned by
+// the APIManager that are not desired anymore, like the ones of removed
+// additional hosts
+func (r *ExposureReconciler) deleteStaleRoutes(desiredRoutes []*routev1.Route) error {
+	desiredNames := map[string]bool{}
+	for _, route := range desiredRoutes {
+		desiredNames[route.Name] = true
+	}
+
+	routes, err := r.listRoutes(client.MatchingLabels{"app": *r.apiManager.Spec.AppLabel})
+	if err != nil {
+		return err
+	}
+
+	for idx := range routes {
+		route := &routes[idx]
+		if _, ok := route.Labels[component.ExposedEndpointLabel]; !ok || desiredNames[route.Name] {
+			continue
+		}
+		if !metav1.IsControlledBy(route, r.apiManager) {
+			continue
+		}
+		err = r.deleteResource(route)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listRoutes returns the Routes of the APIManager namespace matching the
+// labels. No Routes are returned when the Route kind is not available in
+// the cluster
+func (r *ExposureReconciler) listRoutes(labels client.MatchingLabels) ([]routev1.Route, error) {
+	routeList := &routev1.RouteList{}
+	err := r.Client().List(context.TODO(), routeList, client.InNamespace(r.apiManager.Namespace), labels)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return routeList.Items, nil
+}
+
+// deleteRoute deletes the existing Route with the same name as the desired
+// one when it is owned by the APIManager. Nothing is done when the Route
+// kind is not available in the cluster
+func (r *ExposureReconciler) deleteRoute(desiredRoute *routev1.Route) error {
+	existing := &routev1.Route{}
+	err := r.Client().Get(context.TODO(), r.NamespacedNameWithAPIManagerNamespace(desiredRoute), existing)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		r.Logger().Error(err, fmt.Sprintf("Error reading object %s. Requeuing request...", ObjectInfo(desiredRoute)))
+		return err
+	}
+
+	if !metav1.IsControlledBy(existing, r.apiManager) {
+		return nil
+	}
+
+	return r.deleteResource(existing)
+}
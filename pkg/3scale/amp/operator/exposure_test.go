@@ -0,0 +1,279 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func exposureTestAPIManagerSpec(exposure *appsv1alpha1.ExposureSpec) *appsv1alpha1.APIManagerSpec {
+	appLabel := "someLabel"
+	tenantName := "someTenant"
+	return &appsv1alpha1.APIManagerSpec{
+		APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+			AppLabel:       &appLabel,
+			TenantName:     &tenantName,
+			WildcardDomain: "test.3scale.net",
+		},
+		Exposure: exposure,
+	}
+}
+
+func TestGetExposureOptions(t *testing.T) {
+	namespace := "someNS"
+	customHost := "api.example.com"
+
+	certSecret := helper.GetTestSecret(namespace, "mycert", map[string]string{
+		v1.TLSCertKey:       "CERT",
+		v1.TLSPrivateKeyKey: "KEY",
+	})
+	caSecret := helper.GetTestSecret(namespace, "myca", map[string]string{"ca.crt": "CA"})
+
+	spec := exposureTestAPIManagerSpec(&appsv1alpha1.ExposureSpec{
+		ApicastProduction: &appsv1alpha1.EndpointExposureSpec{
+			Host: &customHost,
+			TLS: &appsv1alpha1.EndpointTLSSpec{
+				Termination:                       appsv1alpha1.TLSTerminationReencrypt,
+				CertificateSecretRef:              &v1.LocalObjectReference{Name: "mycert"},
+				DestinationCACertificateSecretRef: &v1.LocalObjectReference{Name: "myca"},
+			},
+		},
+	})
+
+	cl := fake.NewFakeClient([]runtime.Object{certSecret, caSecret}...)
+	optsProvider := OperatorExposureOptionsProvider{APIManagerSpec: spec, Namespace: namespace, Client: cl}
+	opts, err := optsProvider.GetExposureOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	routes := map[string]*routev1.Route{}
+	for _, route := range component.NewExposure(opts).Routes() {
+		routes[route.Name] = route
+	}
+
+	expectedHosts := map[string]string{
+		"system-master":      "master.test.3scale.net",
+		"system-provider":    "someTenant-admin.test.3scale.net",
+		"system-developer":   "someTenant.test.3scale.net",
+		"apicast-staging":    "api-someTenant-apicast-staging.test.3scale.net",
+		"apicast-production": customHost,
+		"backend":            "backend-someTenant.test.3scale.net",
+	}
+	for name, expectedHost := range expectedHosts {
+		route, ok := routes[name]
+		if !ok {
+			t.Fatalf("route %s not found", name)
+		}
+		if route.Spec.Host != expectedHost {
+			t.Errorf("route %s host. Expected: %s, got: %s", name, expectedHost, route.Spec.Host)
+		}
+	}
+
+	tls := routes["apicast-production"].Spec.TLS
+	if tls.Termination != routev1.TLSTerminationReencrypt || tls.Certificate != "CERT" || tls.Key != "KEY" || tls.DestinationCACertificate != "CA" {
+		t.Errorf("unexpected apicast-production TLS configuration: %v", tls)
+	}
+	if routes["backend"].Spec.TLS.Termination != routev1.TLSTerminationEdge {
+		t.Errorf("backend route should default to edge termination, got: %s", routes["backend"].Spec.TLS.Termination)
+	}
+}
+
+func TestGetExposureOptionsInvalidCertificate(t *testing.T) {
+	namespace := "someNS"
+
+	cases := []struct {
+		testName   string
+		secretData map[string]string
+		errSubstr  string
+	}{
+		{"NoCertificateSecretFound", nil, "\"mycert\" not found"},
+		{"CertificateMissing", map[string]string{v1.TLSPrivateKeyKey: "KEY"}, v1.TLSCertKey},
+		{"KeyMissing", map[string]string{v1.TLSCertKey: "CERT"}, v1.TLSPrivateKeyKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			objs := []runtime.Object{}
+			if tc.secretData != nil {
+				objs = append(objs, helper.GetTestSecret(namespace, "mycert", tc.secretData))
+			}
+			spec := exposureTestAPIManagerSpec(&appsv1alpha1.ExposureSpec{
+				Master: &appsv1alpha1.EndpointExposureSpec{
+					TLS: &appsv1alpha1.EndpointTLSSpec{CertificateSecretRef: &v1.LocalObjectReference{Name: "mycert"}},
+				},
+			})
+
+			cl := fake.NewFakeClient(objs...)
+			optsProvider := OperatorExposureOptionsProvider{APIManagerSpec: spec, Namespace: namespace, Client: cl}
+			_, err := optsProvider.GetExposureOptions()
+			if err == nil {
+				subT.Fatal("expected to fail")
+			}
+			if !strings.Contains(err.Error(), tc.errSubstr) {
+				subT.Fatalf("expected error regexp: %s, got: (%v)", tc.errSubstr, err)
+			}
+		})
+	}
+}
+
+func TestExposureAdditionalHosts(t *testing.T) {
+	namespace := "someNS"
+
+	spec := exposureTestAPIManagerSpec(&appsv1alpha1.ExposureSpec{
+		ApicastProduction: &appsv1alpha1.EndpointExposureSpec{
+			AdditionalHosts: []string{"api.example.com", "*.apps.example.com"},
+		},
+	})
+
+	cl := fake.NewFakeClient()
+	optsProvider := OperatorExposureOptionsProvider{APIManagerSpec: spec, Namespace: namespace, Client: cl}
+	opts, err := optsProvider.GetExposureOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exposure := component.NewExposure(opts)
+
+	wildcardPolicies := map[string]routev1.WildcardPolicyType{}
+	for _, route := range exposure.Routes() {
+		if route.Labels[component.ExposedEndpointLabel] == "apicast-production" {
+			wildcardPolicies[route.Spec.Host] = route.Spec.WildcardPolicy
+		}
+	}
+	expectedPolicies := map[string]routev1.WildcardPolicyType{
+		"api-someTenant-apicast-production.test.3scale.net": routev1.WildcardPolicyNone,
+		"api.example.com":           routev1.WildcardPolicyNone,
+		"wildcard.apps.example.com": routev1.WildcardPolicySubdomain,
+	}
+	if !reflect.DeepEqual(wildcardPolicies, expectedPolicies) {
+		t.Errorf("unexpected apicast-production routes. Expected: %v, got: %v", expectedPolicies, wildcardPolicies)
+	}
+
+	for _, ingress := range exposure.Ingresses() {
+		if ingress.Spec.TLS != nil {
+			t.Errorf("ingress %s should not have TLS configuration without certificate secret", ingress.Name)
+		}
+		if ingress.Name != "apicast-production" {
+			continue
+		}
+		hosts := []string{}
+		for _, rule := range ingress.Spec.Rules {
+			hosts = append(hosts, rule.Host)
+		}
+		expectedHosts := []string{"api-someTenant-apicast-production.test.3scale.net", "api.example.com", "*.apps.example.com"}
+		if !reflect.DeepEqual(hosts, expectedHosts) {
+			t.Errorf("unexpected apicast-production ingress hosts. Expected: %v, got: %v", expectedHosts, hosts)
+		}
+	}
+}
+
+func TestExposureReconcilerRoutes(t *testing.T) {
+	var (
+		name      = "example-apimanager"
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+	)
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: *exposureTestAPIManagerSpec(&appsv1alpha1.ExposureSpec{}),
+	}
+	_, err := apimanager.SetDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zyncRoute := func(name, host string) *routev1.Route {
+		return &routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{ZyncCreatedByLabel: "zync"},
+			},
+			Spec: routev1.RouteSpec{Host: host},
+		}
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+	err = routev1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs := []runtime.Object{
+		apimanager,
+		zyncRoute("zync-3scale-provider-abcde", "someTenant-admin.test.3scale.net"),
+		zyncRoute("zync-3scale-api-fghij", "api.other.example.com"),
+	}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(NewBaseLogicReconciler(baseReconciler), apimanager)
+
+	reconcile := func() {
+		reconciler := NewExposureReconciler(baseAPIManagerLogicReconciler)
+		_, err := reconciler.Reconcile()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	routeExists := func(name string) bool {
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, &routev1.Route{})
+		if err != nil && !errors.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	apimanager.Spec.Exposure.ApicastProduction = &appsv1alpha1.EndpointExposureSpec{AdditionalHosts: []string{"api.example.com"}}
+	reconcile()
+
+	if routeExists("zync-3scale-provider-abcde") {
+		t.Error("zync route claiming the provider host should be deleted")
+	}
+	if !routeExists("zync-3scale-api-fghij") {
+		t.Error("zync route of a host not managed by the operator should be kept")
+	}
+	if !routeExists("system-provider") {
+		t.Error("system-provider route should be created")
+	}
+
+	routeList := &routev1.RouteList{}
+	err = cl.List(context.TODO(), routeList, client.MatchingLabels{component.ExposedEndpointLabel: "apicast-production"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routeList.Items) != 2 {
+		t.Fatalf("expected 2 apicast-production routes, got %d", len(routeList.Items))
+	}
+
+	apimanager.Spec.Exposure.ApicastProduction = nil
+	reconcile()
+
+	routeList = &routev1.RouteList{}
+	err = cl.List(context.TODO(), routeList, client.MatchingLabels{component.ExposedEndpointLabel: "apicast-production"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routeList.Items) != 1 || routeList.Items[0].Name != "apicast-production" {
+		t.Errorf("route of the removed additional host should be deleted, got routes: %v", routeList.Items)
+	}
+}
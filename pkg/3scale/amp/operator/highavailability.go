@@ -31,7 +31,7 @@ func (o *OperatorHighAvailabilityOptionsProvider) GetHighAvailabilityOptions() (
 }
 
 func (o *OperatorHighAvailabilityOptionsProvider) setBackendRedisOptions(builder *component.HighAvailabilityOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.BackendSecretBackendRedisSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.BackendSecretBackendRedisSecretName, o.Namespace, o.Client)
 	if err != nil {
 		return err
 	}
@@ -50,11 +50,35 @@ func (o *OperatorHighAvailabilityOptionsProvider) setBackendRedisOptions(builder
 	}
 	builder.BackendRedisQueuesEndpoint(*result)
 
+	err = validateRedisConnectionFields(component.BackendSecretBackendRedisSecretName, secretData,
+		component.BackendSecretBackendRedisStorageURLFieldName,
+		component.BackendSecretBackendRedisStorageSentinelHostsFieldName,
+		component.BackendSecretBackendRedisStorageSentinelRoleFieldName,
+		component.BackendSecretBackendRedisStorageSSLCAFieldName)
+	if err != nil {
+		return err
+	}
+	err = validateRedisConnectionFields(component.BackendSecretBackendRedisSecretName, secretData,
+		component.BackendSecretBackendRedisQueuesURLFieldName,
+		component.BackendSecretBackendRedisQueuesSentinelHostsFieldName,
+		component.BackendSecretBackendRedisQueuesSentinelRoleFieldName,
+		component.BackendSecretBackendRedisQueuesSSLCAFieldName)
+	if err != nil {
+		return err
+	}
+
+	builder.BackendRedisStorageSentinelHosts(helper.GetSecretDataValueOrDefault(secretData, component.BackendSecretBackendRedisStorageSentinelHostsFieldName, ""))
+	builder.BackendRedisStorageSentinelRole(helper.GetSecretDataValueOrDefault(secretData, component.BackendSecretBackendRedisStorageSentinelRoleFieldName, ""))
+	builder.BackendRedisStorageSentinelPassword(helper.GetSecretDataValueOrDefault(secretData, component.BackendSecretBackendRedisStorageSentinelPasswordFieldName, ""))
+	builder.BackendRedisQueuesSentinelHosts(helper.GetSecretDataValueOrDefault(secretData, component.BackendSecretBackendRedisQueuesSentinelHostsFieldName, ""))
+	builder.BackendRedisQueuesSentinelRole(helper.GetSecretDataValueOrDefault(secretData, component.BackendSecretBackendRedisQueuesSentinelRoleFieldName, ""))
+	builder.BackendRedisQueuesSentinelPassword(helper.GetSecretDataValueOrDefault(secretData, component.BackendSecretBackendRedisQueuesSentinelPasswordFieldName, ""))
+
 	return nil
 }
 
 func (o *OperatorHighAvailabilityOptionsProvider) setSystemRedisOptions(builder *component.HighAvailabilityOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemRedisSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemRedisSecretName, o.Namespace, o.Client)
 	if err != nil {
 		return err
 	}
@@ -73,11 +97,35 @@ func (o *OperatorHighAvailabilityOptionsProvider) setSystemRedisOptions(builder
 	}
 	builder.SystemMessageBusRedisURL(*result)
 
+	err = validateRedisConnectionFields(component.SystemSecretSystemRedisSecretName, secretData,
+		component.SystemSecretSystemRedisURLFieldName,
+		component.SystemSecretSystemRedisSentinelHosts,
+		component.SystemSecretSystemRedisSentinelRole,
+		component.SystemSecretSystemRedisSSLCA)
+	if err != nil {
+		return err
+	}
+	err = validateRedisConnectionFields(component.SystemSecretSystemRedisSecretName, secretData,
+		component.SystemSecretSystemRedisMessageBusRedisURLFieldName,
+		component.SystemSecretSystemRedisMessageBusSentinelHosts,
+		component.SystemSecretSystemRedisMessageBusSentinelRole,
+		component.SystemSecretSystemRedisMessageBusSSLCA)
+	if err != nil {
+		return err
+	}
+
+	builder.SystemRedisSentinelsHosts(helper.GetSecretDataValueOrDefault(secretData, component.SystemSecretSystemRedisSentinelHosts, ""))
+	builder.SystemRedisSentinelsRole(helper.GetSecretDataValueOrDefault(secretData, component.SystemSecretSystemRedisSentinelRole, ""))
+	builder.SystemRedisSentinelsPassword(helper.GetSecretDataValueOrDefault(secretData, component.SystemSecretSystemRedisSentinelPassword, ""))
+	builder.SystemMessageBusRedisSentinelsHosts(helper.GetSecretDataValueOrDefault(secretData, component.SystemSecretSystemRedisMessageBusSentinelHosts, ""))
+	builder.SystemMessageBusRedisSentinelsRole(helper.GetSecretDataValueOrDefault(secretData, component.SystemSecretSystemRedisMessageBusSentinelRole, ""))
+	builder.SystemMessageBusRedisSentinelsPassword(helper.GetSecretDataValueOrDefault(secretData, component.SystemSecretSystemRedisMessageBusSentinelPassword, ""))
+
 	return nil
 }
 
 func (o *OperatorHighAvailabilityOptionsProvider) setSystemDatabaseOptions(builder *component.HighAvailabilityOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemDatabaseSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemDatabaseSecretName, o.Namespace, o.Client)
 	if err != nil {
 		return err
 	}
@@ -92,3 +140,18 @@ func (o *OperatorHighAvailabilityOptionsProvider) setSystemDatabaseOptions(build
 
 	return nil
 }
+
+// validateRedisConnectionFields checks the redis URL field of a secret along with its
+// sentinel and TLS fields
+func validateRedisConnectionFields(secretName string, secretData map[string][]byte, urlField, sentinelHostsField, sentinelRoleField, sslCAField string) error {
+	err := component.ValidateRedisConnection(
+		helper.GetSecretDataValueOrDefault(secretData, urlField, ""),
+		helper.GetSecretDataValueOrDefault(secretData, sentinelHostsField, ""),
+		helper.GetSecretDataValueOrDefault(secretData, sentinelRoleField, ""),
+		helper.GetSecretDataValueOrDefault(secretData, sslCAField, ""),
+	)
+	if err != nil {
+		return fmt.Errorf("Secret field '%s' of secret '%s' is not valid - %s", urlField, secretName, err)
+	}
+	return nil
+}
@@ -25,6 +25,33 @@ func systemRedisTestData() map[string]string {
 	}
 }
 
+// redisTestCA is a self-signed CA bundle of an external redis
+const redisTestCA = `-----BEGIN CERTIFICATE-----
+MIIBfTCCASKgAwIBAgITEn+3g4FEKKIKAR6E3DDornn3TjAKBggqhkjOPQQDAjAT
+MREwDwYDVQQDDAhyZWRpcy1jYTAgFw0yNjEwMTgxMTUwMjZaGA8yMTI2MDkyNDEx
+NTAyNlowEzERMA8GA1UEAwwIcmVkaXMtY2EwWTATBgcqhkjOPQIBBggqhkjOPQMB
+BwNCAAS4wTf2zypwHEtWkN/KVdZonMJo0n5M9T4KGor1P8N88XfNu/+eEK3DTj5L
+cpnssxej52Ll+K9faFYoQrFauXs3o1MwUTAdBgNVHQ4EFgQU8MZAahX8EKl3GTpS
+fpZDyKWuQucwHwYDVR0jBBgwFoAU8MZAahX8EKl3GTpSfpZDyKWuQucwDwYDVR0T
+AQH/BAUwAwEB/zAKBggqhkjOPQQDAgNJADBGAiEAhTSBZsbi7P8nYgmrytiII7gS
+kIMdrO5q+ZEBcgbMKQECIQDb9f3dXjuilPTYATqOBde0LyUuu3IlyCXD+2/aj5XE
+Jw==
+-----END CERTIFICATE-----`
+
+func backendRedisSentinelTLSTestData() map[string]string {
+	return map[string]string{
+		component.BackendSecretBackendRedisStorageURLFieldName:              "rediss://storage-master/0",
+		component.BackendSecretBackendRedisStorageSentinelHostsFieldName:    "redis://:sentinelpass@sentinel-0:26379, redis://:sentinelpass@sentinel-1:26379",
+		component.BackendSecretBackendRedisStorageSentinelRoleFieldName:     "master",
+		component.BackendSecretBackendRedisStorageSentinelPasswordFieldName: "sentinelpass",
+		component.BackendSecretBackendRedisStorageSSLCAFieldName:            redisTestCA,
+		component.BackendSecretBackendRedisQueuesURLFieldName:               "rediss://queues-master/1",
+		component.BackendSecretBackendRedisQueuesSentinelHostsFieldName:     "sentinel-0:26379,sentinel-1:26379",
+		component.BackendSecretBackendRedisQueuesSentinelRoleFieldName:      "slave",
+		component.BackendSecretBackendRedisQueuesSSLCAFieldName:             redisTestCA,
+	}
+}
+
 func systemDatabaseTestData() map[string]string {
 	return map[string]string{
 		component.SystemSecretSystemDatabaseURLFieldName: "mysql://mysql.example.com",
@@ -51,6 +78,22 @@ func TestGetHighAvailabilityOptions(t *testing.T) {
 	// then validate setted resources
 }
 
+func TestGetHighAvailabilityOptionsSentinelTLS(t *testing.T) {
+	namespace := "someNS"
+
+	backendRedisSecret := helper.GetTestSecret(namespace, component.BackendSecretBackendRedisSecretName, backendRedisSentinelTLSTestData())
+	systemRedisSecret := helper.GetTestSecret(namespace, component.SystemSecretSystemRedisSecretName, systemRedisTestData())
+	systemDatabaseSecret := helper.GetTestSecret(namespace, component.SystemSecretSystemDatabaseSecretName, systemDatabaseTestData())
+
+	objs := []runtime.Object{backendRedisSecret, systemRedisSecret, systemDatabaseSecret}
+	cl := fake.NewFakeClient(objs...)
+	optsProvider := OperatorHighAvailabilityOptionsProvider{APIManagerSpec: nil, Namespace: namespace, Client: cl}
+	_, err := optsProvider.GetHighAvailabilityOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestGetHighAvailabilityOptionsInvalid(t *testing.T) {
 	namespace := "someNS"
 
@@ -111,6 +154,63 @@ func TestGetHighAvailabilityOptionsInvalid(t *testing.T) {
 			}(),
 			systemDatabaseTestData(), component.SystemSecretSystemRedisMessageBusRedisURLFieldName,
 		},
+		{
+			"BackendRedisStorageURLInvalidScheme",
+			func() map[string]string {
+				data := backendRedisTestData()
+				data[component.BackendSecretBackendRedisStorageURLFieldName] = "http://storage.redis.example.com"
+				return data
+			}(),
+			systemRedisTestData(), systemDatabaseTestData(), "scheme 'http'",
+		},
+		{
+			"BackendRedisQueuesSentinelHostInvalid",
+			func() map[string]string {
+				data := backendRedisSentinelTLSTestData()
+				data[component.BackendSecretBackendRedisQueuesSentinelHostsFieldName] = "sentinel-0:26379,sentinel-1"
+				return data
+			}(),
+			systemRedisTestData(), systemDatabaseTestData(), component.BackendSecretBackendRedisQueuesURLFieldName,
+		},
+		{
+			"BackendRedisStorageSentinelRoleInvalid",
+			func() map[string]string {
+				data := backendRedisSentinelTLSTestData()
+				data[component.BackendSecretBackendRedisStorageSentinelRoleFieldName] = "replica"
+				return data
+			}(),
+			systemRedisTestData(), systemDatabaseTestData(), "sentinel role 'replica'",
+		},
+		{
+			"BackendRedisStorageCAInvalid",
+			func() map[string]string {
+				data := backendRedisSentinelTLSTestData()
+				data[component.BackendSecretBackendRedisStorageSSLCAFieldName] = "not a certificate"
+				return data
+			}(),
+			systemRedisTestData(), systemDatabaseTestData(), "no PEM certificates",
+		},
+		{
+			"SystemRedisCAWithoutTLS",
+			backendRedisTestData(),
+			func() map[string]string {
+				data := systemRedisTestData()
+				data[component.SystemSecretSystemRedisSSLCA] = redisTestCA
+				return data
+			}(),
+			systemDatabaseTestData(), "requires rediss",
+		},
+		{
+			"SystemRedisMessagebusNoMasterName",
+			backendRedisTestData(),
+			func() map[string]string {
+				data := systemRedisTestData()
+				data[component.SystemSecretSystemRedisMessageBusRedisURLFieldName] = "redis:///1"
+				data[component.SystemSecretSystemRedisMessageBusSentinelHosts] = "sentinel-0:26379"
+				return data
+			}(),
+			systemDatabaseTestData(), "no host or sentinel master name",
+		},
 		{
 			"SystemDatabaseURLMissing",
 			backendRedisTestData(),
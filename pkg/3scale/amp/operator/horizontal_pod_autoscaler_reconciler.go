@@ -0,0 +1,93 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type HorizontalPodAutoscalerReconciler struct {
+	BaseAPIManagerLogicReconciler
+}
+
+func NewHorizontalPodAutoscalerReconciler(baseAPIManagerLogicReconciler BaseAPIManagerLogicReconciler) *HorizontalPodAutoscalerReconciler {
+	return &HorizontalPodAutoscalerReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+// Reconcile creates or updates the desired HorizontalPodAutoscaler when
+// enabled is true. Otherwise the existing one, if any, is deleted
+func (r HorizontalPodAutoscalerReconciler) Reconcile(desired *v2beta2.HorizontalPodAutoscaler, enabled bool) error {
+	objectInfo := ObjectInfo(desired)
+	existingHPA, err := r.getCurrentHorizontalPodAutoscaler(types.NamespacedName{Name: desired.Name, Namespace: r.apiManager.GetNamespace()})
+	if err != nil {
+		r.Logger().Error(err, fmt.Sprintf("Error reading object %s. Requeuing request...", objectInfo))
+		return err
+	}
+
+	if enabled && existingHPA == nil {
+		return r.createResource(desired)
+	}
+
+	if enabled && existingHPA != nil && horizontalPodAutoscalerReconcileSpec(desired, existingHPA) {
+		r.Logger().Info(fmt.Sprintf("%s spec has changed", objectInfo))
+		return r.updateResource(existingHPA)
+	}
+
+	if !enabled && existingHPA != nil {
+		return r.deleteResource(existingHPA)
+	}
+
+	return nil
+}
+
+// horizontalPodAutoscalerReconcileSpec sets the scale target, the replica
+// bounds and the metrics of the desired HorizontalPodAutoscaler in the
+// existing one. Other fields may be defaulted by the server, so they are
+// not compared
+func horizontalPodAutoscalerReconcileSpec(desired, existing *v2beta2.HorizontalPodAutoscaler) bool {
+	update := false
+
+	if !reflect.DeepEqual(existing.Spec.ScaleTargetRef, desired.Spec.ScaleTargetRef) {
+		existing.Spec.ScaleTargetRef = desired.Spec.ScaleTargetRef
+		update = true
+	}
+
+	// The server defaults the min replicas to 1
+	if desired.Spec.MinReplicas != nil && (existing.Spec.MinReplicas == nil || *existing.Spec.MinReplicas != *desired.Spec.MinReplicas) {
+		existing.Spec.MinReplicas = desired.Spec.MinReplicas
+		update = true
+	}
+
+	if existing.Spec.MaxReplicas != desired.Spec.MaxReplicas {
+		existing.Spec.MaxReplicas = desired.Spec.MaxReplicas
+		update = true
+	}
+
+	// The server defaults empty metrics to a CPU utilization target
+	if len(desired.Spec.Metrics) > 0 && !reflect.DeepEqual(existing.Spec.Metrics, desired.Spec.Metrics) {
+		existing.Spec.Metrics = desired.Spec.Metrics
+		update = true
+	}
+
+	return update
+}
+
+func (r HorizontalPodAutoscalerReconciler) getCurrentHorizontalPodAutoscaler(selector client.ObjectKey) (*v2beta2.HorizontalPodAutoscaler, error) {
+	existing := &v2beta2.HorizontalPodAutoscaler{}
+	err := r.Client().Get(context.TODO(), selector, existing)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		return existing.DeepCopy(), nil
+	}
+	return nil, nil
+}
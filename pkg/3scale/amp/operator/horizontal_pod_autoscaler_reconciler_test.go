@@ -0,0 +1,142 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func testHorizontalPodAutoscaler(namespace string, minReplicas, maxReplicas int32) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	return &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myHorizontalPodAutoscaler",
+			Namespace: namespace,
+		},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: "apps.openshift.io/v1",
+				Kind:       "DeploymentConfig",
+				Name:       "myDC",
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: maxReplicas,
+		},
+	}
+}
+
+func TestHorizontalPodAutoscalerReconciler(t *testing.T) {
+	var (
+		name      = "example-apimanager"
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+	)
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+	err := autoscalingv2beta2.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		testName       string
+		existing       []runtime.Object
+		desired        *autoscalingv2beta2.HorizontalPodAutoscaler
+		enabled        bool
+		expectedExists bool
+	}{
+		{"Create", []runtime.Object{}, testHorizontalPodAutoscaler(namespace, 1, 3), true, true},
+		{"Update", []runtime.Object{testHorizontalPodAutoscaler(namespace, 1, 3)}, testHorizontalPodAutoscaler(namespace, 2, 5), true, true},
+		{"Delete", []runtime.Object{testHorizontalPodAutoscaler(namespace, 1, 3)}, testHorizontalPodAutoscaler(namespace, 1, 3), false, false},
+		{"DisabledNotExisting", []runtime.Object{}, testHorizontalPodAutoscaler(namespace, 1, 3), false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			cl := fake.NewFakeClient(tc.existing...)
+			clientAPIReader := fake.NewFakeClient(tc.existing...)
+
+			baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+			baseLogicReconciler := NewBaseLogicReconciler(baseReconciler)
+			baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseLogicReconciler, apimanager)
+
+			reconciler := NewHorizontalPodAutoscalerReconciler(baseAPIManagerLogicReconciler)
+			err := reconciler.Reconcile(tc.desired, tc.enabled)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			namespacedName := types.NamespacedName{
+				Name:      "myHorizontalPodAutoscaler",
+				Namespace: namespace,
+			}
+			reconciled := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+			err = cl.Get(context.TODO(), namespacedName, reconciled)
+			if !tc.expectedExists {
+				if !errors.IsNotFound(err) {
+					subT.Fatalf("HPA should not exist, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.desired.Spec, reconciled.Spec) {
+				subT.Errorf("Reconciled HPA is not the same as desired")
+			}
+			if len(reconciled.GetOwnerReferences()) != 1 || reconciled.GetOwnerReferences()[0].Name != name {
+				subT.Errorf("reconciled does not have apimanager owner reference")
+			}
+		})
+	}
+}
+
+func TestHorizontalPodAutoscalerReconcileSpec(t *testing.T) {
+	desired := testHorizontalPodAutoscaler("myNS", 1, 3)
+	desired.Spec.MinReplicas = nil
+
+	// Values defaulted by the server are not reconciled
+	existing := testHorizontalPodAutoscaler("myNS", 1, 3)
+	averageUtilization := int32(80)
+	existing.Spec.Metrics = []autoscalingv2beta2.MetricSpec{{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name:   "cpu",
+			Target: autoscalingv2beta2.MetricTarget{Type: autoscalingv2beta2.UtilizationMetricType, AverageUtilization: &averageUtilization},
+		},
+	}}
+	if horizontalPodAutoscalerReconcileSpec(desired, existing) {
+		t.Error("update should not be needed for server defaulted values")
+	}
+
+	desired = testHorizontalPodAutoscaler("myNS", 2, 5)
+	desired.Spec.ScaleTargetRef.Name = "otherDC"
+	desired.Spec.Metrics = []autoscalingv2beta2.MetricSpec{{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name:   "memory",
+			Target: autoscalingv2beta2.MetricTarget{Type: autoscalingv2beta2.UtilizationMetricType, AverageUtilization: &averageUtilization},
+		},
+	}}
+	if !horizontalPodAutoscalerReconcileSpec(desired, existing) {
+		t.Fatal("HPA spec changes were not detected")
+	}
+	if !reflect.DeepEqual(desired.Spec, existing.Spec) {
+		t.Errorf("HPA spec not reconciled. Expected: %v, got: %v", desired.Spec, existing.Spec)
+	}
+}
@@ -28,6 +28,12 @@ func NewImageStreamBaseReconciler(baseAPIManagerLogicReconciler BaseAPIManagerLo
 }
 
 func (r *ImageStreamBaseReconciler) Reconcile(desired *imagev1.ImageStream) error {
+	// ImageStreams are not available in the Kubernetes platform, the
+	// Deployments reference the images directly
+	if r.apiManager.IsKubernetesPlatform() {
+		return nil
+	}
+
 	objectInfo := ObjectInfo(desired)
 	existing := &imagev1.ImageStream{}
 	err := r.Client().Get(
@@ -0,0 +1,70 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type IngressReconciler struct {
+	BaseAPIManagerLogicReconciler
+}
+
+func NewIngressReconciler(baseAPIManagerLogicReconciler BaseAPIManagerLogicReconciler) *IngressReconciler {
+	return &IngressReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+// Reconcile creates or updates the desired Ingress when enabled is true.
+// Otherwise the existing one, if any, is deleted
+func (r IngressReconciler) Reconcile(desired *v1beta1.Ingress, enabled bool) error {
+	objectInfo := ObjectInfo(desired)
+	existing, err := r.getCurrentIngress(types.NamespacedName{Name: desired.Name, Namespace: r.apiManager.GetNamespace()})
+	if err != nil {
+		r.Logger().Error(err, fmt.Sprintf("Error reading object %s. Requeuing request...", objectInfo))
+		return err
+	}
+
+	if enabled && existing == nil {
+		return r.createResource(desired)
+	}
+
+	if enabled && existing != nil {
+		update := helper.EnsureObjectMeta(&existing.ObjectMeta, &desired.ObjectMeta)
+		if !reflect.DeepEqual(desired.Spec, existing.Spec) {
+			r.Logger().Info(fmt.Sprintf("%s spec has changed", objectInfo))
+			existing.Spec = desired.Spec
+			update = true
+		}
+		if update {
+			return r.updateResource(existing)
+		}
+		return nil
+	}
+
+	if !enabled && existing != nil {
+		return r.deleteResource(existing)
+	}
+
+	return nil
+}
+
+func (r IngressReconciler) getCurrentIngress(selector client.ObjectKey) (*v1beta1.Ingress, error) {
+	existing := &v1beta1.Ingress{}
+	err := r.Client().Get(context.TODO(), selector, existing)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		return existing.DeepCopy(), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,118 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func testIngress(namespace, host string) *networkingv1beta1.Ingress {
+	return &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myIngress",
+			Namespace: namespace,
+		},
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{
+				networkingv1beta1.IngressRule{
+					Host: host,
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{
+								networkingv1beta1.HTTPIngressPath{
+									Backend: networkingv1beta1.IngressBackend{
+										ServiceName: "myService",
+										ServicePort: intstr.FromString("http"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestIngressReconciler(t *testing.T) {
+	var (
+		name      = "example-apimanager"
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+	)
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+	err := networkingv1beta1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		testName       string
+		existing       []runtime.Object
+		desired        *networkingv1beta1.Ingress
+		enabled        bool
+		expectedExists bool
+	}{
+		{"Create", []runtime.Object{}, testIngress(namespace, "a.example.com"), true, true},
+		{"Update", []runtime.Object{testIngress(namespace, "a.example.com")}, testIngress(namespace, "b.example.com"), true, true},
+		{"Delete", []runtime.Object{testIngress(namespace, "a.example.com")}, testIngress(namespace, "a.example.com"), false, false},
+		{"DisabledNotExisting", []runtime.Object{}, testIngress(namespace, "a.example.com"), false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			cl := fake.NewFakeClient(tc.existing...)
+			clientAPIReader := fake.NewFakeClient(tc.existing...)
+
+			baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+			baseLogicReconciler := NewBaseLogicReconciler(baseReconciler)
+			baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseLogicReconciler, apimanager)
+
+			reconciler := NewIngressReconciler(baseAPIManagerLogicReconciler)
+			err := reconciler.Reconcile(tc.desired, tc.enabled)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			namespacedName := types.NamespacedName{
+				Name:      "myIngress",
+				Namespace: namespace,
+			}
+			reconciled := &networkingv1beta1.Ingress{}
+			err = cl.Get(context.TODO(), namespacedName, reconciled)
+			if !tc.expectedExists {
+				if !errors.IsNotFound(err) {
+					subT.Fatalf("Ingress should not exist, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.desired.Spec, reconciled.Spec) {
+				subT.Errorf("Reconciled Ingress is not the same as desired")
+			}
+			if len(reconciled.GetOwnerReferences()) != 1 || reconciled.GetOwnerReferences()[0].Name != name {
+				subT.Errorf("reconciled does not have apimanager owner reference")
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ func (o *OperatorMemcachedOptionsProvider) GetMemcachedOptions() (*component.Mem
 	optProv.AppLabel(*o.APIManagerSpec.AppLabel)
 
 	o.setResourceRequirementsOptions(&optProv)
+	o.setPodPlacementOptions(&optProv)
 
 	res, err := optProv.Build()
 	if err != nil {
@@ -24,4 +25,14 @@ func (o *OperatorMemcachedOptionsProvider) setResourceRequirementsOptions(b *com
 	if !*o.APIManagerSpec.ResourceRequirementsEnabled {
 		b.ResourceRequirements(v1.ResourceRequirements{})
 	}
+
+	if o.APIManagerSpec.System != nil && o.APIManagerSpec.System.MemcachedResources != nil {
+		b.ResourceRequirements(*o.APIManagerSpec.System.MemcachedResources)
+	}
+}
+
+func (o *OperatorMemcachedOptionsProvider) setPodPlacementOptions(b *component.MemcachedOptionsBuilder) {
+	if o.APIManagerSpec.System != nil && o.APIManagerSpec.System.MemcachedPodPlacement != nil {
+		b.PodPlacement(podPlacementFromSpec(*o.APIManagerSpec.System.MemcachedPodPlacement))
+	}
 }
@@ -22,6 +22,9 @@ func (r *MemcachedDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Deploym
 	tmpUpdate := DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
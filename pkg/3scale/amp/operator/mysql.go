@@ -22,6 +22,12 @@ func (o *OperatorMysqlOptionsProvider) GetMysqlOptions() (*component.SystemMysql
 	}
 
 	o.setResourceRequirementsOptions(&optProv)
+	o.setPodPlacementOptions(&optProv)
+
+	err = o.setPVCOptions(&optProv)
+	if err != nil {
+		return nil, err
+	}
 
 	res, err := optProv.Build()
 	if err != nil {
@@ -30,6 +36,22 @@ func (o *OperatorMysqlOptionsProvider) GetMysqlOptions() (*component.SystemMysql
 	return res, nil
 }
 
+func (o *OperatorMysqlOptionsProvider) setPVCOptions(builder *component.SystemMysqlOptionsBuilder) error {
+	if o.APIManagerSpec.System == nil ||
+		o.APIManagerSpec.System.DatabaseSpec == nil ||
+		o.APIManagerSpec.System.DatabaseSpec.MySQL == nil ||
+		o.APIManagerSpec.System.DatabaseSpec.MySQL.PersistentVolumeClaimSpec == nil {
+		return nil
+	}
+
+	pvcOptions, err := pvcOptionsFromSpec(o.APIManagerSpec.System.DatabaseSpec.MySQL.PersistentVolumeClaimSpec)
+	if err != nil {
+		return fmt.Errorf("unable to create Mysql PVC options - %s", err)
+	}
+	builder.PVCOptions(pvcOptions)
+	return nil
+}
+
 func (o *OperatorMysqlOptionsProvider) setSecretBasedOptions(builder *component.SystemMysqlOptionsBuilder) error {
 	err := o.setSystemDatabaseOptions(builder)
 	if err != nil {
@@ -40,7 +62,7 @@ func (o *OperatorMysqlOptionsProvider) setSecretBasedOptions(builder *component.
 }
 
 func (o *OperatorMysqlOptionsProvider) setSystemDatabaseOptions(builder *component.SystemMysqlOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemDatabaseSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemDatabaseSecretName, o.Namespace, o.Client)
 	defaultDatabaseName := "system"
 	defaultDatabaseRootPassword := oprand.String(8)
 	defaultDatabaseUsername := "mysql"
@@ -114,4 +136,19 @@ func (o *OperatorMysqlOptionsProvider) setResourceRequirementsOptions(b *compone
 	if !*o.APIManagerSpec.ResourceRequirementsEnabled {
 		b.ContainerResourceRequirements(v1.ResourceRequirements{})
 	}
+
+	if o.APIManagerSpec.System != nil &&
+		o.APIManagerSpec.System.DatabaseSpec != nil &&
+		o.APIManagerSpec.System.DatabaseSpec.MySQL != nil &&
+		o.APIManagerSpec.System.DatabaseSpec.MySQL.Resources != nil {
+		b.ContainerResourceRequirements(*o.APIManagerSpec.System.DatabaseSpec.MySQL.Resources)
+	}
+}
+
+func (o *OperatorMysqlOptionsProvider) setPodPlacementOptions(b *component.SystemMysqlOptionsBuilder) {
+	if o.APIManagerSpec.System != nil &&
+		o.APIManagerSpec.System.DatabaseSpec != nil &&
+		o.APIManagerSpec.System.DatabaseSpec.MySQL != nil {
+		b.PodPlacement(podPlacementFromSpec(o.APIManagerSpec.System.DatabaseSpec.MySQL.PodPlacementSpec))
+	}
 }
@@ -45,13 +45,22 @@ func (r *PVCBaseReconciler) Reconcile(desired *v1.PersistentVolumeClaim) error {
 		return err
 	}
 
+	existingStorageRequest := existing.Spec.Resources.Requests[v1.ResourceStorage]
+
 	update, err := r.isUpdateNeeded(desired, existing)
 	if err != nil {
 		return err
 	}
 
 	if update {
-		return r.updateResource(existing)
+		err = r.updateResource(existing)
+		storageRequest := existing.Spec.Resources.Requests[v1.ResourceStorage]
+		if errors.IsForbidden(err) && storageRequest.Cmp(existingStorageRequest) != 0 {
+			// the storage class of the claim does not allow volume expansion
+			r.Logger().Info(fmt.Sprintf("%s cannot be expanded to %s: %s", objectInfo, storageRequest.String(), err))
+			return nil
+		}
+		return err
 	}
 
 	return nil
@@ -83,3 +92,31 @@ func NewCreateOnlyPVCReconciler() *CreateOnlyPVCReconciler {
 func (r *CreateOnlyPVCReconciler) IsUpdateNeeded(desired, existing *v1.PersistentVolumeClaim) bool {
 	return false
 }
+
+// ExpandPVCReconciler expands the existing claim when the desired storage
+// request is greater. Claims are never shrunk and the rest of the claim spec
+// cannot be changed once it is created, so it is not reconciled
+type ExpandPVCReconciler struct {
+}
+
+func NewExpandPVCReconciler() *ExpandPVCReconciler {
+	return &ExpandPVCReconciler{}
+}
+
+func (r *ExpandPVCReconciler) IsUpdateNeeded(desired, existing *v1.PersistentVolumeClaim) bool {
+	desiredStorageRequest, ok := desired.Spec.Resources.Requests[v1.ResourceStorage]
+	if !ok {
+		return false
+	}
+
+	existingStorageRequest := existing.Spec.Resources.Requests[v1.ResourceStorage]
+	if desiredStorageRequest.Cmp(existingStorageRequest) <= 0 {
+		return false
+	}
+
+	if existing.Spec.Resources.Requests == nil {
+		existing.Spec.Resources.Requests = v1.ResourceList{}
+	}
+	existing.Spec.Resources.Requests[v1.ResourceStorage] = desiredStorageRequest
+	return true
+}
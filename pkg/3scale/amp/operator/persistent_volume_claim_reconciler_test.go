@@ -6,6 +6,7 @@ import (
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -222,3 +223,92 @@ func TestPVCBaseReconcilerUpdateNeeded(t *testing.T) {
 		t.Fatalf("reconciled have reconciled data. Expected: 'myClass', got: %s", *reconciled.Spec.StorageClassName)
 	}
 }
+
+func TestExpandPVCReconciler(t *testing.T) {
+	var (
+		name      = "example-apimanager"
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+	)
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1alpha1.APIManagerSpec{},
+	}
+	existing := &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myPVC",
+			Namespace: namespace,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+
+	err := controllerutil.SetControllerReference(apimanager, existing, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objs := []runtime.Object{existing}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+
+	baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+	baseLogicReconciler := NewBaseLogicReconciler(baseReconciler)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseLogicReconciler, apimanager)
+	reconciler := NewPVCBaseReconciler(baseAPIManagerLogicReconciler, NewExpandPVCReconciler())
+
+	cases := []struct {
+		testName               string
+		desiredStorageRequest  string
+		expectedStorageRequest string
+	}{
+		{"Expand", "5Gi", "5Gi"},
+		{"Shrink", "2Gi", "5Gi"},
+		{"Unchanged", "5Gi", "5Gi"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			desired := existing.DeepCopy()
+			desired.Spec.Resources.Requests[v1.ResourceStorage] = resource.MustParse(tc.desiredStorageRequest)
+			// immutable fields are not reconciled
+			desired.Spec.AccessModes = []v1.PersistentVolumeAccessMode{v1.ReadWriteMany}
+
+			err := reconciler.Reconcile(desired)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			reconciled := &v1.PersistentVolumeClaim{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: "myPVC", Namespace: namespace}, reconciled)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if storageQuantity(reconciled).String() != tc.expectedStorageRequest {
+				subT.Errorf("unexpected storage request. Expected: %s, got: %s", tc.expectedStorageRequest, storageQuantity(reconciled).String())
+			}
+			if reconciled.Spec.AccessModes[0] != v1.ReadWriteOnce {
+				subT.Errorf("access modes reconciled: %v", reconciled.Spec.AccessModes)
+			}
+		})
+	}
+}
+
+func storageQuantity(pvc *v1.PersistentVolumeClaim) *resource.Quantity {
+	storageRequest := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	return &storageRequest
+}
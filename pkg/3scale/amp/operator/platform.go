@@ -0,0 +1,61 @@
+package operator
+
+import (
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	imagev1 "github.com/openshift/api/image/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// deploymentImages returns the images that would be imported by the
+// ImageStreams of the OpenShift platform, indexed by "<imagestream>:<tag>".
+// They are used to set the images of the Deployments of the Kubernetes
+// platform, where ImageStreams are not available
+func (r BaseAPIManagerLogicReconciler) deploymentImages() (map[string]string, error) {
+	return apiManagerImages(r.apiManager, r.Client())
+}
+
+func apiManagerImages(apiManager *appsv1alpha1.APIManager, client client.Client) (map[string]string, error) {
+	spec := &apiManager.Spec
+
+	ampImagesOptsProvider := OperatorAmpImagesOptionsProvider{APIManagerSpec: spec}
+	ampImagesOpts, err := ampImagesOptsProvider.GetAmpImagesOptions()
+	if err != nil {
+		return nil, err
+	}
+	ampImages := component.NewAmpImages(ampImagesOpts)
+
+	redisOptsProvider := OperatorRedisOptionsProvider{APIManagerSpec: spec}
+	redisOpts, err := redisOptsProvider.GetRedisOptions()
+	if err != nil {
+		return nil, err
+	}
+	redis := component.NewRedis(redisOpts)
+
+	mysqlImageOptsProvider := OperatorSystemMySQLImageOptionsProvider{APIManagerSpec: spec}
+	mysqlImageOpts, err := mysqlImageOptsProvider.GetSystemMySQLImageOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	postgreSQLImageOptsProvider := OperatorSystemPostgreSQLImageOptionsProvider{APIManagerSpec: spec, Namespace: apiManager.Namespace, Client: client}
+	postgreSQLImageOpts, err := postgreSQLImageOptsProvider.GetSystemPostgreSQLImageOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	imageStreams := []*imagev1.ImageStream{
+		ampImages.BackendImageStream(),
+		ampImages.ZyncImageStream(),
+		ampImages.APICastImageStream(),
+		ampImages.SystemImageStream(),
+		ampImages.ZyncDatabasePostgreSQLImageStream(),
+		ampImages.SystemMemcachedImageStream(),
+		redis.BackendImageStream(),
+		redis.SystemImageStream(),
+		component.NewSystemMySQLImage(mysqlImageOpts).ImageStream(),
+		component.NewSystemPostgreSQLImage(postgreSQLImageOpts).ImageStream(),
+	}
+
+	return component.ImageStreamTagImages(imageStreams), nil
+}
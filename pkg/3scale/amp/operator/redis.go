@@ -29,6 +29,12 @@ func (o *OperatorRedisOptionsProvider) GetRedisOptions() (*component.RedisOption
 	}
 
 	o.setResourceRequirementsOptions(&optProv)
+	o.setPodPlacementOptions(&optProv)
+
+	err := o.setPVCOptions(&optProv)
+	if err != nil {
+		return nil, err
+	}
 
 	res, err := optProv.Build()
 	if err != nil {
@@ -42,6 +48,42 @@ func (o *OperatorRedisOptionsProvider) setResourceRequirementsOptions(b *compone
 		b.SystemRedisContainerResourceRequirements(v1.ResourceRequirements{})
 		b.BackendRedisContainerResourceRequirements(v1.ResourceRequirements{})
 	}
+
+	if o.APIManagerSpec.Backend != nil && o.APIManagerSpec.Backend.RedisResources != nil {
+		b.BackendRedisContainerResourceRequirements(*o.APIManagerSpec.Backend.RedisResources)
+	}
+	if o.APIManagerSpec.System != nil && o.APIManagerSpec.System.RedisResources != nil {
+		b.SystemRedisContainerResourceRequirements(*o.APIManagerSpec.System.RedisResources)
+	}
+}
+
+func (o *OperatorRedisOptionsProvider) setPodPlacementOptions(b *component.RedisOptionsBuilder) {
+	if o.APIManagerSpec.Backend != nil && o.APIManagerSpec.Backend.RedisPodPlacement != nil {
+		b.BackendRedisPodPlacement(podPlacementFromSpec(*o.APIManagerSpec.Backend.RedisPodPlacement))
+	}
+	if o.APIManagerSpec.System != nil && o.APIManagerSpec.System.RedisPodPlacement != nil {
+		b.SystemRedisPodPlacement(podPlacementFromSpec(*o.APIManagerSpec.System.RedisPodPlacement))
+	}
+}
+
+func (o *OperatorRedisOptionsProvider) setPVCOptions(b *component.RedisOptionsBuilder) error {
+	if o.APIManagerSpec.Backend != nil && o.APIManagerSpec.Backend.RedisPersistentVolumeClaimSpec != nil {
+		pvcOptions, err := pvcOptionsFromSpec(o.APIManagerSpec.Backend.RedisPersistentVolumeClaimSpec)
+		if err != nil {
+			return fmt.Errorf("unable to create Backend Redis PVC options - %s", err)
+		}
+		b.BackendRedisPVCOptions(pvcOptions)
+	}
+
+	if o.APIManagerSpec.System != nil && o.APIManagerSpec.System.RedisPersistentVolumeClaimSpec != nil {
+		pvcOptions, err := pvcOptionsFromSpec(o.APIManagerSpec.System.RedisPersistentVolumeClaimSpec)
+		if err != nil {
+			return fmt.Errorf("unable to create System Redis PVC options - %s", err)
+		}
+		b.SystemRedisPVCOptions(pvcOptions)
+	}
+
+	return nil
 }
 
 func Redis(cr *appsv1alpha1.APIManager) (*component.Redis, error) {
@@ -23,6 +23,9 @@ func (r *RedisSystemDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Deplo
 	tmpUpdate := DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -42,6 +45,9 @@ func (r *RedisBackendDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Depl
 	tmpUpdate := DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -128,7 +134,7 @@ func (r *RedisReconciler) reconcileBackendConfigMap(desiredConfigMap *v1.ConfigM
 }
 
 func (r *RedisReconciler) reconcileBackendPVC(desiredPVC *v1.PersistentVolumeClaim) error {
-	reconciler := NewPVCBaseReconciler(r.BaseAPIManagerLogicReconciler, NewCreateOnlyPVCReconciler())
+	reconciler := NewPVCBaseReconciler(r.BaseAPIManagerLogicReconciler, NewExpandPVCReconciler())
 	return reconciler.Reconcile(desiredPVC)
 }
 
@@ -138,7 +144,7 @@ func (r *RedisReconciler) reconcileSystemDeploymentConfig(desiredDeploymentConfi
 }
 
 func (r *RedisReconciler) reconcileSystemPVC(desiredPVC *v1.PersistentVolumeClaim) error {
-	reconciler := NewPVCBaseReconciler(r.BaseAPIManagerLogicReconciler, NewCreateOnlyPVCReconciler())
+	reconciler := NewPVCBaseReconciler(r.BaseAPIManagerLogicReconciler, NewExpandPVCReconciler())
 	return reconciler.Reconcile(desiredPVC)
 }
 
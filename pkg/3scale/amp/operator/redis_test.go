@@ -3,7 +3,11 @@ package operator
 import (
 	"testing"
 
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -93,3 +97,112 @@ func TestGetRedisOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisPVCs(t *testing.T) {
+	appLabel := "someLabel"
+	trueValue := true
+	storageClassName := "fast"
+	storageRequest := "10Gi"
+
+	spec := &appsv1alpha1.APIManagerSpec{
+		APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+			AppLabel:                     &appLabel,
+			ImageStreamTagImportInsecure: &trueValue,
+			ResourceRequirementsEnabled:  &trueValue,
+		},
+		Backend: &appsv1alpha1.BackendSpec{
+			RedisPersistentVolumeClaimSpec: &appsv1alpha1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClassName,
+				StorageRequest:   &storageRequest,
+				Selector:         &metav1.LabelSelector{MatchLabels: map[string]string{"volume": "backend-redis"}},
+			},
+		},
+	}
+
+	optsProvider := OperatorRedisOptionsProvider{APIManagerSpec: spec}
+	opts, err := optsProvider.GetRedisOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	redis := component.NewRedis(opts)
+
+	backendPVC := redis.BackendPVC()
+	if backendPVC.Spec.StorageClassName == nil || *backendPVC.Spec.StorageClassName != storageClassName {
+		t.Errorf("unexpected backend redis storage class: %v", backendPVC.Spec.StorageClassName)
+	}
+	if storageQuantity(backendPVC).String() != storageRequest {
+		t.Errorf("unexpected backend redis storage request: %s", storageQuantity(backendPVC).String())
+	}
+	if backendPVC.Spec.Selector == nil || backendPVC.Spec.Selector.MatchLabels["volume"] != "backend-redis" {
+		t.Errorf("unexpected backend redis selector: %v", backendPVC.Spec.Selector)
+	}
+
+	// not configured claims keep the defaults
+	systemPVC := redis.SystemPVC()
+	if systemPVC.Spec.StorageClassName != nil {
+		t.Errorf("unexpected system redis storage class: %s", *systemPVC.Spec.StorageClassName)
+	}
+	if storageQuantity(systemPVC).String() != "1Gi" {
+		t.Errorf("unexpected system redis storage request: %s", storageQuantity(systemPVC).String())
+	}
+	if len(systemPVC.Spec.AccessModes) != 1 || systemPVC.Spec.AccessModes[0] != v1.ReadWriteOnce {
+		t.Errorf("unexpected system redis access modes: %v", systemPVC.Spec.AccessModes)
+	}
+
+	invalidStorageRequest := "ten"
+	spec.Backend.RedisPersistentVolumeClaimSpec.StorageRequest = &invalidStorageRequest
+	_, err = optsProvider.GetRedisOptions()
+	if err == nil {
+		t.Error("expected invalid storage request error")
+	}
+}
+
+func TestRedisResourcesAndPodPlacement(t *testing.T) {
+	appLabel := "someLabel"
+	trueValue := true
+
+	backendResources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Gi")},
+	}
+	spec := &appsv1alpha1.APIManagerSpec{
+		APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+			AppLabel:                     &appLabel,
+			ImageStreamTagImportInsecure: &trueValue,
+			ResourceRequirementsEnabled:  &trueValue,
+		},
+		Backend: &appsv1alpha1.BackendSpec{
+			RedisResources: &backendResources,
+			RedisPodPlacement: &appsv1alpha1.PodPlacementSpec{
+				NodeSelector:      map[string]string{"disktype": "ssd"},
+				PriorityClassName: "high-priority",
+			},
+		},
+	}
+
+	optsProvider := OperatorRedisOptionsProvider{APIManagerSpec: spec}
+	opts, err := optsProvider.GetRedisOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	redis := component.NewRedis(opts)
+
+	backendPodSpec := redis.BackendDeploymentConfig().Spec.Template.Spec
+	if !helper.CmpResources(&backendPodSpec.Containers[0].Resources, &backendResources) {
+		t.Errorf("unexpected backend redis resources: %v", backendPodSpec.Containers[0].Resources)
+	}
+	if backendPodSpec.NodeSelector["disktype"] != "ssd" {
+		t.Errorf("unexpected backend redis node selector: %v", backendPodSpec.NodeSelector)
+	}
+	if backendPodSpec.PriorityClassName != "high-priority" {
+		t.Errorf("unexpected backend redis priority class: %s", backendPodSpec.PriorityClassName)
+	}
+
+	// not configured overrides keep the defaults
+	systemPodSpec := redis.SystemDeploymentConfig().Spec.Template.Spec
+	if systemPodSpec.Containers[0].Resources.Limits.Memory().String() != "32Gi" {
+		t.Errorf("unexpected system redis resources: %v", systemPodSpec.Containers[0].Resources)
+	}
+	if systemPodSpec.NodeSelector != nil || systemPodSpec.PriorityClassName != "" {
+		t.Errorf("unexpected system redis placement: %v %s", systemPodSpec.NodeSelector, systemPodSpec.PriorityClassName)
+	}
+}
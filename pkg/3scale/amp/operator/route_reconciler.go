@@ -45,11 +45,21 @@ func (r *RouteBaseReconciler) Reconcile(desired *routev1.Route) error {
 		return err
 	}
 
+	existingWildcardPolicy := existing.Spec.WildcardPolicy
 	update, err := r.isUpdateNeeded(desired, existing)
 	if err != nil {
 		return err
 	}
 
+	if update && existing.Spec.WildcardPolicy != existingWildcardPolicy {
+		// The wildcard policy of a route is immutable, the route is recreated
+		err = r.deleteResource(existing)
+		if err != nil {
+			return err
+		}
+		return r.createResource(desired)
+	}
+
 	if update {
 		return r.updateResource(existing)
 	}
@@ -82,3 +92,72 @@ func NewCreateOnlyRouteReconciler() *CreateOnlyRouteReconciler {
 func (r *CreateOnlyRouteReconciler) IsUpdateNeeded(desired, existing *routev1.Route) bool {
 	return false
 }
+
+// RouteSpecReconciler keeps the host, target, port, TLS configuration and
+// wildcard policy of the existing Route in sync with the desired one. Values
+// defaulted by the server, like the target weight, are not reconciled
+type RouteSpecReconciler struct {
+}
+
+func NewRouteSpecReconciler() *RouteSpecReconciler {
+	return &RouteSpecReconciler{}
+}
+
+func (r *RouteSpecReconciler) IsUpdateNeeded(desired, existing *routev1.Route) bool {
+	update := false
+
+	if existing.Spec.Host != desired.Spec.Host {
+		existing.Spec.Host = desired.Spec.Host
+		update = true
+	}
+
+	if existing.Spec.To.Kind != desired.Spec.To.Kind || existing.Spec.To.Name != desired.Spec.To.Name {
+		existing.Spec.To.Kind = desired.Spec.To.Kind
+		existing.Spec.To.Name = desired.Spec.To.Name
+		update = true
+	}
+
+	if routePortTargetPort(existing.Spec.Port) != routePortTargetPort(desired.Spec.Port) {
+		existing.Spec.Port = desired.Spec.Port
+		update = true
+	}
+
+	if !routeTLSConfigEqual(existing.Spec.TLS, desired.Spec.TLS) {
+		existing.Spec.TLS = desired.Spec.TLS
+		update = true
+	}
+
+	// The server defaults the wildcard policy to None
+	if desired.Spec.WildcardPolicy != "" && routeWildcardPolicy(existing.Spec.WildcardPolicy) != desired.Spec.WildcardPolicy {
+		existing.Spec.WildcardPolicy = desired.Spec.WildcardPolicy
+		update = true
+	}
+
+	return update
+}
+
+func routePortTargetPort(port *routev1.RoutePort) string {
+	if port == nil {
+		return ""
+	}
+	return port.TargetPort.String()
+}
+
+func routeTLSConfigEqual(a, b *routev1.TLSConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Termination == b.Termination &&
+		a.InsecureEdgeTerminationPolicy == b.InsecureEdgeTerminationPolicy &&
+		a.Certificate == b.Certificate &&
+		a.Key == b.Key &&
+		a.CACertificate == b.CACertificate &&
+		a.DestinationCACertificate == b.DestinationCACertificate
+}
+
+func routeWildcardPolicy(policy routev1.WildcardPolicyType) routev1.WildcardPolicyType {
+	if policy == "" {
+		return routev1.WildcardPolicyNone
+	}
+	return policy
+}
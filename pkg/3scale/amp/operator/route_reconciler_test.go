@@ -231,3 +231,136 @@ func TestRouteBaseReconcilerUpdateNeeded(t *testing.T) {
 		t.Fatalf("reconciled have reconciled data. Expected: '/newPath', got: %s", reconciled.Spec.Path)
 	}
 }
+
+func TestRouteSpecReconciler(t *testing.T) {
+	existing := &routev1.Route{
+		Spec: routev1.RouteSpec{
+			Host: "old.example.com",
+			To:   routev1.RouteTargetReference{Kind: "Service", Name: "myService"},
+			Path: "/existingPath",
+			TLS:  &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge},
+		},
+	}
+	desired := &routev1.Route{
+		Spec: routev1.RouteSpec{
+			Host: "new.example.com",
+			To:   routev1.RouteTargetReference{Kind: "Service", Name: "myService"},
+			TLS:  &routev1.TLSConfig{Termination: routev1.TLSTerminationReencrypt, DestinationCACertificate: "CA"},
+		},
+	}
+
+	reconciler := NewRouteSpecReconciler()
+	if !reconciler.IsUpdateNeeded(desired, existing) {
+		t.Fatal("route spec changes were not detected")
+	}
+	if existing.Spec.Host != desired.Spec.Host {
+		t.Errorf("host not reconciled. Expected: %s, got: %s", desired.Spec.Host, existing.Spec.Host)
+	}
+	if existing.Spec.TLS.Termination != routev1.TLSTerminationReencrypt || existing.Spec.TLS.DestinationCACertificate != "CA" {
+		t.Errorf("TLS configuration not reconciled. Got: %v", existing.Spec.TLS)
+	}
+	if existing.Spec.Path != "/existingPath" {
+		t.Errorf("fields not managed by the reconciler should not be changed. Got path: %s", existing.Spec.Path)
+	}
+
+	if reconciler.IsUpdateNeeded(desired, existing) {
+		t.Error("update should not be needed once reconciled")
+	}
+
+	// Values defaulted by the server are not reconciled
+	weight := int32(100)
+	existing.Spec.To.Weight = &weight
+	existing.Spec.WildcardPolicy = routev1.WildcardPolicyNone
+	desired.Spec.WildcardPolicy = routev1.WildcardPolicyNone
+	if reconciler.IsUpdateNeeded(desired, existing) {
+		t.Error("update should not be needed for server defaulted values")
+	}
+
+	desired.Spec.WildcardPolicy = routev1.WildcardPolicySubdomain
+	if !reconciler.IsUpdateNeeded(desired, existing) || existing.Spec.WildcardPolicy != routev1.WildcardPolicySubdomain {
+		t.Errorf("wildcard policy not reconciled. Got: %s", existing.Spec.WildcardPolicy)
+	}
+}
+
+func TestRouteBaseReconcilerWildcardPolicy(t *testing.T) {
+	var (
+		name      = "example-apimanager"
+		namespace = "operator-unittest"
+		log       = logf.Log.WithName("operator_test")
+	)
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1alpha1.APIManagerSpec{},
+	}
+	existing := &routev1.Route{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Route",
+			APIVersion: "route.openshift.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myRoute",
+			Namespace: namespace,
+			UID:       "old-route-uid",
+		},
+		Spec: routev1.RouteSpec{
+			Host:           "*.example.com",
+			To:             routev1.RouteTargetReference{Kind: "Service", Name: "myService"},
+			WildcardPolicy: routev1.WildcardPolicyNone,
+		},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+	err := routev1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = controllerutil.SetControllerReference(apimanager, existing, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{existing}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+
+	baseReconciler := NewBaseReconciler(cl, clientAPIReader, s, log)
+	baseLogicReconciler := NewBaseLogicReconciler(baseReconciler)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseLogicReconciler, apimanager)
+	reconciler := NewRouteBaseReconciler(baseAPIManagerLogicReconciler, NewRouteSpecReconciler())
+
+	desired := &routev1.Route{
+		TypeMeta:   existing.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{Name: "myRoute", Namespace: namespace},
+		Spec:       *existing.Spec.DeepCopy(),
+	}
+	desired.Spec.WildcardPolicy = routev1.WildcardPolicySubdomain
+
+	err = reconciler.Reconcile(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	namespacedName := types.NamespacedName{
+		Name:      "myRoute",
+		Namespace: namespace,
+	}
+	reconciled := &routev1.Route{}
+	err = cl.Get(context.TODO(), namespacedName, reconciled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The wildcard policy is immutable, the route has to be recreated
+	if reconciled.UID == existing.UID {
+		t.Error("expected the route to be recreated")
+	}
+	if reconciled.Spec.WildcardPolicy != routev1.WildcardPolicySubdomain {
+		t.Errorf("wildcard policy not reconciled. Got: %s", reconciled.Spec.WildcardPolicy)
+	}
+}
@@ -27,6 +27,11 @@ func NewSecretBaseReconciler(baseAPIManagerLogicReconciler BaseAPIManagerLogicRe
 }
 
 func (r *SecretBaseReconciler) Reconcile(desired *v1.Secret) error {
+	if r.apiManager.Spec.SecretSource(desired.Name) != nil {
+		// the content is provided by the secret source
+		return nil
+	}
+
 	objectInfo := ObjectInfo(desired)
 	existing := &v1.Secret{}
 	err := r.Client().Get(
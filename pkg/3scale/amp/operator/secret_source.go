@@ -0,0 +1,327 @@
+package operator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/go-logr/logr"
+	appsv1 "github.com/openshift/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SecretSourceVolumePrefix is the name prefix of the secret source CSI volumes
+	SecretSourceVolumePrefix = "secret-source-"
+	// SecretSourceMountPath is the directory the secret source CSI volumes are mounted in
+	SecretSourceMountPath = "/var/run/secrets/3scale"
+)
+
+// getAPIManagerSecret returns the APIManager secret read from its secret source. The
+// data of the returned secret is indexed by the field names of the APIManager secret.
+// Like helper.GetSecret, an empty secret is returned together with the error
+func getAPIManagerSecret(spec *appsv1alpha1.APIManagerSpec, name, namespace string, cl client.Client) (*v1.Secret, error) {
+	source := spec.SecretSource(name)
+	if source == nil {
+		return helper.GetSecret(name, namespace, cl)
+	}
+
+	secret, err := helper.GetSecret(source.GetSecretName(), namespace, cl)
+	if err != nil {
+		return secret, err
+	}
+
+	data := map[string][]byte{}
+	for key, value := range secret.Data {
+		data[key] = value
+	}
+	for field := range source.Keys {
+		if value, ok := secret.Data[source.GetKey(field)]; ok {
+			data[field] = value
+		}
+	}
+	secret.Data = data
+	return secret, nil
+}
+
+// secretSourceVolumeName returns the name of the CSI volume of the secret source
+func secretSourceVolumeName(source *appsv1alpha1.SecretSourceSpec) string {
+	return SecretSourceVolumePrefix + source.Secret
+}
+
+// applySecretSources points the references of the deployment config to the APIManager
+// secrets to their secret sources. The containers using a secret with a CSI source
+// mount its volume, so the secret is synced from the external store before they start
+func applySecretSources(spec *appsv1alpha1.APIManagerSpec, dc *appsv1.DeploymentConfig) {
+	if len(spec.SecretSources) == 0 || dc.Spec.Template == nil {
+		return
+	}
+
+	applyPodSecretSources(spec, &dc.Spec.Template.Spec, component.DeploymentConfigPreHook(dc))
+}
+
+// ApplyBackupSecretSources points the references of the backup or restore jobs to the
+// APIManager secrets to their secret sources, like applySecretSources does for the
+// deployment configs
+func ApplyBackupSecretSources(spec *appsv1alpha1.APIManagerSpec, steps []component.BackupStep) {
+	if len(spec.SecretSources) == 0 {
+		return
+	}
+
+	for _, step := range steps {
+		applyPodSecretSources(spec, &step.Job.Spec.Template.Spec, nil)
+	}
+}
+
+// applyPodSecretSources points the references of the pod spec, and of the pre deployment
+// hook when not nil, to the APIManager secrets to their secret sources
+func applyPodSecretSources(spec *appsv1alpha1.APIManagerSpec, podSpec *v1.PodSpec, hook *appsv1.ExecNewPodHook) {
+	csiSources := map[string]*appsv1alpha1.SecretSourceSpec{}
+
+	applyEnv := func(env []v1.EnvVar, envFrom []v1.EnvFromSource) []*appsv1alpha1.SecretSourceSpec {
+		used := []*appsv1alpha1.SecretSourceSpec{}
+		for idx := range env {
+			if env[idx].ValueFrom == nil || env[idx].ValueFrom.SecretKeyRef == nil {
+				continue
+			}
+			ref := env[idx].ValueFrom.SecretKeyRef
+			if source := spec.SecretSource(ref.Name); source != nil {
+				ref.Name = source.GetSecretName()
+				ref.Key = source.GetKey(ref.Key)
+				used = append(used, source)
+			}
+		}
+		for idx := range envFrom {
+			ref := envFrom[idx].SecretRef
+			if ref == nil {
+				continue
+			}
+			if source := spec.SecretSource(ref.Name); source != nil {
+				ref.Name = source.GetSecretName()
+				used = append(used, source)
+			}
+		}
+		return used
+	}
+
+	mountCSIVolumes := func(container *v1.Container, sources []*appsv1alpha1.SecretSourceSpec) {
+		for _, source := range sources {
+			if source.CSI == nil {
+				continue
+			}
+			csiSources[source.Secret] = source
+			volumeName := secretSourceVolumeName(source)
+			if hasVolumeMount(container.VolumeMounts, volumeName) {
+				continue
+			}
+			container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+				Name:      volumeName,
+				MountPath: path.Join(SecretSourceMountPath, source.Secret),
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	for idx := range podSpec.InitContainers {
+		container := &podSpec.InitContainers[idx]
+		mountCSIVolumes(container, applyEnv(container.Env, container.EnvFrom))
+	}
+	for idx := range podSpec.Containers {
+		container := &podSpec.Containers[idx]
+		mountCSIVolumes(container, applyEnv(container.Env, container.EnvFrom))
+	}
+	if hook != nil {
+		// the hook pod mounts the listed volumes of its container
+		hookSources := applyEnv(hook.Env, nil)
+		for idx := range podSpec.Containers {
+			container := &podSpec.Containers[idx]
+			if container.Name == hook.ContainerName {
+				mountCSIVolumes(container, hookSources)
+			}
+		}
+		for _, source := range hookSources {
+			if source.CSI != nil && !containsString(hook.Volumes, secretSourceVolumeName(source)) {
+				hook.Volumes = append(hook.Volumes, secretSourceVolumeName(source))
+			}
+		}
+	}
+
+	for idx := range podSpec.Volumes {
+		volume := &podSpec.Volumes[idx]
+		if volume.Secret != nil {
+			if source := spec.SecretSource(volume.Secret.SecretName); source != nil {
+				volume.Secret.SecretName = source.GetSecretName()
+				applySecretSourceItems(source, volume.Secret.Items)
+			}
+		}
+		if volume.Projected != nil {
+			for _, projection := range volume.Projected.Sources {
+				if projection.Secret == nil {
+					continue
+				}
+				if source := spec.SecretSource(projection.Secret.Name); source != nil {
+					projection.Secret.Name = source.GetSecretName()
+					applySecretSourceItems(source, projection.Secret.Items)
+				}
+			}
+		}
+	}
+
+	// volumes in the order of the secret sources, so the desired pod template is stable
+	for idx := range spec.SecretSources {
+		source, ok := csiSources[spec.SecretSources[idx].Secret]
+		if !ok {
+			continue
+		}
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name:         secretSourceVolumeName(source),
+			VolumeSource: v1.VolumeSource{CSI: source.CSI.DeepCopy()},
+		})
+	}
+}
+
+func applySecretSourceItems(source *appsv1alpha1.SecretSourceSpec, items []v1.KeyToPath) {
+	for idx := range items {
+		items[idx].Key = source.GetKey(items[idx].Key)
+	}
+}
+
+func hasVolumeMount(volumeMounts []v1.VolumeMount, name string) bool {
+	for _, volumeMount := range volumeMounts {
+		if volumeMount.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// DeploymentConfigReconcileSecretSources reconciles the secret references of the
+// environment and the secret source CSI volumes, so adding or removing a secret
+// source updates the existing deployment configs. The rest of the environment and
+// volumes are not reconciled
+func DeploymentConfigReconcileSecretSources(desired, existing *appsv1.DeploymentConfig, logger logr.Logger) bool {
+	if desired.Spec.Template == nil || existing.Spec.Template == nil {
+		return false
+	}
+
+	desiredName := ObjectInfo(desired)
+	update := false
+
+	desiredPodSpec := &desired.Spec.Template.Spec
+	existingPodSpec := &existing.Spec.Template.Spec
+
+	volumes, changed := reconcileManagedVolumes(existingPodSpec.Volumes, desiredPodSpec.Volumes, isSecretSourceVolume)
+	if changed {
+		logger.Info(fmt.Sprintf("%s spec.template.spec.volumes secret source volumes differ", desiredName))
+		existingPodSpec.Volumes = volumes
+		update = true
+	}
+
+	for idx := range existingPodSpec.InitContainers {
+		tmpUpdate := reconcileContainerSecretSources(desiredPodSpec.InitContainers, &existingPodSpec.InitContainers[idx])
+		if tmpUpdate {
+			logger.Info(fmt.Sprintf("%s spec.template.spec.initContainers[%d] secret references differ", desiredName, idx))
+		}
+		update = update || tmpUpdate
+	}
+
+	for idx := range existingPodSpec.Containers {
+		tmpUpdate := reconcileContainerSecretSources(desiredPodSpec.Containers, &existingPodSpec.Containers[idx])
+		if tmpUpdate {
+			logger.Info(fmt.Sprintf("%s spec.template.spec.containers[%d] secret references differ", desiredName, idx))
+		}
+		update = update || tmpUpdate
+	}
+
+	desiredHook := component.DeploymentConfigPreHook(desired)
+	existingHook := component.DeploymentConfigPreHook(existing)
+	if desiredHook != nil && existingHook != nil {
+		changed := reconcileSecretKeyRefs(existingHook.Env, desiredHook.Env)
+		volumeNames, volumesChanged := reconcileManagedNames(existingHook.Volumes, desiredHook.Volumes, isSecretSourceVolume)
+		if changed || volumesChanged {
+			logger.Info(fmt.Sprintf("%s spec.strategy pre hook secret references differ", desiredName))
+			existingHook.Volumes = volumeNames
+			update = true
+		}
+	}
+
+	return update
+}
+
+func reconcileContainerSecretSources(desiredContainers []v1.Container, existing *v1.Container) bool {
+	var desired *v1.Container
+	for idx := range desiredContainers {
+		if desiredContainers[idx].Name == existing.Name {
+			desired = &desiredContainers[idx]
+		}
+	}
+	if desired == nil {
+		return false
+	}
+
+	update := reconcileSecretKeyRefs(existing.Env, desired.Env)
+
+	for idx := range existing.EnvFrom {
+		if idx >= len(desired.EnvFrom) || existing.EnvFrom[idx].SecretRef == nil || desired.EnvFrom[idx].SecretRef == nil {
+			continue
+		}
+		if existing.EnvFrom[idx].SecretRef.Name != desired.EnvFrom[idx].SecretRef.Name {
+			existing.EnvFrom[idx].SecretRef.Name = desired.EnvFrom[idx].SecretRef.Name
+			update = true
+		}
+	}
+
+	volumeMounts, changed := reconcileManagedVolumeMounts(existing.VolumeMounts, desired.VolumeMounts, isSecretSourceVolume)
+	if changed {
+		existing.VolumeMounts = volumeMounts
+		update = true
+	}
+
+	return update
+}
+
+// reconcileSecretKeyRefs sets the secret and key of the existing variables read from a
+// secret to the ones of the desired variable with the same name
+func reconcileSecretKeyRefs(existing, desired []v1.EnvVar) bool {
+	desiredRefs := map[string]*v1.SecretKeySelector{}
+	for _, envVar := range desired {
+		if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
+			desiredRefs[envVar.Name] = envVar.ValueFrom.SecretKeyRef
+		}
+	}
+
+	update := false
+	for idx := range existing {
+		if existing[idx].ValueFrom == nil || existing[idx].ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		desiredRef, ok := desiredRefs[existing[idx].Name]
+		if !ok {
+			continue
+		}
+		existingRef := existing[idx].ValueFrom.SecretKeyRef
+		if existingRef.Name != desiredRef.Name || existingRef.Key != desiredRef.Key {
+			existingRef.Name = desiredRef.Name
+			existingRef.Key = desiredRef.Key
+			update = true
+		}
+	}
+	return update
+}
+
+func isSecretSourceVolume(name string) bool {
+	return strings.HasPrefix(name, SecretSourceVolumePrefix)
+}
@@ -0,0 +1,208 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	appsv1 "github.com/openshift/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func secretSourceTestDC() *appsv1.DeploymentConfig {
+	secretEnvVar := func(name, secretName, key string) v1.EnvVar {
+		return v1.EnvVar{
+			Name: name,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: secretName},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	return &appsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "system-app", Namespace: "operator-unittest"},
+		Spec: appsv1.DeploymentConfigSpec{
+			Strategy: appsv1.DeploymentStrategy{
+				RollingParams: &appsv1.RollingDeploymentStrategyParams{
+					Pre: &appsv1.LifecycleHook{
+						ExecNewPod: &appsv1.ExecNewPodHook{
+							ContainerName: "system-master",
+							Env: []v1.EnvVar{
+								secretEnvVar("MASTER_PASSWORD", component.SystemSecretSystemSeedSecretName, component.SystemSecretSystemSeedMasterPasswordFieldName),
+							},
+						},
+					},
+				},
+			},
+			Template: &v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "system-master",
+							Env: []v1.EnvVar{
+								secretEnvVar("MASTER_ACCESS_TOKEN", component.SystemSecretSystemSeedSecretName, component.SystemSecretSystemSeedMasterAccessTokenFieldName),
+								secretEnvVar("DATABASE_URL", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName),
+							},
+						},
+						{
+							Name: "system-sidekiq",
+							Env: []v1.EnvVar{
+								secretEnvVar("DATABASE_URL", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestApplySecretSources(t *testing.T) {
+	vaultSecretName := "vault-system-seed"
+	spec := &appsv1alpha1.APIManagerSpec{
+		SecretSources: []appsv1alpha1.SecretSourceSpec{
+			{
+				Secret:     component.SystemSecretSystemSeedSecretName,
+				SecretName: &vaultSecretName,
+				Keys:       map[string]string{component.SystemSecretSystemSeedMasterAccessTokenFieldName: "master-token"},
+				CSI: &v1.CSIVolumeSource{
+					Driver:           "secrets-store.csi.k8s.io",
+					VolumeAttributes: map[string]string{"secretProviderClass": "3scale-system-seed"},
+				},
+			},
+		},
+	}
+
+	dc := secretSourceTestDC()
+	applySecretSources(spec, dc)
+
+	masterContainer := dc.Spec.Template.Spec.Containers[0]
+	tokenRef := masterContainer.Env[0].ValueFrom.SecretKeyRef
+	if tokenRef.Name != vaultSecretName || tokenRef.Key != "master-token" {
+		t.Errorf("unexpected MASTER_ACCESS_TOKEN reference: %s/%s", tokenRef.Name, tokenRef.Key)
+	}
+	databaseRef := masterContainer.Env[1].ValueFrom.SecretKeyRef
+	if databaseRef.Name != component.SystemSecretSystemDatabaseSecretName {
+		t.Errorf("secret without source changed: %s", databaseRef.Name)
+	}
+
+	hook := dc.Spec.Strategy.RollingParams.Pre.ExecNewPod
+	passwordRef := hook.Env[0].ValueFrom.SecretKeyRef
+	if passwordRef.Name != vaultSecretName || passwordRef.Key != component.SystemSecretSystemSeedMasterPasswordFieldName {
+		t.Errorf("unexpected hook MASTER_PASSWORD reference: %s/%s", passwordRef.Name, passwordRef.Key)
+	}
+	if !reflect.DeepEqual(hook.Volumes, []string{"secret-source-system-seed"}) {
+		t.Errorf("unexpected hook volumes: %v", hook.Volumes)
+	}
+
+	volumes := dc.Spec.Template.Spec.Volumes
+	if len(volumes) != 1 || volumes[0].Name != "secret-source-system-seed" || volumes[0].CSI == nil || volumes[0].CSI.Driver != "secrets-store.csi.k8s.io" {
+		t.Fatalf("unexpected volumes: %v", volumes)
+	}
+	expectedMounts := []v1.VolumeMount{{Name: "secret-source-system-seed", MountPath: "/var/run/secrets/3scale/system-seed", ReadOnly: true}}
+	if !reflect.DeepEqual(masterContainer.VolumeMounts, expectedMounts) {
+		t.Errorf("unexpected system-master volume mounts: %v", masterContainer.VolumeMounts)
+	}
+	if len(dc.Spec.Template.Spec.Containers[1].VolumeMounts) != 0 {
+		t.Errorf("system-sidekiq mounts the secret source volume without using the secret")
+	}
+}
+
+func TestDeploymentConfigReconcileSecretSources(t *testing.T) {
+	spec := &appsv1alpha1.APIManagerSpec{
+		SecretSources: []appsv1alpha1.SecretSourceSpec{
+			{
+				Secret: component.SystemSecretSystemDatabaseSecretName,
+				Keys:   map[string]string{component.SystemSecretSystemDatabaseURLFieldName: "url"},
+				CSI:    &v1.CSIVolumeSource{Driver: "secrets-store.csi.k8s.io"},
+			},
+		},
+	}
+	log := logf.Log.WithName("operator_test")
+
+	existing := secretSourceTestDC()
+	existing.Spec.Template.Spec.Volumes = []v1.Volume{{Name: "system-storage"}}
+	desired := secretSourceTestDC()
+	applySecretSources(spec, desired)
+
+	if !DeploymentConfigReconcileSecretSources(desired, existing, log) {
+		t.Fatal("secret source not reconciled")
+	}
+	if existing.Spec.Template.Spec.Containers[1].Env[0].ValueFrom.SecretKeyRef.Key != "url" {
+		t.Errorf("DATABASE_URL key not reconciled: %v", existing.Spec.Template.Spec.Containers[1].Env[0])
+	}
+	volumeNames := []string{}
+	for _, volume := range existing.Spec.Template.Spec.Volumes {
+		volumeNames = append(volumeNames, volume.Name)
+	}
+	if !reflect.DeepEqual(volumeNames, []string{"system-storage", "secret-source-system-database"}) {
+		t.Errorf("unexpected volumes: %v", volumeNames)
+	}
+	if len(existing.Spec.Template.Spec.Containers[1].VolumeMounts) != 1 {
+		t.Errorf("unexpected volume mounts: %v", existing.Spec.Template.Spec.Containers[1].VolumeMounts)
+	}
+
+	if DeploymentConfigReconcileSecretSources(desired, existing, log) {
+		t.Error("reconciled secret sources reported update needed")
+	}
+
+	// removing the secret source restores the references to the secret
+	desired = secretSourceTestDC()
+	if !DeploymentConfigReconcileSecretSources(desired, existing, log) {
+		t.Fatal("secret source removal not reconciled")
+	}
+	if existing.Spec.Template.Spec.Containers[1].Env[0].ValueFrom.SecretKeyRef.Key != component.SystemSecretSystemDatabaseURLFieldName {
+		t.Errorf("DATABASE_URL key not restored: %v", existing.Spec.Template.Spec.Containers[1].Env[0])
+	}
+	if len(existing.Spec.Template.Spec.Volumes) != 1 || len(existing.Spec.Template.Spec.Containers[1].VolumeMounts) != 0 {
+		t.Errorf("secret source volume not removed: %v", existing.Spec.Template.Spec.Volumes)
+	}
+}
+
+func TestGetAPIManagerSecret(t *testing.T) {
+	namespace := "operator-unittest"
+	vaultSecretName := "vault-system-seed"
+	vaultSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: vaultSecretName, Namespace: namespace},
+		Data: map[string][]byte{
+			"master-token": []byte("token"),
+			component.SystemSecretSystemSeedMasterUserFieldName: []byte("master"),
+		},
+	}
+	cl := fake.NewFakeClient([]runtime.Object{vaultSecret}...)
+
+	spec := &appsv1alpha1.APIManagerSpec{
+		SecretSources: []appsv1alpha1.SecretSourceSpec{
+			{
+				Secret:     component.SystemSecretSystemSeedSecretName,
+				SecretName: &vaultSecretName,
+				Keys:       map[string]string{component.SystemSecretSystemSeedMasterAccessTokenFieldName: "master-token"},
+			},
+		},
+	}
+
+	secret, err := getAPIManagerSecret(spec, component.SystemSecretSystemSeedSecretName, namespace, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data[component.SystemSecretSystemSeedMasterAccessTokenFieldName]) != "token" {
+		t.Errorf("mapped field not read: %v", secret.Data)
+	}
+	if string(secret.Data[component.SystemSecretSystemSeedMasterUserFieldName]) != "master" {
+		t.Errorf("unmapped field not read: %v", secret.Data)
+	}
+
+	// without a source, the secret with the fixed name is read
+	_, err = getAPIManagerSecret(&appsv1alpha1.APIManagerSpec{}, component.SystemSecretSystemSeedSecretName, namespace, cl)
+	if err == nil {
+		t.Error("expected not found error")
+	}
+}
@@ -28,8 +28,14 @@ func (o *OperatorSystemOptionsProvider) GetSystemOptions() (*component.SystemOpt
 	}
 
 	o.setResourceRequirementsOptions(&optProv)
-	o.setFileStorageOptions(&optProv)
+
+	err = o.setFileStorageOptions(&optProv)
+	if err != nil {
+		return nil, err
+	}
+
 	o.setReplicas(&optProv)
+	o.setPodPlacementOptions(&optProv)
 
 	res, err := optProv.Build()
 	if err != nil {
@@ -59,6 +65,11 @@ func (o *OperatorSystemOptionsProvider) setSecretBasedOptions(builder *component
 		return fmt.Errorf("unable to create System Redis secret options - %s", err)
 	}
 
+	err = o.setBackendRedisOptions(builder)
+	if err != nil {
+		return fmt.Errorf("unable to create System Backend Redis secret options - %s", err)
+	}
+
 	err = o.setSystemAppOptions(builder)
 	if err != nil {
 		return fmt.Errorf("unable to create System App secret options - %s", err)
@@ -83,7 +94,7 @@ func (o *OperatorSystemOptionsProvider) setSecretBasedOptions(builder *component
 }
 
 func (o *OperatorSystemOptionsProvider) setSystemMemcachedOptions(builder *component.SystemOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemMemcachedSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemMemcachedSecretName, o.Namespace, o.Client)
 
 	if err != nil && !errors.IsNotFound(err) {
 		return err
@@ -95,7 +106,7 @@ func (o *OperatorSystemOptionsProvider) setSystemMemcachedOptions(builder *compo
 }
 
 func (o *OperatorSystemOptionsProvider) setSystemRecaptchaOptions(builder *component.SystemOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemRecaptchaSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemRecaptchaSecretName, o.Namespace, o.Client)
 	defaultRecaptchaPublicKey := ""
 	defaultRecaptchaPrivateKey := ""
 
@@ -111,7 +122,7 @@ func (o *OperatorSystemOptionsProvider) setSystemRecaptchaOptions(builder *compo
 }
 
 func (o *OperatorSystemOptionsProvider) setSystemEventHookOptions(builder *component.SystemOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemEventsHookSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemEventsHookSecretName, o.Namespace, o.Client)
 	defaultBackendSharedSecret := oprand.String(8)
 
 	if err != nil && !errors.IsNotFound(err) {
@@ -125,7 +136,7 @@ func (o *OperatorSystemOptionsProvider) setSystemEventHookOptions(builder *compo
 }
 
 func (o *OperatorSystemOptionsProvider) setSystemRedisOptions(builder *component.SystemOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemRedisSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemRedisSecretName, o.Namespace, o.Client)
 
 	if err != nil && !errors.IsNotFound(err) {
 		return err
@@ -140,12 +151,29 @@ func (o *OperatorSystemOptionsProvider) setSystemRedisOptions(builder *component
 	builder.MessageBusRedisURL(helper.GetSecretDataValue(secretData, component.SystemSecretSystemRedisMessageBusRedisURLFieldName))
 	builder.RedisNamespace(helper.GetSecretDataValue(secretData, component.SystemSecretSystemRedisNamespace))
 	builder.MessageBusRedisNamespace(helper.GetSecretDataValue(secretData, component.SystemSecretSystemRedisMessageBusRedisNamespace))
+	builder.RedisSentinelPassword(helper.GetSecretDataValue(secretData, component.SystemSecretSystemRedisSentinelPassword))
+	builder.MessageBusRedisSentinelPassword(helper.GetSecretDataValue(secretData, component.SystemSecretSystemRedisMessageBusSentinelPassword))
+	builder.RedisSSLCA(helper.GetSecretDataValue(secretData, component.SystemSecretSystemRedisSSLCA))
+	builder.MessageBusRedisSSLCA(helper.GetSecretDataValue(secretData, component.SystemSecretSystemRedisMessageBusSSLCA))
+
+	return nil
+}
+
+func (o *OperatorSystemOptionsProvider) setBackendRedisOptions(builder *component.SystemOptionsBuilder) error {
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.BackendSecretBackendRedisSecretName, o.Namespace, o.Client)
+
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	secretData := currSecret.Data
+	builder.BackendRedisSSLCA(helper.GetSecretDataValue(secretData, component.BackendSecretBackendRedisStorageSSLCAFieldName))
 
 	return nil
 }
 
 func (o *OperatorSystemOptionsProvider) setSystemAppOptions(builder *component.SystemOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemAppSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemAppSecretName, o.Namespace, o.Client)
 	// TODO is not exactly what we were generating
 	// in OpenShift templates. We were generating
 	// '[a-f0-9]{128}' . Ask system if there's some reason
@@ -165,7 +193,7 @@ func (o *OperatorSystemOptionsProvider) setSystemAppOptions(builder *component.S
 }
 
 func (o *OperatorSystemOptionsProvider) setSystemSeedOptions(builder *component.SystemOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemSeedSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemSeedSecretName, o.Namespace, o.Client)
 	defaultMasterDomain := "master"
 	defaultMasterUser := "master"
 	defaultMasterPassword := oprand.String(8)
@@ -192,7 +220,7 @@ func (o *OperatorSystemOptionsProvider) setSystemSeedOptions(builder *component.
 }
 
 func (o *OperatorSystemOptionsProvider) setSystemMasterApicastOptions(builder *component.SystemOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemMasterApicastSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemMasterApicastSecretName, o.Namespace, o.Client)
 	defaultSystemMasterApicastAccessToken := oprand.String(8)
 
 	if err != nil && !errors.IsNotFound(err) {
@@ -207,7 +235,7 @@ func (o *OperatorSystemOptionsProvider) setSystemMasterApicastOptions(builder *c
 }
 
 func (o *OperatorSystemOptionsProvider) setSystemSMTPOptions(builder *component.SystemOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemSMTPSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemSMTPSecretName, o.Namespace, o.Client)
 	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
@@ -237,9 +265,26 @@ func (o *OperatorSystemOptionsProvider) setResourceRequirementsOptions(b *compon
 		b.SidekiqContainerResourceRequirements(v1.ResourceRequirements{})
 		b.SphinxContainerResourceRequirements(v1.ResourceRequirements{})
 	}
+
+	appSpec := o.APIManagerSpec.System.AppSpec
+	if appSpec.MasterContainerResources != nil {
+		b.AppMasterContainerResourceRequirements(*appSpec.MasterContainerResources)
+	}
+	if appSpec.ProviderContainerResources != nil {
+		b.AppProviderContainerResourceRequirements(*appSpec.ProviderContainerResources)
+	}
+	if appSpec.DeveloperContainerResources != nil {
+		b.AppDeveloperContainerResourceRequirements(*appSpec.DeveloperContainerResources)
+	}
+	if o.APIManagerSpec.System.SidekiqSpec.Resources != nil {
+		b.SidekiqContainerResourceRequirements(*o.APIManagerSpec.System.SidekiqSpec.Resources)
+	}
+	if o.APIManagerSpec.System.SphinxResources != nil {
+		b.SphinxContainerResourceRequirements(*o.APIManagerSpec.System.SphinxResources)
+	}
 }
 
-func (o *OperatorSystemOptionsProvider) setFileStorageOptions(b *component.SystemOptionsBuilder) {
+func (o *OperatorSystemOptionsProvider) setFileStorageOptions(b *component.SystemOptionsBuilder) error {
 	if o.APIManagerSpec.System != nil &&
 		o.APIManagerSpec.System.FileStorageSpec != nil &&
 		o.APIManagerSpec.System.FileStorageSpec.S3 != nil {
@@ -249,17 +294,21 @@ func (o *OperatorSystemOptionsProvider) setFileStorageOptions(b *component.Syste
 		})
 	} else {
 		// default to PVC
-		var storageClass *string
+		pvcOptions := component.PVCOptions{}
 		if o.APIManagerSpec.System != nil &&
 			o.APIManagerSpec.System.FileStorageSpec != nil &&
 			o.APIManagerSpec.System.FileStorageSpec.PVC != nil {
-			storageClass = o.APIManagerSpec.System.FileStorageSpec.PVC.StorageClassName
+			var err error
+			pvcOptions, err = pvcOptionsFromSpec(o.APIManagerSpec.System.FileStorageSpec.PVC.PersistentVolumeClaimSpec())
+			if err != nil {
+				return fmt.Errorf("unable to create System file storage PVC options - %s", err)
+			}
 		}
 
-		b.PVCFileStorageOptions(component.PVCFileStorageOptions{
-			StorageClass: storageClass,
-		})
+		b.PVCFileStorageOptions(pvcOptions)
 	}
+
+	return nil
 }
 
 func (o *OperatorSystemOptionsProvider) setReplicas(sob *component.SystemOptionsBuilder) {
@@ -267,6 +316,14 @@ func (o *OperatorSystemOptionsProvider) setReplicas(sob *component.SystemOptions
 	sob.SidekiqReplicas(int32(*o.APIManagerSpec.System.SidekiqSpec.Replicas))
 }
 
+func (o *OperatorSystemOptionsProvider) setPodPlacementOptions(sob *component.SystemOptionsBuilder) {
+	sob.AppPodPlacement(podPlacementFromSpec(o.APIManagerSpec.System.AppSpec.PodPlacementSpec))
+	sob.SidekiqPodPlacement(podPlacementFromSpec(o.APIManagerSpec.System.SidekiqSpec.PodPlacementSpec))
+	if o.APIManagerSpec.System.SphinxPodPlacement != nil {
+		sob.SphinxPodPlacement(podPlacementFromSpec(*o.APIManagerSpec.System.SphinxPodPlacement))
+	}
+}
+
 func System(cr *appsv1alpha1.APIManager, client client.Client) (*component.System, error) {
 	optsProvider := OperatorSystemOptionsProvider{APIManagerSpec: &cr.Spec, Namespace: cr.Namespace, Client: client}
 	opts, err := optsProvider.GetSystemOptions()
@@ -22,6 +22,12 @@ func (o *OperatorSystemPostgreSQLOptionsProvider) GetSystemPostgreSQLOptions() (
 	}
 
 	o.setResourceRequirementsOptions(&optProv)
+	o.setPodPlacementOptions(&optProv)
+
+	err = o.setPVCOptions(&optProv)
+	if err != nil {
+		return nil, err
+	}
 
 	res, err := optProv.Build()
 	if err != nil {
@@ -30,6 +36,22 @@ func (o *OperatorSystemPostgreSQLOptionsProvider) GetSystemPostgreSQLOptions() (
 	return res, nil
 }
 
+func (o *OperatorSystemPostgreSQLOptionsProvider) setPVCOptions(builder *component.SystemPostgreSQLOptionsBuilder) error {
+	if o.APIManagerSpec.System == nil ||
+		o.APIManagerSpec.System.DatabaseSpec == nil ||
+		o.APIManagerSpec.System.DatabaseSpec.PostgreSQL == nil ||
+		o.APIManagerSpec.System.DatabaseSpec.PostgreSQL.PersistentVolumeClaimSpec == nil {
+		return nil
+	}
+
+	pvcOptions, err := pvcOptionsFromSpec(o.APIManagerSpec.System.DatabaseSpec.PostgreSQL.PersistentVolumeClaimSpec)
+	if err != nil {
+		return fmt.Errorf("unable to create System PostgreSQL PVC options - %s", err)
+	}
+	builder.PVCOptions(pvcOptions)
+	return nil
+}
+
 func (o *OperatorSystemPostgreSQLOptionsProvider) setSecretBasedOptions(builder *component.SystemPostgreSQLOptionsBuilder) error {
 	err := o.setSystemDatabaseOptions(builder)
 	if err != nil {
@@ -40,7 +62,7 @@ func (o *OperatorSystemPostgreSQLOptionsProvider) setSecretBasedOptions(builder
 }
 
 func (o *OperatorSystemPostgreSQLOptionsProvider) setSystemDatabaseOptions(builder *component.SystemPostgreSQLOptionsBuilder) error {
-	currSecret, err := helper.GetSecret(component.SystemSecretSystemDatabaseSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.SystemSecretSystemDatabaseSecretName, o.Namespace, o.Client)
 	defaultDatabaseName := "system"
 	defaultDatabaseUsername := "system"
 	defaultDatabasePassword := oprand.String(8)
@@ -110,4 +132,19 @@ func (o *OperatorSystemPostgreSQLOptionsProvider) setResourceRequirementsOptions
 	if !*o.APIManagerSpec.ResourceRequirementsEnabled {
 		b.ContainerResourceRequirements(v1.ResourceRequirements{})
 	}
+
+	if o.APIManagerSpec.System != nil &&
+		o.APIManagerSpec.System.DatabaseSpec != nil &&
+		o.APIManagerSpec.System.DatabaseSpec.PostgreSQL != nil &&
+		o.APIManagerSpec.System.DatabaseSpec.PostgreSQL.Resources != nil {
+		b.ContainerResourceRequirements(*o.APIManagerSpec.System.DatabaseSpec.PostgreSQL.Resources)
+	}
+}
+
+func (o *OperatorSystemPostgreSQLOptionsProvider) setPodPlacementOptions(b *component.SystemPostgreSQLOptionsBuilder) {
+	if o.APIManagerSpec.System != nil &&
+		o.APIManagerSpec.System.DatabaseSpec != nil &&
+		o.APIManagerSpec.System.DatabaseSpec.PostgreSQL != nil {
+		b.PodPlacement(podPlacementFromSpec(o.APIManagerSpec.System.DatabaseSpec.PostgreSQL.PodPlacementSpec))
+	}
 }
@@ -23,6 +23,9 @@ func (r *SystemPostgresqlDCReconciler) IsUpdateNeeded(desired, existing *appsv1.
 	tmpUpdate := DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -94,6 +97,6 @@ func (r *SystemPostgreSQLReconciler) reconcileSystemPostgreSQLSystemDatabaseSecr
 }
 
 func (r *SystemPostgreSQLReconciler) reconcileSystemPostgreSQLDataPersistentVolumeClaim(desiredPVC *v1.PersistentVolumeClaim) error {
-	reconciler := NewPVCBaseReconciler(r.BaseAPIManagerLogicReconciler, NewCreateOnlyPVCReconciler())
+	reconciler := NewPVCBaseReconciler(r.BaseAPIManagerLogicReconciler, NewExpandPVCReconciler())
 	return reconciler.Reconcile(desiredPVC)
 }
@@ -29,6 +29,12 @@ func (r *SystemSphinxDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Depl
 	tmpUpdate := DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentConfigReconcileRedisConnection(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -48,9 +54,15 @@ func (r *SystemSidekiqDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Dep
 	tmpUpdate := DeploymentConfigReconcileReplicas(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	tmpUpdate = DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcileRedisConnection(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -71,6 +83,9 @@ func (r *SystemAppDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Deploym
 	tmpUpdate := DeploymentConfigReconcileReplicas(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	//
 	// Check containers
 	//
@@ -97,6 +112,9 @@ func (r *SystemAppDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Deploym
 		}
 	}
 
+	tmpUpdate = DeploymentConfigReconcileRedisConnection(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -278,7 +296,7 @@ func (r *SystemReconciler) reconcileS3Storage() error {
 }
 
 func (r *SystemReconciler) reconcileSharedStorage(desiredPVC *v1.PersistentVolumeClaim) error {
-	reconciler := NewPVCBaseReconciler(r.BaseAPIManagerLogicReconciler, NewCreateOnlyPVCReconciler())
+	reconciler := NewPVCBaseReconciler(r.BaseAPIManagerLogicReconciler, NewExpandPVCReconciler())
 	return reconciler.Reconcile(desiredPVC)
 }
 
@@ -340,3 +340,58 @@ func TestGetSystemOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemRedisTLS(t *testing.T) {
+	name := "example-apimanager"
+	namespace := "someNS"
+
+	systemRedisSecret := helper.GetTestSecret(namespace, component.SystemSecretSystemRedisSecretName, map[string]string{
+		component.SystemSecretSystemRedisURLFieldName: "rediss://system-redis/1",
+		component.SystemSecretSystemRedisSSLCA:        redisTestCA,
+	})
+	backendRedisSecret := helper.GetTestSecret(namespace, component.BackendSecretBackendRedisSecretName, backendRedisSentinelTLSTestData())
+	apimanager := basicApimanagerSpecTestSystemOptions(name, namespace)
+	cl := fake.NewFakeClient(apimanager, systemRedisSecret, backendRedisSecret)
+	optsProvider := OperatorSystemOptionsProvider{
+		APIManagerSpec: &apimanager.Spec,
+		Namespace:      namespace,
+		Client:         cl,
+	}
+	opts, err := optsProvider.GetSystemOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dc := component.NewSystem(opts).SidekiqDeploymentConfig()
+
+	volumes := map[string]v1.Volume{}
+	for _, volume := range dc.Spec.Template.Spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	tlsVolume, ok := volumes[component.RedisTLSVolumeName]
+	if !ok {
+		t.Fatalf("redis TLS volume not found: %v", dc.Spec.Template.Spec.Volumes)
+	}
+	if len(tlsVolume.Projected.Sources) != 2 {
+		t.Fatalf("expected the system redis and backend redis storage CA files, got: %v", tlsVolume.Projected.Sources)
+	}
+
+	env := map[string]string{}
+	for _, envVar := range dc.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	if env["REDIS_CA_FILE"] != "/tls/redis/system-redis-ca.crt" || env["BACKEND_REDIS_CA_FILE"] != "/tls/redis/backend-redis-storage-ca.crt" {
+		t.Errorf("unexpected CA files: %s, %s", env["REDIS_CA_FILE"], env["BACKEND_REDIS_CA_FILE"])
+	}
+	if _, ok := env["MESSAGE_BUS_REDIS_CA_FILE"]; ok {
+		t.Error("unexpected message bus CA file without message bus CA bundle")
+	}
+
+	mounted := false
+	for _, volumeMount := range dc.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mounted = mounted || volumeMount.Name == component.RedisTLSVolumeName
+	}
+	if !mounted {
+		t.Error("redis TLS volume not mounted")
+	}
+}
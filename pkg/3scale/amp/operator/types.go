@@ -72,3 +72,9 @@ type OperatorHighAvailabilityOptionsProvider struct {
 	Namespace      string
 	Client         k8sclient.Client
 }
+
+type OperatorExposureOptionsProvider struct {
+	APIManagerSpec *appsv1alpha1.APIManagerSpec
+	Namespace      string
+	Client         k8sclient.Client
+}
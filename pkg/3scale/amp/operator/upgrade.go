@@ -1,28 +1,227 @@
 package operator
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/version"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// UpgradeStep is a single action of an upgrade. Steps can be run more than
+// once when an upgrade is resumed, so they must be idempotent
+type UpgradeStep struct {
+	Name string
+	Run  func(u *UpgradeApiManager) (reconcile.Result, error)
+}
+
+// UpgradePath describes the migration of an APIManager deployed by the
+// operator version FromOperatorVersion to the operator version
+// ToOperatorVersion
+type UpgradePath struct {
+	FromOperatorVersion string
+	// When set, it must match the 3scale version of the APIManagers
+	// deployed by FromOperatorVersion
+	FromThreescaleVersion string
+	ToOperatorVersion     string
+	// PreSteps are run before the components are reconciled with the
+	// version of the running operator. Eg. secret field migrations or
+	// DeploymentConfig spec changes
+	PreSteps []UpgradeStep
+	// PostSteps are run once the components have been reconciled with the
+	// version of the running operator. Eg. database migration hooks
+	PostSteps []UpgradeStep
+}
+
+// UnsupportedUpgradeError is returned when there is no chain of upgrade
+// paths from the version of an APIManager to the version of the operator
+type UnsupportedUpgradeError struct {
+	FromOperatorVersion   string
+	FromThreescaleVersion string
+	ToOperatorVersion     string
+}
+
+func (e *UnsupportedUpgradeError) Error() string {
+	return fmt.Sprintf("upgrade from operator version %s (3scale %s) to %s is not supported",
+		e.FromOperatorVersion, e.FromThreescaleVersion, e.ToOperatorVersion)
+}
+
+// IsUnsupportedUpgradeError returns true if the error is an UnsupportedUpgradeError
+func IsUnsupportedUpgradeError(err error) bool {
+	_, ok := err.(*UnsupportedUpgradeError)
+	return ok
+}
+
+// FindUpgradePaths returns the ordered chain of paths that upgrades an
+// APIManager deployed by fromOperatorVersion to toOperatorVersion
+func FindUpgradePaths(paths []UpgradePath, fromOperatorVersion, fromThreescaleVersion, toOperatorVersion string) ([]UpgradePath, error) {
+	chain := []UpgradePath{}
+	current := fromOperatorVersion
+	// a chain cannot be longer than the number of paths
+	for hops := 0; current != toOperatorVersion && hops < len(paths); hops++ {
+		var next *UpgradePath
+		for idx := range paths {
+			if paths[idx].FromOperatorVersion != current {
+				continue
+			}
+			// the 3scale version is only known for the installed version
+			if hops == 0 && paths[idx].FromThreescaleVersion != "" && paths[idx].FromThreescaleVersion != fromThreescaleVersion {
+				continue
+			}
+			next = &paths[idx]
+			break
+		}
+		if next == nil {
+			break
+		}
+		chain = append(chain, *next)
+		current = next.ToOperatorVersion
+	}
+
+	if current != toOperatorVersion || len(chain) == 0 {
+		return nil, &UnsupportedUpgradeError{
+			FromOperatorVersion:   fromOperatorVersion,
+			FromThreescaleVersion: fromThreescaleVersion,
+			ToOperatorVersion:     toOperatorVersion,
+		}
+	}
+
+	return chain, nil
+}
+
 type UpgradeApiManager struct {
 	Cr              *appsv1alpha1.APIManager
 	Client          client.Client
 	Logger          logr.Logger
 	ApiClientReader client.Reader
 	Scheme          *runtime.Scheme
+	// Paths are the supported upgrade paths. Defaults to UpgradePaths
+	Paths []UpgradePath
+	// ReconcileComponents reconciles the components with the version of
+	// the running operator. It is run between pre and post steps
+	ReconcileComponents func() (reconcile.Result, error)
 }
 
+// Upgrade runs the steps of the chain of upgrade paths from the version of
+// the APIManager to the version of the running operator. The progress is
+// recorded in the APIManager status so a failed upgrade is resumed from the
+// first step not completed
 func (u *UpgradeApiManager) Upgrade() (reconcile.Result, error) {
-	res, err := u.upgradeImages()
-	if res.Requeue || err != nil {
-		return res, err
+	fromOperatorVersion := u.Cr.Annotations[appsv1alpha1.OperatorVersionAnnotation]
+	fromThreescaleVersion := u.Cr.Annotations[appsv1alpha1.ThreescaleVersionAnnotation]
+
+	paths := u.Paths
+	if paths == nil {
+		paths = UpgradePaths
 	}
 
-	return reconcile.Result{}, nil
+	chain, err := FindUpgradePaths(paths, fromOperatorVersion, fromThreescaleVersion, version.Version)
+	if err != nil {
+		u.startUpgrade(fromOperatorVersion, fromThreescaleVersion)
+		statusErr := u.failUpgrade("", err)
+		if statusErr != nil {
+			u.Logger.Error(statusErr, "Error updating upgrade status")
+		}
+		return reconcile.Result{}, err
+	}
+
+	u.startUpgrade(fromOperatorVersion, fromThreescaleVersion)
+	err = u.updateUpgradeStatus()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	for _, step := range u.upgradeSteps(chain) {
+		if u.Cr.Status.Upgrade.IsStepCompleted(step.Name) {
+			continue
+		}
+
+		u.Logger.Info(fmt.Sprintf("Running upgrade step %s", step.Name))
+		res, err := step.Run(u)
+		if err != nil {
+			statusErr := u.failUpgrade(step.Name, err)
+			if statusErr != nil {
+				u.Logger.Error(statusErr, "Error updating upgrade status")
+			}
+			return reconcile.Result{}, err
+		}
+		if res.Requeue {
+			return res, nil
+		}
+
+		u.Cr.Status.Upgrade.CompletedSteps = append(u.Cr.Status.Upgrade.CompletedSteps, step.Name)
+		u.Cr.Status.Upgrade.FailedStep = ""
+		u.Cr.Status.Upgrade.Message = ""
+		err = u.updateUpgradeStatus()
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	u.Cr.Status.Upgrade.Phase = appsv1alpha1.APIManagerUpgradePhaseCompleted
+	return reconcile.Result{}, u.updateUpgradeStatus()
+}
+
+// upgradeSteps returns the pre steps of every path of the chain, the
+// reconciliation of the components and the post steps of every path of the
+// chain. Only the components of the running operator version can be
+// deployed, so intermediate versions are never rolled out
+func (u *UpgradeApiManager) upgradeSteps(chain []UpgradePath) []UpgradeStep {
+	steps := []UpgradeStep{}
+	for _, path := range chain {
+		for _, step := range path.PreSteps {
+			steps = append(steps, UpgradeStep{Name: fmt.Sprintf("%s/pre/%s", path.ToOperatorVersion, step.Name), Run: step.Run})
+		}
+	}
+
+	if u.ReconcileComponents != nil {
+		steps = append(steps, UpgradeStep{
+			Name: fmt.Sprintf("%s/reconcile", version.Version),
+			Run:  func(*UpgradeApiManager) (reconcile.Result, error) { return u.ReconcileComponents() },
+		})
+	}
+
+	for _, path := range chain {
+		for _, step := range path.PostSteps {
+			steps = append(steps, UpgradeStep{Name: fmt.Sprintf("%s/post/%s", path.ToOperatorVersion, step.Name), Run: step.Run})
+		}
+	}
+	return steps
+}
+
+// startUpgrade initializes the upgrade status unless it already records
+// the same upgrade, which is then resumed
+func (u *UpgradeApiManager) startUpgrade(fromOperatorVersion, fromThreescaleVersion string) {
+	upgrade := u.Cr.Status.Upgrade
+	if upgrade == nil ||
+		upgrade.FromOperatorVersion != fromOperatorVersion ||
+		upgrade.FromThreescaleVersion != fromThreescaleVersion ||
+		upgrade.ToOperatorVersion != version.Version ||
+		upgrade.ToThreescaleVersion != product.ThreescaleRelease {
+		u.Cr.Status.Upgrade = &appsv1alpha1.APIManagerUpgradeStatus{
+			FromOperatorVersion:   fromOperatorVersion,
+			FromThreescaleVersion: fromThreescaleVersion,
+			ToOperatorVersion:     version.Version,
+			ToThreescaleVersion:   product.ThreescaleRelease,
+		}
+	}
+	u.Cr.Status.Upgrade.Phase = appsv1alpha1.APIManagerUpgradePhaseInProgress
+}
+
+func (u *UpgradeApiManager) failUpgrade(step string, err error) error {
+	u.Cr.Status.Upgrade.Phase = appsv1alpha1.APIManagerUpgradePhaseFailed
+	u.Cr.Status.Upgrade.FailedStep = step
+	u.Cr.Status.Upgrade.Message = err.Error()
+	return u.updateUpgradeStatus()
+}
+
+func (u *UpgradeApiManager) updateUpgradeStatus() error {
+	return u.Client.Status().Update(context.TODO(), u.Cr)
 }
 
 func (u *UpgradeApiManager) upgradeImages() (reconcile.Result, error) {
@@ -0,0 +1,14 @@
+package operator
+
+// UpgradePaths are the upgrades supported by the operator. A path has to
+// be added for every released operator version
+var UpgradePaths = []UpgradePath{
+	{
+		FromOperatorVersion:   "0.5.0",
+		FromThreescaleVersion: "2.8",
+		ToOperatorVersion:     "0.6.0",
+		PreSteps: []UpgradeStep{
+			{Name: "images", Run: (*UpgradeApiManager).upgradeImages},
+		},
+	},
+}
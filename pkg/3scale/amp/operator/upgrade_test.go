@@ -0,0 +1,151 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestFindUpgradePaths(t *testing.T) {
+	paths := []UpgradePath{
+		{FromOperatorVersion: "0.4.0", FromThreescaleVersion: "2.7", ToOperatorVersion: "0.5.0"},
+		{FromOperatorVersion: "0.5.0", FromThreescaleVersion: "2.8", ToOperatorVersion: "0.6.0"},
+	}
+
+	cases := []struct {
+		testName              string
+		fromOperatorVersion   string
+		fromThreescaleVersion string
+		toOperatorVersion     string
+		expectedFromVersions  []string
+	}{
+		{"ConsecutiveVersions", "0.5.0", "2.8", "0.6.0", []string{"0.5.0"}},
+		{"MultipleVersions", "0.4.0", "2.7", "0.6.0", []string{"0.4.0", "0.5.0"}},
+		{"UnknownVersion", "0.3.0", "2.6", "0.6.0", nil},
+		{"ThreescaleVersionMismatch", "0.5.0", "2.7", "0.6.0", nil},
+		{"Downgrade", "0.6.0", "2.9", "0.5.0", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			chain, err := FindUpgradePaths(paths, tc.fromOperatorVersion, tc.fromThreescaleVersion, tc.toOperatorVersion)
+			if tc.expectedFromVersions == nil {
+				if !IsUnsupportedUpgradeError(err) {
+					subT.Fatalf("expected unsupported upgrade error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			fromVersions := []string{}
+			for _, path := range chain {
+				fromVersions = append(fromVersions, path.FromOperatorVersion)
+			}
+			if !reflect.DeepEqual(fromVersions, tc.expectedFromVersions) {
+				subT.Errorf("Unexpected upgrade chain. Expected: %v, got: %v", tc.expectedFromVersions, fromVersions)
+			}
+		})
+	}
+}
+
+func TestUpgradeApiManagerResume(t *testing.T) {
+	name := "example-apimanager"
+	namespace := "operator-unittest"
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				appsv1alpha1.OperatorVersionAnnotation:   "0.0.1",
+				appsv1alpha1.ThreescaleVersionAnnotation: "2.0",
+			},
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, apimanager)
+	cl := fake.NewFakeClient([]runtime.Object{apimanager}...)
+
+	runSteps := []string{}
+	failingStep := true
+	step := func(name string) UpgradeStep {
+		return UpgradeStep{Name: name, Run: func(*UpgradeApiManager) (reconcile.Result, error) {
+			if name == "failing" && failingStep {
+				return reconcile.Result{}, fmt.Errorf("step failed")
+			}
+			runSteps = append(runSteps, name)
+			return reconcile.Result{}, nil
+		}}
+	}
+
+	upgrade := &UpgradeApiManager{
+		Cr:     apimanager,
+		Client: cl,
+		Logger: logf.Log.WithName("operator_test"),
+		Scheme: s,
+		Paths: []UpgradePath{
+			{
+				FromOperatorVersion: "0.0.1",
+				ToOperatorVersion:   version.Version,
+				PreSteps:            []UpgradeStep{step("first"), step("failing")},
+				PostSteps:           []UpgradeStep{step("last")},
+			},
+		},
+		ReconcileComponents: func() (reconcile.Result, error) {
+			runSteps = append(runSteps, "reconcile")
+			return reconcile.Result{}, nil
+		},
+	}
+
+	_, err := upgrade.Upgrade()
+	if err == nil {
+		t.Fatal("upgrade should fail")
+	}
+
+	upgradedAPIManager := &appsv1alpha1.APIManager{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, upgradedAPIManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upgradeStatus := upgradedAPIManager.Status.Upgrade
+	if upgradeStatus == nil {
+		t.Fatal("upgrade status not found")
+	}
+	if upgradeStatus.Phase != appsv1alpha1.APIManagerUpgradePhaseFailed {
+		t.Errorf("Unexpected upgrade phase. Expected: %s, got: %s", appsv1alpha1.APIManagerUpgradePhaseFailed, upgradeStatus.Phase)
+	}
+	expectedFailedStep := fmt.Sprintf("%s/pre/failing", version.Version)
+	if upgradeStatus.FailedStep != expectedFailedStep {
+		t.Errorf("Unexpected failed step. Expected: %s, got: %s", expectedFailedStep, upgradeStatus.FailedStep)
+	}
+
+	failingStep = false
+	upgrade.Cr = upgradedAPIManager
+	_, err = upgrade.Upgrade()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRunSteps := []string{"first", "failing", "reconcile", "last"}
+	if !reflect.DeepEqual(runSteps, expectedRunSteps) {
+		t.Errorf("Unexpected run steps. Expected: %v, got: %v", expectedRunSteps, runSteps)
+	}
+	if upgradedAPIManager.Status.Upgrade.Phase != appsv1alpha1.APIManagerUpgradePhaseCompleted {
+		t.Errorf("Unexpected upgrade phase. Expected: %s, got: %s", appsv1alpha1.APIManagerUpgradePhaseCompleted, upgradedAPIManager.Status.Upgrade.Phase)
+	}
+	if upgradedAPIManager.Status.Upgrade.FromOperatorVersion != "0.0.1" {
+		t.Errorf("Unexpected upgrade from version. Expected: 0.0.1, got: %s", upgradedAPIManager.Status.Upgrade.FromOperatorVersion)
+	}
+}
@@ -3,9 +3,53 @@ package operator
 import (
 	"fmt"
 
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/common"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func ObjectInfo(obj common.KubernetesObject) string {
 	return fmt.Sprintf("%s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())
 }
+
+func autoscalingFromSpec(spec *appsv1alpha1.AutoscalingSpec, replicas int32) component.Autoscaling {
+	minReplicas := replicas
+	if spec.MinReplicas != nil {
+		minReplicas = *spec.MinReplicas
+	}
+
+	return component.Autoscaling{
+		MinReplicas:                       minReplicas,
+		MaxReplicas:                       spec.MaxReplicas,
+		TargetCPUUtilizationPercentage:    spec.TargetCPUUtilizationPercentage,
+		TargetMemoryUtilizationPercentage: spec.TargetMemoryUtilizationPercentage,
+	}
+}
+
+func podPlacementFromSpec(spec appsv1alpha1.PodPlacementSpec) component.PodPlacement {
+	return component.PodPlacement{
+		NodeSelector:      spec.NodeSelector,
+		Tolerations:       spec.Tolerations,
+		Affinity:          spec.Affinity,
+		PriorityClassName: spec.PriorityClassName,
+	}
+}
+
+func pvcOptionsFromSpec(spec *appsv1alpha1.PersistentVolumeClaimSpec) (component.PVCOptions, error) {
+	options := component.PVCOptions{
+		StorageClass: spec.StorageClassName,
+		AccessModes:  spec.AccessModes,
+		Selector:     spec.Selector,
+	}
+
+	if spec.StorageRequest != nil {
+		storageRequest, err := resource.ParseQuantity(*spec.StorageRequest)
+		if err != nil {
+			return options, fmt.Errorf("invalid storage request '%s' - %s", *spec.StorageRequest, err)
+		}
+		options.StorageRequest = &storageRequest
+	}
+
+	return options, nil
+}
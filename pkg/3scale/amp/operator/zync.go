@@ -21,6 +21,17 @@ func (o *OperatorZyncOptionsProvider) GetZyncOptions() (*component.ZyncOptions,
 
 	o.setResourceRequirementsOptions(&optProv)
 	o.setReplicas(&optProv)
+	o.setPodPlacementOptions(&optProv)
+
+	// Zync keeps creating the Routes of the hosts the operator doesn't
+	// expose, unless the wildcard hosts of the exposure serve them
+	exposure := o.APIManagerSpec.Exposure
+	optProv.RouteCreationDisabled(exposure != nil && exposure.CoversTenantHosts())
+
+	err = o.setDatabasePVCOptions(&optProv)
+	if err != nil {
+		return nil, err
+	}
 
 	res, err := optProv.Build()
 	if err != nil {
@@ -43,7 +54,7 @@ func (o *OperatorZyncOptionsProvider) setZyncSecretOptions(zob *component.ZyncOp
 	defaultZyncDatabasePassword := oprand.String(16)
 	defaultZyncAuthenticationToken := oprand.String(16)
 
-	currSecret, err := helper.GetSecret(component.ZyncSecretName, o.Namespace, o.Client)
+	currSecret, err := getAPIManagerSecret(o.APIManagerSpec, component.ZyncSecretName, o.Namespace, o.Client)
 
 	if err != nil && !errors.IsNotFound(err) {
 		return err
@@ -65,9 +76,40 @@ func (o *OperatorZyncOptionsProvider) setResourceRequirementsOptions(b *componen
 		b.QueContainerResourceRequirements(v1.ResourceRequirements{})
 		b.DatabaseContainerResourceRequirements(v1.ResourceRequirements{})
 	}
+
+	if o.APIManagerSpec.Zync.AppSpec.Resources != nil {
+		b.ContainerResourceRequirements(*o.APIManagerSpec.Zync.AppSpec.Resources)
+	}
+	if o.APIManagerSpec.Zync.QueSpec.Resources != nil {
+		b.QueContainerResourceRequirements(*o.APIManagerSpec.Zync.QueSpec.Resources)
+	}
+	if o.APIManagerSpec.Zync.DatabaseResources != nil {
+		b.DatabaseContainerResourceRequirements(*o.APIManagerSpec.Zync.DatabaseResources)
+	}
 }
 
 func (o *OperatorZyncOptionsProvider) setReplicas(zob *component.ZyncOptionsBuilder) {
 	zob.ZyncReplicas(int32(*o.APIManagerSpec.Zync.AppSpec.Replicas))
 	zob.ZyncQueReplicas(int32(*o.APIManagerSpec.Zync.QueSpec.Replicas))
 }
+
+func (o *OperatorZyncOptionsProvider) setPodPlacementOptions(zob *component.ZyncOptionsBuilder) {
+	zob.ZyncPodPlacement(podPlacementFromSpec(o.APIManagerSpec.Zync.AppSpec.PodPlacementSpec))
+	zob.ZyncQuePodPlacement(podPlacementFromSpec(o.APIManagerSpec.Zync.QueSpec.PodPlacementSpec))
+	if o.APIManagerSpec.Zync.DatabasePodPlacement != nil {
+		zob.DatabasePodPlacement(podPlacementFromSpec(*o.APIManagerSpec.Zync.DatabasePodPlacement))
+	}
+}
+
+func (o *OperatorZyncOptionsProvider) setDatabasePVCOptions(zob *component.ZyncOptionsBuilder) error {
+	if o.APIManagerSpec.Zync.DatabasePersistentVolumeClaimSpec == nil {
+		return nil
+	}
+
+	pvcOptions, err := pvcOptionsFromSpec(o.APIManagerSpec.Zync.DatabasePersistentVolumeClaimSpec)
+	if err != nil {
+		return fmt.Errorf("unable to create Zync database PVC options - %s", err)
+	}
+	zob.DatabasePVCOptions(pvcOptions)
+	return nil
+}
@@ -1,10 +1,14 @@
 package operator
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	appsv1 "github.com/openshift/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -24,6 +28,12 @@ func (r *ZyncDatabaseDCReconciler) IsUpdateNeeded(desired, existing *appsv1.Depl
 	tmpUpdate := DeploymentConfigReconcileContainerResources(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
+	// The data volume is not reconciled. Switching the volume source would
+	// discard the zync database of existing installs
+
 	return update
 }
 
@@ -46,6 +56,16 @@ func (r *ZyncDCReconciler) IsUpdateNeeded(desired, existing *appsv1.DeploymentCo
 	tmpUpdate = DeploymentConfigReconcileReplicas(desired, existing, r.Logger())
 	update = update || tmpUpdate
 
+	tmpUpdate = DeploymentConfigReconcilePodPlacement(desired, existing, r.Logger())
+	update = update || tmpUpdate
+
+	// accepts the previous token while the credential is rotated
+	tmpUpdate = DeploymentConfigReconcileContainerEnvVar(desired, existing, "ZYNC_PREVIOUS_AUTHENTICATION_TOKEN", r.Logger())
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentConfigReconcileContainerEnvVar(desired, existing, component.ZyncDisableRoutesCreationEnvVarName, r.Logger())
+	update = update || tmpUpdate
+
 	return update
 }
 
@@ -93,7 +113,15 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.reconcileZyncDatabaseDeploymentConfig(zync.DatabaseDeploymentConfig())
+	databaseDeploymentConfig := zync.DatabaseDeploymentConfig()
+	if databasePVC := zync.DatabasePVC(); databasePVC != nil {
+		err = r.reconcileZyncDatabasePVCOnNewInstalls(databasePVC, databaseDeploymentConfig)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	err = r.reconcileZyncDatabaseDeploymentConfig(databaseDeploymentConfig)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -166,6 +194,37 @@ func (r *ZyncReconciler) reconcileZyncDatabaseDeploymentConfig(desiredDeployment
 	return reconciler.Reconcile(desiredDeploymentConfig)
 }
 
+func (r *ZyncReconciler) reconcileZyncDatabasePVC(desiredPVC *v1.PersistentVolumeClaim) error {
+	reconciler := NewPVCBaseReconciler(r.BaseAPIManagerLogicReconciler, NewExpandPVCReconciler())
+	return reconciler.Reconcile(desiredPVC)
+}
+
+// reconcileZyncDatabasePVCOnNewInstalls reconciles the zync database PVC unless
+// the existing zync database uses an emptyDir volume, which is kept
+func (r *ZyncReconciler) reconcileZyncDatabasePVCOnNewInstalls(desiredPVC *v1.PersistentVolumeClaim, desiredDeploymentConfig *appsv1.DeploymentConfig) error {
+	existing := &appsv1.DeploymentConfig{}
+	err := r.Client().Get(context.TODO(), r.NamespacedNameWithAPIManagerNamespace(desiredDeploymentConfig), existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil && zyncDatabaseUsesEmptyDir(existing) {
+		r.Logger().Info(fmt.Sprintf("%s keeps its emptyDir volume. Delete it to use the PersistentVolumeClaim", ObjectInfo(desiredDeploymentConfig)))
+		return nil
+	}
+
+	return r.reconcileZyncDatabasePVC(desiredPVC)
+}
+
+func zyncDatabaseUsesEmptyDir(dc *appsv1.DeploymentConfig) bool {
+	for _, volume := range dc.Spec.Template.Spec.Volumes {
+		if volume.Name == component.ZyncDatabaseDataVolumeName {
+			return volume.EmptyDir != nil
+		}
+	}
+	return false
+}
+
 func (r *ZyncReconciler) reconcileZyncService(desiredService *v1.Service) error {
 	reconciler := NewServiceBaseReconciler(r.BaseAPIManagerLogicReconciler, NewCreateOnlySvcReconciler())
 	return reconciler.Reconcile(desiredService)
@@ -116,3 +116,80 @@ func TestNewZyncReconciler(t *testing.T) {
 		})
 	}
 }
+
+func TestZyncReconcilerDatabasePVC(t *testing.T) {
+	var (
+		name                 = "example-apimanager"
+		namespace            = "operator-unittest"
+		log                  = logf.Log.WithName("operator_test")
+		appLabel             = "someLabel"
+		trueValue            = true
+		oneValue       int64 = 1
+		storageRequest       = "2Gi"
+	)
+
+	newAPIManager := func() *appsv1alpha1.APIManager {
+		return &appsv1alpha1.APIManager{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: appsv1alpha1.APIManagerSpec{
+				APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+					AppLabel:                     &appLabel,
+					ImageStreamTagImportInsecure: &trueValue,
+					WildcardDomain:               "test.3scale.net",
+					ResourceRequirementsEnabled:  &trueValue,
+				},
+				Zync: &appsv1alpha1.ZyncSpec{
+					AppSpec: &appsv1alpha1.ZyncAppSpec{Replicas: &oneValue},
+					QueSpec: &appsv1alpha1.ZyncQueSpec{Replicas: &oneValue},
+				},
+			},
+		}
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.SchemeGroupVersion, &appsv1alpha1.APIManager{})
+	err := appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reconcileZync := func(apimanager *appsv1alpha1.APIManager, objs ...runtime.Object) *appsv1.DeploymentConfig {
+		objs = append(objs, apimanager)
+		cl := fake.NewFakeClient(objs...)
+		baseReconciler := NewBaseReconciler(cl, fake.NewFakeClient(objs...), s, log)
+		zyncReconciler := NewZyncReconciler(NewBaseAPIManagerLogicReconciler(NewBaseLogicReconciler(baseReconciler), apimanager))
+		_, err := zyncReconciler.Reconcile()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the PVC only exists when the zync database uses it
+		databaseDC := &appsv1.DeploymentConfig{}
+		err = cl.Get(context.TODO(), types.NamespacedName{Name: "zync-database", Namespace: namespace}, databaseDC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pvc := &v1.PersistentVolumeClaim{}
+		err = cl.Get(context.TODO(), types.NamespacedName{Name: component.ZyncDatabaseDataVolumeName, Namespace: namespace}, pvc)
+		if zyncDatabaseUsesEmptyDir(databaseDC) == (err == nil) {
+			t.Errorf("unexpected zync database PVC: %v", err)
+		}
+		return databaseDC
+	}
+
+	// the emptyDir volume of existing installs is kept
+	existingDC := reconcileZync(newAPIManager())
+	existingDC.ResourceVersion = ""
+	apimanager := newAPIManager()
+	apimanager.Spec.Zync.DatabasePersistentVolumeClaimSpec = &appsv1alpha1.PersistentVolumeClaimSpec{StorageRequest: &storageRequest}
+	databaseDC := reconcileZync(apimanager, existingDC)
+	if !zyncDatabaseUsesEmptyDir(databaseDC) {
+		t.Error("existing zync database does not keep its emptyDir volume")
+	}
+
+	// new installs use the PVC
+	databaseDC = reconcileZync(apimanager)
+	if zyncDatabaseUsesEmptyDir(databaseDC) {
+		t.Error("new zync database does not use the PVC")
+	}
+}
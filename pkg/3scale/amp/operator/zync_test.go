@@ -3,8 +3,11 @@ package operator
 import (
 	"testing"
 
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -71,3 +74,111 @@ func TestGetZyncOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestZyncDatabasePVC(t *testing.T) {
+	appLabel := "someLabel"
+	namespace := "someNS"
+	storageRequest := "5Gi"
+	var oneValue int64 = 1
+	falseValue := false
+
+	spec := &appsv1alpha1.APIManagerSpec{
+		APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+			AppLabel:                    &appLabel,
+			ResourceRequirementsEnabled: &falseValue,
+		},
+		Zync: &appsv1alpha1.ZyncSpec{
+			AppSpec: &appsv1alpha1.ZyncAppSpec{Replicas: &oneValue},
+			QueSpec: &appsv1alpha1.ZyncQueSpec{Replicas: &oneValue},
+		},
+	}
+
+	zyncFromSpec := func() *component.Zync {
+		optsProvider := OperatorZyncOptionsProvider{
+			APIManagerSpec: spec,
+			Namespace:      namespace,
+			Client:         fake.NewFakeClient(),
+		}
+		opts, err := optsProvider.GetZyncOptions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return component.NewZync(opts)
+	}
+
+	// emptyDir volume by default
+	zync := zyncFromSpec()
+	if zync.DatabasePVC() != nil {
+		t.Error("unexpected zync database PVC")
+	}
+	if zync.DatabaseDeploymentConfig().Spec.Template.Spec.Volumes[0].EmptyDir == nil {
+		t.Error("zync database does not use an emptyDir volume")
+	}
+
+	spec.Zync.DatabasePersistentVolumeClaimSpec = &appsv1alpha1.PersistentVolumeClaimSpec{StorageRequest: &storageRequest}
+	zync = zyncFromSpec()
+	pvc := zync.DatabasePVC()
+	if pvc == nil {
+		t.Fatal("zync database PVC not created")
+	}
+	if storageQuantity(pvc).String() != storageRequest {
+		t.Errorf("unexpected storage request: %s", storageQuantity(pvc).String())
+	}
+	if len(pvc.Spec.AccessModes) != 1 || pvc.Spec.AccessModes[0] != v1.ReadWriteOnce {
+		t.Errorf("unexpected access modes: %v", pvc.Spec.AccessModes)
+	}
+	volume := zync.DatabaseDeploymentConfig().Spec.Template.Spec.Volumes[0]
+	if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != pvc.Name {
+		t.Errorf("zync database does not use the PVC: %v", volume)
+	}
+}
+
+func TestZyncDatabaseResourcesAndPodPlacement(t *testing.T) {
+	appLabel := "someLabel"
+	namespace := "someNS"
+	var oneValue int64 = 1
+	falseValue := false
+
+	databaseResources := v1.ResourceRequirements{
+		Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	tolerations := []v1.Toleration{
+		{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "databases", Effect: v1.TaintEffectNoSchedule},
+	}
+	spec := &appsv1alpha1.APIManagerSpec{
+		APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+			AppLabel:                    &appLabel,
+			ResourceRequirementsEnabled: &falseValue,
+		},
+		Zync: &appsv1alpha1.ZyncSpec{
+			AppSpec:              &appsv1alpha1.ZyncAppSpec{Replicas: &oneValue},
+			QueSpec:              &appsv1alpha1.ZyncQueSpec{Replicas: &oneValue},
+			DatabaseResources:    &databaseResources,
+			DatabasePodPlacement: &appsv1alpha1.PodPlacementSpec{Tolerations: tolerations},
+		},
+	}
+
+	optsProvider := OperatorZyncOptionsProvider{
+		APIManagerSpec: spec,
+		Namespace:      namespace,
+		Client:         fake.NewFakeClient(),
+	}
+	opts, err := optsProvider.GetZyncOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	zync := component.NewZync(opts)
+
+	podSpec := zync.DatabaseDeploymentConfig().Spec.Template.Spec
+	if !helper.CmpResources(&podSpec.Containers[0].Resources, &databaseResources) {
+		t.Errorf("unexpected zync database resources: %v", podSpec.Containers[0].Resources)
+	}
+	if len(podSpec.Tolerations) != 1 || podSpec.Tolerations[0] != tolerations[0] {
+		t.Errorf("unexpected zync database tolerations: %v", podSpec.Tolerations)
+	}
+
+	// the overrides only apply to the zync database
+	if len(zync.DeploymentConfig().Spec.Template.Spec.Tolerations) != 0 {
+		t.Errorf("unexpected zync tolerations: %v", zync.DeploymentConfig().Spec.Template.Spec.Tolerations)
+	}
+}
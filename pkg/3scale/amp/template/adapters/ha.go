@@ -53,15 +53,19 @@ func (h *HAAdapter) options() (*component.HighAvailabilityOptions, error) {
 	hob.BackendRedisQueuesEndpoint("${BACKEND_REDIS_QUEUES_ENDPOINT}")
 	hob.BackendRedisQueuesSentinelHosts("${BACKEND_REDIS_QUEUE_SENTINEL_HOSTS}")
 	hob.BackendRedisQueuesSentinelRole("${BACKEND_REDIS_QUEUE_SENTINEL_ROLE}")
+	hob.BackendRedisQueuesSentinelPassword("${BACKEND_REDIS_QUEUE_SENTINEL_PASSWORD}")
 	hob.BackendRedisStorageEndpoint("${BACKEND_REDIS_STORAGE_ENDPOINT}")
 	hob.BackendRedisStorageSentinelHosts("${BACKEND_REDIS_STORAGE_SENTINEL_HOSTS}")
 	hob.BackendRedisStorageSentinelRole("${BACKEND_REDIS_STORAGE_SENTINEL_ROLE}")
+	hob.BackendRedisStorageSentinelPassword("${BACKEND_REDIS_STORAGE_SENTINEL_PASSWORD}")
 	hob.SystemDatabaseURL("${SYSTEM_DATABASE_URL}")
 	hob.SystemRedisURL("${SYSTEM_REDIS_URL}")
 	hob.SystemRedisSentinelsHosts("${SYSTEM_REDIS_SENTINEL_HOSTS}")
 	hob.SystemRedisSentinelsRole("${SYSTEM_REDIS_SENTINEL_ROLE}")
+	hob.SystemRedisSentinelsPassword("${SYSTEM_REDIS_SENTINEL_PASSWORD}")
 	hob.SystemMessageBusRedisSentinelsHosts("${SYSTEM_MESSAGE_BUS_REDIS_SENTINEL_HOSTS}")
 	hob.SystemMessageBusRedisSentinelsRole("${SYSTEM_MESSAGE_BUS_REDIS_SENTINEL_ROLE}")
+	hob.SystemMessageBusRedisSentinelsPassword("${SYSTEM_MESSAGE_BUS_REDIS_SENTINEL_PASSWORD}")
 	hob.SystemMessageBusRedisURL("${SYSTEM_MESSAGE_BUS_REDIS_URL}")
 
 	return hob.Build()
@@ -92,6 +96,10 @@ func (h *HAAdapter) parameters() []templatev1.Parameter {
 			Name:        "SYSTEM_MESSAGE_BUS_REDIS_SENTINEL_ROLE",
 			Description: "Define the external system message bus sentinel role",
 		},
+		templatev1.Parameter{
+			Name:        "SYSTEM_MESSAGE_BUS_REDIS_SENTINEL_PASSWORD",
+			Description: "Define the external system message bus sentinel password",
+		},
 		templatev1.Parameter{
 			Name:        "SYSTEM_REDIS_SENTINEL_HOSTS",
 			Description: "Define the external system redis sentinel hosts",
@@ -100,6 +108,10 @@ func (h *HAAdapter) parameters() []templatev1.Parameter {
 			Name:        "SYSTEM_REDIS_SENTINEL_ROLE",
 			Description: "Define the external system redis sentinel role",
 		},
+		templatev1.Parameter{
+			Name:        "SYSTEM_REDIS_SENTINEL_PASSWORD",
+			Description: "Define the external system redis sentinel password",
+		},
 		templatev1.Parameter{
 			Name:        "BACKEND_REDIS_QUEUE_SENTINEL_HOSTS",
 			Description: "Define the external backend redis queue sentinel hosts",
@@ -108,6 +120,10 @@ func (h *HAAdapter) parameters() []templatev1.Parameter {
 			Name:        "BACKEND_REDIS_QUEUE_SENTINEL_ROLE",
 			Description: "Define the external backend redis queue sentinel role",
 		},
+		templatev1.Parameter{
+			Name:        "BACKEND_REDIS_QUEUE_SENTINEL_PASSWORD",
+			Description: "Define the external backend redis queue sentinel password",
+		},
 		templatev1.Parameter{
 			Name:        "BACKEND_REDIS_STORAGE_SENTINEL_HOSTS",
 			Description: "Define the external backend redis storage sentinel hosts",
@@ -116,5 +132,9 @@ func (h *HAAdapter) parameters() []templatev1.Parameter {
 			Name:        "BACKEND_REDIS_STORAGE_SENTINEL_ROLE",
 			Description: "Define the external backend redis storage sentinel role",
 		},
+		templatev1.Parameter{
+			Name:        "BACKEND_REDIS_STORAGE_SENTINEL_PASSWORD",
+			Description: "Define the external backend redis storage sentinel password",
+		},
 	}
 }
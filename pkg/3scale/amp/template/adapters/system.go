@@ -166,6 +166,6 @@ func (s *System) options() (*component.SystemOptions, error) {
 	sob.BackendSharedSecret("${SYSTEM_BACKEND_SHARED_SECRET}")
 	sob.TenantName("${TENANT_NAME}")
 	sob.WildcardDomain("${WILDCARD_DOMAIN}")
-	sob.PVCFileStorageOptions(component.PVCFileStorageOptions{StorageClass: nil})
+	sob.PVCFileStorageOptions(component.PVCOptions{})
 	return sob.Build()
 }
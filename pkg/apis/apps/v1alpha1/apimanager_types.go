@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 	"github.com/3scale/3scale-operator/version"
@@ -9,6 +10,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -25,6 +27,16 @@ const (
 	defaultTenantName                  = "3scale"
 	defaultImageStreamImportInsecure   = false
 	defaultResourceRequirementsEnabled = true
+	defaultPlatform                    = PlatformOpenShift
+)
+
+type PlatformType string
+
+const (
+	// PlatformOpenShift deploys DeploymentConfigs and ImageStreams
+	PlatformOpenShift PlatformType = "OpenShift"
+	// PlatformKubernetes deploys plain Deployments with direct image references
+	PlatformKubernetes PlatformType = "Kubernetes"
 )
 
 const (
@@ -50,11 +62,21 @@ type APIManagerSpec struct {
 	HighAvailability *HighAvailabilitySpec `json:"highAvailability,omitempty"`
 	// +optional
 	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// +optional
+	Exposure *ExposureSpec `json:"exposure,omitempty"`
+	// +optional
+	CredentialRotation *CredentialRotationSpec `json:"credentialRotation,omitempty"`
+	// +optional
+	SecretSources []SecretSourceSpec `json:"secretSources,omitempty"`
 }
 
 // APIManagerStatus defines the observed state of APIManager
 // +k8s:openapi-gen=true
 type APIManagerStatus struct {
+	// Current state of the APIManager
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.displayName="Conditions"
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes.conditions"
 	Conditions []APIManagerCondition `json:"conditions,omitempty" protobuf:"bytes,4,rep,name=conditions"`
 
 	// APIManager Deployment Configs
@@ -62,6 +84,212 @@ type APIManagerStatus struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.displayName="Deployments"
 	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses"
 	Deployments olm.DeploymentStatus `json:"deployments"`
+
+	// Progress of the last upgrade of the APIManager
+	// +optional
+	Upgrade *APIManagerUpgradeStatus `json:"upgrade,omitempty"`
+
+	// Progress of the credential rotations of the APIManager
+	// +optional
+	CredentialRotation *CredentialRotationStatus `json:"credentialRotation,omitempty"`
+
+	// Platform the 3scale components are deployed in. Changes of the spec platform are not applied
+	// +optional
+	Platform PlatformType `json:"platform,omitempty"`
+}
+
+type APIManagerUpgradePhase string
+
+const (
+	APIManagerUpgradePhaseInProgress APIManagerUpgradePhase = "InProgress"
+	APIManagerUpgradePhaseFailed     APIManagerUpgradePhase = "Failed"
+	APIManagerUpgradePhaseCompleted  APIManagerUpgradePhase = "Completed"
+)
+
+// APIManagerUpgradeStatus records the progress of an upgrade. The versions
+// the upgrade started from are kept once the upgrade is completed as a
+// record of the previous installation
+type APIManagerUpgradeStatus struct {
+	FromOperatorVersion   string `json:"fromOperatorVersion"`
+	FromThreescaleVersion string `json:"fromThreescaleVersion"`
+	ToOperatorVersion     string `json:"toOperatorVersion"`
+	ToThreescaleVersion   string `json:"toThreescaleVersion"`
+
+	Phase APIManagerUpgradePhase `json:"phase"`
+	// Steps already run. They are skipped when a failed upgrade is resumed
+	// +optional
+	CompletedSteps []string `json:"completedSteps,omitempty"`
+	// +optional
+	FailedStep string `json:"failedStep,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsStepCompleted returns true when the given upgrade step has already been run
+func (u *APIManagerUpgradeStatus) IsStepCompleted(step string) bool {
+	for _, completedStep := range u.CompletedSteps {
+		if completedStep == step {
+			return true
+		}
+	}
+	return false
+}
+
+// RotatedCredential is a credential generated by the operator that can be rotated
+type RotatedCredential string
+
+const (
+	// Password of the backend-internal-api secret, used by system to call backend
+	RotatedCredentialBackendInternalAPI RotatedCredential = "BackendInternalAPI"
+	// ZYNC_AUTHENTICATION_TOKEN of the zync secret, used by system to call zync
+	RotatedCredentialZyncAuthenticationToken RotatedCredential = "ZyncAuthenticationToken"
+	// SECRET_KEY_BASE of the system-app secret
+	RotatedCredentialSystemAppSecretKeyBase RotatedCredential = "SystemAppSecretKeyBase"
+	// MASTER_ACCESS_TOKEN of the system-seed secret
+	RotatedCredentialMasterAccessToken RotatedCredential = "MasterAccessToken"
+	// ADMIN_ACCESS_TOKEN of the system-seed secret
+	RotatedCredentialAdminAccessToken RotatedCredential = "AdminAccessToken"
+)
+
+// CredentialRotationSpec requests the regeneration of the credentials generated by
+// the operator. Increasing a field rotates its credential. The credentials are
+// rotated one at a time, rolling out the components using them in order
+type CredentialRotationSpec struct {
+	// Increase to regenerate the password of the backend-internal-api secret
+	// +optional
+	BackendInternalAPI int64 `json:"backendInternalAPI,omitempty"`
+	// Increase to regenerate the ZYNC_AUTHENTICATION_TOKEN of the zync secret
+	// +optional
+	ZyncAuthenticationToken int64 `json:"zyncAuthenticationToken,omitempty"`
+	// Increase to regenerate the SECRET_KEY_BASE of the system-app secret.
+	// The sessions of the portal users are lost
+	// +optional
+	SystemAppSecretKeyBase int64 `json:"systemAppSecretKeyBase,omitempty"`
+	// Increase to create a new master access token in 3scale and write it into
+	// the system-seed secret. The previous token is deleted from 3scale
+	// +optional
+	MasterAccessToken int64 `json:"masterAccessToken,omitempty"`
+	// Increase to create a new admin access token in 3scale and write it into
+	// the system-seed secret. The previous token is deleted from 3scale
+	// +optional
+	AdminAccessToken int64 `json:"adminAccessToken,omitempty"`
+}
+
+// Rotation returns the requested rotation of the credential
+func (s *CredentialRotationSpec) Rotation(credential RotatedCredential) int64 {
+	if s == nil {
+		return 0
+	}
+	switch credential {
+	case RotatedCredentialBackendInternalAPI:
+		return s.BackendInternalAPI
+	case RotatedCredentialZyncAuthenticationToken:
+		return s.ZyncAuthenticationToken
+	case RotatedCredentialSystemAppSecretKeyBase:
+		return s.SystemAppSecretKeyBase
+	case RotatedCredentialMasterAccessToken:
+		return s.MasterAccessToken
+	case RotatedCredentialAdminAccessToken:
+		return s.AdminAccessToken
+	}
+	return 0
+}
+
+// SetRotation sets the rotation of the credential
+func (s *CredentialRotationSpec) SetRotation(credential RotatedCredential, rotation int64) {
+	switch credential {
+	case RotatedCredentialBackendInternalAPI:
+		s.BackendInternalAPI = rotation
+	case RotatedCredentialZyncAuthenticationToken:
+		s.ZyncAuthenticationToken = rotation
+	case RotatedCredentialSystemAppSecretKeyBase:
+		s.SystemAppSecretKeyBase = rotation
+	case RotatedCredentialMasterAccessToken:
+		s.MasterAccessToken = rotation
+	case RotatedCredentialAdminAccessToken:
+		s.AdminAccessToken = rotation
+	}
+}
+
+type CredentialRotationPhase string
+
+const (
+	// The new credential is being accepted by the components validating it
+	CredentialRotationPhaseAccepting CredentialRotationPhase = "Accepting"
+	// The components using the credential are being rolled out with the new one
+	CredentialRotationPhaseDistributing CredentialRotationPhase = "Distributing"
+	// The previous credential is being dropped
+	CredentialRotationPhaseRevoking CredentialRotationPhase = "Revoking"
+)
+
+// CredentialRotationStatus records the rotations applied to each credential and
+// the progress of the one being rotated
+type CredentialRotationStatus struct {
+	// Last rotations of the spec applied to the credentials
+	// +optional
+	Applied CredentialRotationSpec `json:"applied,omitempty"`
+	// Credential being rotated
+	// +optional
+	Credential RotatedCredential `json:"credential,omitempty"`
+	// Phase of the credential being rotated
+	// +optional
+	Phase CredentialRotationPhase `json:"phase,omitempty"`
+	// Time the last credential rotation was completed
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+}
+
+// SecretSourceSpec reads the content of one of the secrets of the APIManager from an
+// external source instead of the secret with the fixed name. The operator does not
+// create the secret, the source has to provide all of its fields
+type SecretSourceSpec struct {
+	// Name of the APIManager secret, e.g. system-seed
+	Secret string `json:"secret"`
+	// Name of the secret used instead. Defaults to the name of the APIManager secret
+	// +optional
+	SecretName *string `json:"secretName,omitempty"`
+	// Keys of the secret holding the fields of the APIManager secret, indexed by the
+	// field name. Fields not included are read from the key with the same name
+	// +optional
+	Keys map[string]string `json:"keys,omitempty"`
+	// Secrets Store CSI volume mounted in the pods using the secret. The volume
+	// syncs the secret from the external store when the pods are started
+	// +optional
+	CSI *v1.CSIVolumeSource `json:"csi,omitempty"`
+}
+
+// GetSecretName returns the name of the secret providing the content
+func (s *SecretSourceSpec) GetSecretName() string {
+	if s.SecretName != nil && *s.SecretName != "" {
+		return *s.SecretName
+	}
+	return s.Secret
+}
+
+// GetKey returns the key of the secret providing the field
+func (s *SecretSourceSpec) GetKey(field string) string {
+	if key, ok := s.Keys[field]; ok && key != "" {
+		return key
+	}
+	return field
+}
+
+// SecretSourcedSecrets are the APIManager secrets whose content can be read
+// from a secret source
+var SecretSourcedSecrets = []string{
+	"backend-internal-api",
+	"backend-listener",
+	"backend-redis",
+	"system-app",
+	"system-database",
+	"system-events-hook",
+	"system-master-apicast",
+	"system-memcache",
+	"system-recaptcha",
+	"system-redis",
+	"system-seed",
+	"system-smtp",
+	"zync",
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -87,31 +315,46 @@ type APIManager struct {
 type APIManagerConditionType string
 
 const (
-	// Ready means the APIManager is available. This is, when all of its
-	// elements are up and running
-	APIManagerReady APIManagerConditionType = "Ready"
-	// Progressing means the APIManager is being deployed
+	// APIManagerAvailable means all the APIManager components are up and
+	// running and ready to serve requests
+	APIManagerAvailable APIManagerConditionType = "Available"
+	// APIManagerProgressing means some of the APIManager components are
+	// still being deployed or rolled out
 	APIManagerProgressing APIManagerConditionType = "Progressing"
+	// APIManagerDegraded means the last reconciliation of the APIManager
+	// failed. The reason and message of the condition contain the details
+	APIManagerDegraded APIManagerConditionType = "Degraded"
+	// APIManagerUpgradeInProgress means the APIManager is being upgraded
+	// to the version managed by the running operator
+	APIManagerUpgradeInProgress APIManagerConditionType = "UpgradeInProgress"
+	// APIManagerExternalDatabasesValid means the secrets required to use
+	// external databases exist and have valid content. Only set when
+	// HighAvailability is enabled
+	APIManagerExternalDatabasesValid APIManagerConditionType = "ExternalDatabasesValid"
+	// APIManagerRolloutPending means some DeploymentConfigs have not rolled
+	// out their latest pod template yet, like after a change of the secrets
+	// and configmaps they consume
+	APIManagerRolloutPending APIManagerConditionType = "RolloutPending"
+	// APIManagerSystemReady means all the System DeploymentConfigs are ready
+	APIManagerSystemReady APIManagerConditionType = "SystemReady"
+	// APIManagerBackendReady means all the Backend DeploymentConfigs are ready
+	APIManagerBackendReady APIManagerConditionType = "BackendReady"
+	// APIManagerZyncReady means all the Zync DeploymentConfigs are ready
+	APIManagerZyncReady APIManagerConditionType = "ZyncReady"
+	// APIManagerApicastReady means all the APIcast DeploymentConfigs are ready
+	APIManagerApicastReady APIManagerConditionType = "ApicastReady"
 )
 
 type APIManagerCondition struct {
 	Type   APIManagerConditionType `json:"type" description:"type of APIManager condition"`
-	Status v1.ConditionStatus      `json:"status" description:"status of the condition, one of True, False, Unknown"` //TODO should be a custom ConditionStatus or the core v1 one?
+	Status v1.ConditionStatus      `json:"status" description:"status of the condition, one of True, False, Unknown"`
 
-	// The Reason, Message, LastHeartbeatTime and LastTransitionTime fields are
-	// optional. Unless we really use them they should directly not be used even
-	// if they are optional
-
-	// +optional
-	//Reason *string `json:"reason,omitempty" description:"one-word CamelCase reason for the condition's last transition"`
 	// +optional
-	//Message *string `json:"message,omitempty" description:"human-readable message indicating details about last transition"`
-
+	Reason string `json:"reason,omitempty" description:"one-word CamelCase reason for the condition's last transition"`
 	// +optional
-	//LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime,omitempty" description:"last time we got an update on a given condition"` // TODO the Kubernetes API convention guide says *unversioned.Time should be used but that seems to be a client-side package. I've seen that objects like PersistentVolumeClaim use metav1.Time
+	Message string `json:"message,omitempty" description:"human-readable message indicating details about last transition"`
 	// +optional
-	//metav1.Time        `json:"lastProbeTime,omitempty" protobuf:"bytes,3,opt,name=lastProbeTime"`
-	//LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" description:"last time the condition transit from one status to another"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" description:"last time the condition transit from one status to another"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -137,6 +380,9 @@ type APIManagerCommonSpec struct {
 	ImageStreamTagImportInsecure *bool `json:"imageStreamTagImportInsecure,omitempty"`
 	// +optional
 	ResourceRequirementsEnabled *bool `json:"resourceRequirementsEnabled,omitempty"`
+	// Platform where 3scale is deployed. It cannot be changed once set
+	// +optional
+	Platform *PlatformType `json:"platform,omitempty"`
 }
 
 type ApicastSpec struct {
@@ -159,11 +405,47 @@ type ApicastSpec struct {
 type ApicastProductionSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
 }
 
 type ApicastStagingSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
+}
+
+// AutoscalingSpec configures a HorizontalPodAutoscaler for a DeploymentConfig.
+// When enabled, the replicas of the DeploymentConfig are managed by the
+// HorizontalPodAutoscaler instead of the Replicas field
+type AutoscalingSpec struct {
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	MaxReplicas int32  `json:"maxReplicas"`
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// PodPlacementSpec contains the pod scheduling settings that can be
+// customized for each one of the APIManager DeploymentConfigs
+type PodPlacementSpec struct {
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 type BackendSpec struct {
@@ -177,21 +459,39 @@ type BackendSpec struct {
 	WorkerSpec *BackendWorkerSpec `json:"workerSpec,omitempty"`
 	// +optional
 	CronSpec *BackendCronSpec `json:"cronSpec,omitempty"`
+	// PersistentVolumeClaim of the backend-redis-storage volume
+	// +optional
+	RedisPersistentVolumeClaimSpec *PersistentVolumeClaimSpec `json:"redisPersistentVolumeClaim,omitempty"`
+	// +optional
+	RedisResources *v1.ResourceRequirements `json:"redisResources,omitempty"`
+	// +optional
+	RedisPodPlacement *PodPlacementSpec `json:"redisPodPlacement,omitempty"`
 }
 
 type BackendListenerSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
 }
 
 type BackendWorkerSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
 }
 
 type BackendCronSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
 }
 
 type SystemSpec struct {
@@ -204,6 +504,25 @@ type SystemSpec struct {
 	// +optional
 	RedisImage *string `json:"redisImage,omitempty"`
 
+	// PersistentVolumeClaim of the system-redis-storage volume
+	// +optional
+	RedisPersistentVolumeClaimSpec *PersistentVolumeClaimSpec `json:"redisPersistentVolumeClaim,omitempty"`
+
+	// +optional
+	RedisResources *v1.ResourceRequirements `json:"redisResources,omitempty"`
+	// +optional
+	RedisPodPlacement *PodPlacementSpec `json:"redisPodPlacement,omitempty"`
+
+	// +optional
+	MemcachedResources *v1.ResourceRequirements `json:"memcachedResources,omitempty"`
+	// +optional
+	MemcachedPodPlacement *PodPlacementSpec `json:"memcachedPodPlacement,omitempty"`
+
+	// +optional
+	SphinxResources *v1.ResourceRequirements `json:"sphinxResources,omitempty"`
+	// +optional
+	SphinxPodPlacement *PodPlacementSpec `json:"sphinxPodPlacement,omitempty"`
+
 	// TODO should this field be optional? We have different approaches in Kubernetes.
 	// For example, in v1.Volume it is optional and there's an implied behaviour
 	// on which one is the default VolumeSource of the ones available. However,
@@ -227,11 +546,21 @@ type SystemSpec struct {
 type SystemAppSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	MasterContainerResources *v1.ResourceRequirements `json:"masterContainerResources,omitempty"`
+	// +optional
+	ProviderContainerResources *v1.ResourceRequirements `json:"providerContainerResources,omitempty"`
+	// +optional
+	DeveloperContainerResources *v1.ResourceRequirements `json:"developerContainerResources,omitempty"`
+	PodPlacementSpec            `json:",inline"`
 }
 
 type SystemSidekiqSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
 }
 
 type SystemFileStorageSpec struct {
@@ -248,6 +577,27 @@ type SystemFileStorageSpec struct {
 type SystemPVCSpec struct {
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Storage requested by the system-storage PersistentVolumeClaim, as a resource
+	// quantity. Increasing it expands the claim when its storage class allows
+	// volume expansion
+	// +optional
+	StorageRequest *string `json:"storageRequest,omitempty"`
+	// +optional
+	AccessModes []v1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// Selector of the PersistentVolumes the claim can be bound to
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// PersistentVolumeClaimSpec returns the configuration of the system-storage
+// PersistentVolumeClaim
+func (s *SystemPVCSpec) PersistentVolumeClaimSpec() *PersistentVolumeClaimSpec {
+	return &PersistentVolumeClaimSpec{
+		StorageClassName: s.StorageClassName,
+		StorageRequest:   s.StorageRequest,
+		AccessModes:      s.AccessModes,
+		Selector:         s.Selector,
+	}
 }
 
 type DeprecatedSystemS3Spec struct {
@@ -274,11 +624,39 @@ type SystemDatabaseSpec struct {
 type SystemMySQLSpec struct {
 	// +optional
 	Image *string `json:"image,omitempty"`
+	// PersistentVolumeClaim of the mysql-storage volume
+	// +optional
+	PersistentVolumeClaimSpec *PersistentVolumeClaimSpec `json:"persistentVolumeClaim,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
 }
 
 type SystemPostgreSQLSpec struct {
 	// +optional
 	Image *string `json:"image,omitempty"`
+	// PersistentVolumeClaim of the postgresql-data volume
+	// +optional
+	PersistentVolumeClaimSpec *PersistentVolumeClaimSpec `json:"persistentVolumeClaim,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
+}
+
+// PersistentVolumeClaimSpec configures the PersistentVolumeClaim of a stateful
+// component. Only the storage request can be changed once the claim is created
+type PersistentVolumeClaimSpec struct {
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Storage requested by the PersistentVolumeClaim, as a resource quantity.
+	// Increasing it expands the claim when its storage class allows volume expansion
+	// +optional
+	StorageRequest *string `json:"storageRequest,omitempty"`
+	// +optional
+	AccessModes []v1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// Selector of the PersistentVolumes the claim can be bound to
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
 type ZyncSpec struct {
@@ -286,6 +664,14 @@ type ZyncSpec struct {
 	Image *string `json:"image,omitempty"`
 	// +optional
 	PostgreSQLImage *string `json:"postgreSQLImage,omitempty"`
+	// PersistentVolumeClaim of the zync-database-data volume. The zync
+	// database uses an emptyDir volume when it is not set
+	// +optional
+	DatabasePersistentVolumeClaimSpec *PersistentVolumeClaimSpec `json:"databasePersistentVolumeClaim,omitempty"`
+	// +optional
+	DatabaseResources *v1.ResourceRequirements `json:"databaseResources,omitempty"`
+	// +optional
+	DatabasePodPlacement *PodPlacementSpec `json:"databasePodPlacement,omitempty"`
 
 	// +optional
 	AppSpec *ZyncAppSpec `json:"appSpec,omitempty"`
@@ -297,11 +683,17 @@ type ZyncSpec struct {
 type ZyncAppSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
 }
 
 type ZyncQueSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Resources        *v1.ResourceRequirements `json:"resources,omitempty"`
+	PodPlacementSpec `json:",inline"`
 }
 
 type HighAvailabilitySpec struct {
@@ -312,6 +704,74 @@ type PodDisruptionBudgetSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 }
 
+type ExposureType string
+
+const (
+	ExposureTypeRoute   ExposureType = "Route"
+	ExposureTypeIngress ExposureType = "Ingress"
+)
+
+type TLSTerminationType string
+
+const (
+	TLSTerminationEdge        TLSTerminationType = "edge"
+	TLSTerminationReencrypt   TLSTerminationType = "reencrypt"
+	TLSTerminationPassthrough TLSTerminationType = "passthrough"
+)
+
+// ExposureSpec configures how the external endpoints of 3scale are exposed.
+// When set, the operator manages the OpenShift Routes or the Kubernetes
+// Ingresses of the master, provider, developer, apicast and backend endpoints
+type ExposureSpec struct {
+	// Type of the resources used to expose the endpoints. Route by default
+	// +optional
+	Type ExposureType `json:"type,omitempty"`
+	// Ingress class set in the kubernetes.io/ingress.class annotation of the Ingresses
+	// +optional
+	IngressClass *string `json:"ingressClass,omitempty"`
+	// +optional
+	Master *EndpointExposureSpec `json:"master,omitempty"`
+	// +optional
+	Provider *EndpointExposureSpec `json:"provider,omitempty"`
+	// +optional
+	Developer *EndpointExposureSpec `json:"developer,omitempty"`
+	// +optional
+	ApicastStaging *EndpointExposureSpec `json:"apicastStaging,omitempty"`
+	// +optional
+	ApicastProduction *EndpointExposureSpec `json:"apicastProduction,omitempty"`
+	// +optional
+	Backend *EndpointExposureSpec `json:"backend,omitempty"`
+}
+
+type EndpointExposureSpec struct {
+	// Hostname of the endpoint. Defaults to a hostname under the wildcardDomain
+	// +optional
+	Host *string `json:"host,omitempty"`
+	// Other hostnames served by the endpoint, like the hosts of the API
+	// products served by apicast. A hostname starting with "*." serves all
+	// the subdomains of the domain
+	// +optional
+	AdditionalHosts []string `json:"additionalHosts,omitempty"`
+	// +optional
+	TLS *EndpointTLSSpec `json:"tls,omitempty"`
+}
+
+type EndpointTLSSpec struct {
+	// TLS termination type. edge by default
+	// +optional
+	Termination TLSTerminationType `json:"termination,omitempty"`
+	// Secret with the certificate (tls.crt), key (tls.key) and optionally
+	// the CA certificate (ca.crt) served by the endpoint. The default
+	// certificate of the router is used when not set. Ingresses get no TLS
+	// configuration when not set
+	// +optional
+	CertificateSecretRef *v1.LocalObjectReference `json:"certificateSecretRef,omitempty"`
+	// Secret with the CA certificate (ca.crt) used to validate the
+	// certificate of the service when the termination is reencrypt
+	// +optional
+	DestinationCACertificateSecretRef *v1.LocalObjectReference `json:"destinationCACertificateSecretRef,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&APIManager{}, &APIManagerList{})
 }
@@ -342,6 +802,8 @@ func (apimanager *APIManager) SetDefaults() (bool, error) {
 	tmpChanged = apimanager.setZyncDefaults()
 	changed = changed || tmpChanged
 
+	err = apimanager.validateAutoscalingSpecs()
+
 	return changed, err
 }
 
@@ -474,6 +936,7 @@ func (apimanager *APIManager) setAPIManagerCommonSpecDefaults() bool {
 	tmpDefaultTenantName := defaultTenantName
 	tmpDefaultImageStreamTagImportInsecure := defaultImageStreamImportInsecure
 	tmpDefaultResourceRequirementsEnabled := defaultResourceRequirementsEnabled
+	tmpDefaultPlatform := defaultPlatform
 
 	if spec.AppLabel == nil {
 		spec.AppLabel = &tmpDefaultAppLabel
@@ -495,6 +958,11 @@ func (apimanager *APIManager) setAPIManagerCommonSpecDefaults() bool {
 		changed = true
 	}
 
+	if spec.Platform == nil {
+		spec.Platform = &tmpDefaultPlatform
+		changed = true
+	}
+
 	// TODO do something with mandatory parameters?
 	// TODO check that only compatible ProductRelease versions are compatible?
 
@@ -617,3 +1085,191 @@ func (apimanager *APIManager) IsExternalDatabaseEnabled() bool {
 func (apimanager *APIManager) IsPDBEnabled() bool {
 	return apimanager.Spec.PodDisruptionBudget != nil && apimanager.Spec.PodDisruptionBudget.Enabled
 }
+
+func (apimanager *APIManager) IsApicastProductionAutoscalingEnabled() bool {
+	return apimanager.Spec.Apicast != nil &&
+		apimanager.Spec.Apicast.ProductionSpec != nil &&
+		apimanager.Spec.Apicast.ProductionSpec.Autoscaling.IsEnabled()
+}
+
+func (apimanager *APIManager) IsBackendListenerAutoscalingEnabled() bool {
+	return apimanager.Spec.Backend != nil &&
+		apimanager.Spec.Backend.ListenerSpec != nil &&
+		apimanager.Spec.Backend.ListenerSpec.Autoscaling.IsEnabled()
+}
+
+// IsKubernetesPlatform returns true when the 3scale components are deployed
+// as plain Deployments instead of DeploymentConfigs and ImageStreams. The
+// platform recorded in the status takes precedence over the spec one
+func (apimanager *APIManager) IsKubernetesPlatform() bool {
+	if apimanager.Status.Platform != "" {
+		return apimanager.Status.Platform == PlatformKubernetes
+	}
+	return apimanager.specPlatform() == PlatformKubernetes
+}
+
+// PlatformChanged returns true when the spec platform differs from the
+// platform the 3scale components were deployed in
+func (apimanager *APIManager) PlatformChanged() bool {
+	return apimanager.Status.Platform != "" && apimanager.Status.Platform != apimanager.specPlatform()
+}
+
+func (apimanager *APIManager) specPlatform() PlatformType {
+	if apimanager.Spec.Platform == nil {
+		return defaultPlatform
+	}
+	return *apimanager.Spec.Platform
+}
+
+// IsExposureManaged returns true when the operator manages the Routes or
+// Ingresses of all the external endpoints
+func (apimanager *APIManager) IsExposureManaged() bool {
+	return apimanager.Spec.Exposure != nil
+}
+
+// ExposureType returns the type of the resources used to expose the
+// external endpoints
+func (apimanager *APIManager) ExposureType() ExposureType {
+	if apimanager.Spec.Exposure == nil || apimanager.Spec.Exposure.Type == "" {
+		return ExposureTypeRoute
+	}
+	return apimanager.Spec.Exposure.Type
+}
+
+// CoversTenantHosts returns true when the provider, developer and apicast
+// endpoints serve a wildcard host, so the hosts of the other tenants and of
+// the API products don't need a Route of their own
+func (s *ExposureSpec) CoversTenantHosts() bool {
+	for _, endpoint := range []*EndpointExposureSpec{s.Provider, s.Developer, s.ApicastStaging, s.ApicastProduction} {
+		if endpoint == nil || !endpoint.hasWildcardHost() {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *EndpointExposureSpec) hasWildcardHost() bool {
+	for _, host := range e.AdditionalHosts {
+		if strings.HasPrefix(host, "*.") {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretSource returns the source of the APIManager secret, nil when the secret
+// is read from the secret with the fixed name
+func (s *APIManagerSpec) SecretSource(secretName string) *SecretSourceSpec {
+	if s == nil {
+		return nil
+	}
+	for idx := range s.SecretSources {
+		if s.SecretSources[idx].Secret == secretName {
+			return &s.SecretSources[idx]
+		}
+	}
+	return nil
+}
+
+// IsEnabled returns true when the autoscaling spec is present and enabled
+func (a *AutoscalingSpec) IsEnabled() bool {
+	return a != nil && a.Enabled
+}
+
+func (apimanager *APIManager) validateAutoscalingSpecs() error {
+	return apimanager.validateAutoscalingSpecFields().ToAggregate()
+}
+
+func (apimanager *APIManager) validateAutoscalingSpecFields() field.ErrorList {
+	errs := field.ErrorList{}
+
+	if apimanager.IsApicastProductionAutoscalingEnabled() {
+		fldPath := field.NewPath("spec", "apicast", "productionSpec", "autoscaling")
+		errs = append(errs, apimanager.Spec.Apicast.ProductionSpec.Autoscaling.validate(fldPath)...)
+	}
+
+	if apimanager.IsBackendListenerAutoscalingEnabled() {
+		fldPath := field.NewPath("spec", "backend", "listenerSpec", "autoscaling")
+		errs = append(errs, apimanager.Spec.Backend.ListenerSpec.Autoscaling.validate(fldPath)...)
+	}
+
+	return errs
+}
+
+func (a *AutoscalingSpec) validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if a.MaxReplicas < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("maxReplicas"), a.MaxReplicas, "must be greater than 0"))
+	}
+
+	if a.MinReplicas != nil && (*a.MinReplicas < 1 || *a.MinReplicas > a.MaxReplicas) {
+		errs = append(errs, field.Invalid(fldPath.Child("minReplicas"), *a.MinReplicas, "must be between 1 and maxReplicas"))
+	}
+
+	return errs
+}
+
+// GetCondition returns the condition of the given type or nil if it does not exist
+func (s *APIManagerStatus) GetCondition(conditionType APIManagerConditionType) *APIManagerCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns true when the condition of the given type exists and its status is True
+func (s *APIManagerStatus) IsConditionTrue(conditionType APIManagerConditionType) bool {
+	condition := s.GetCondition(conditionType)
+	return condition != nil && condition.Status == v1.ConditionTrue
+}
+
+// SetCondition adds or updates the condition with the same type. The
+// LastTransitionTime is only updated when the status of the condition changes.
+// Returns true if the status was changed
+func (s *APIManagerStatus) SetCondition(newCondition APIManagerCondition) bool {
+	existing := s.GetCondition(newCondition.Type)
+	if existing == nil {
+		if newCondition.LastTransitionTime.IsZero() {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+		s.Conditions = append(s.Conditions, newCondition)
+		return true
+	}
+
+	changed := false
+	if existing.Status != newCondition.Status {
+		existing.Status = newCondition.Status
+		existing.LastTransitionTime = newCondition.LastTransitionTime
+		if existing.LastTransitionTime.IsZero() {
+			existing.LastTransitionTime = metav1.Now()
+		}
+		changed = true
+	}
+
+	if existing.Reason != newCondition.Reason {
+		existing.Reason = newCondition.Reason
+		changed = true
+	}
+
+	if existing.Message != newCondition.Message {
+		existing.Message = newCondition.Message
+		changed = true
+	}
+
+	return changed
+}
+
+// RemoveCondition removes the condition of the given type. Returns true if
+// the status was changed
+func (s *APIManagerStatus) RemoveCondition(conditionType APIManagerConditionType) bool {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			s.Conditions = append(s.Conditions[:i], s.Conditions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -3,11 +3,13 @@ package v1alpha1
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 	"github.com/3scale/3scale-operator/version"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -16,6 +18,7 @@ func TestSetDefaults(t *testing.T) {
 	tmpDefaultTenantName := defaultTenantName
 	tmpDefaultImageStreamTagImportInsecure := defaultImageStreamImportInsecure
 	tmpDefaultResourceRequirementsEnabled := defaultResourceRequirementsEnabled
+	tmpDefaultPlatform := defaultPlatform
 	tmpDefaultApicastManagementAPI := defaultApicastManagementAPI
 	tmpDefaultApicastOpenSSLVerify := defaultApicastOpenSSLVerify
 	tmpDefaultApicastResponseCodes := defaultApicastResponseCodes
@@ -44,6 +47,7 @@ func TestSetDefaults(t *testing.T) {
 				TenantName:                   &tmpDefaultTenantName,
 				ImageStreamTagImportInsecure: &tmpDefaultImageStreamTagImportInsecure,
 				ResourceRequirementsEnabled:  &tmpDefaultResourceRequirementsEnabled,
+				Platform:                     &tmpDefaultPlatform,
 			},
 			Apicast: &ApicastSpec{
 				IncludeResponseCodes: &tmpDefaultApicastResponseCodes,
@@ -106,3 +110,130 @@ func testBasicAPIManagerDefaults(input, expected *APIManager) func(t *testing.T)
 		}
 	}
 }
+
+func TestSetDefaultsAutoscalingValidation(t *testing.T) {
+	var zero int32 = 0
+	var two int32 = 2
+	var five int32 = 5
+
+	cases := []struct {
+		name        string
+		autoscaling *AutoscalingSpec
+		expectError bool
+	}{
+		{"NotSet", nil, false},
+		{"Disabled", &AutoscalingSpec{Enabled: false}, false},
+		{"Valid", &AutoscalingSpec{Enabled: true, MinReplicas: &two, MaxReplicas: 4}, false},
+		{"ValidWithoutMinReplicas", &AutoscalingSpec{Enabled: true, MaxReplicas: 4}, false},
+		{"MissingMaxReplicas", &AutoscalingSpec{Enabled: true}, true},
+		{"MinReplicasZero", &AutoscalingSpec{Enabled: true, MinReplicas: &zero, MaxReplicas: 4}, true},
+		{"MinReplicasGreaterThanMax", &AutoscalingSpec{Enabled: true, MinReplicas: &five, MaxReplicas: 4}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			apimanager := &APIManager{
+				Spec: APIManagerSpec{
+					APIManagerCommonSpec: APIManagerCommonSpec{
+						WildcardDomain: "test.3scale.com",
+					},
+					Backend: &BackendSpec{
+						ListenerSpec: &BackendListenerSpec{Autoscaling: tc.autoscaling},
+					},
+				},
+			}
+			_, err := apimanager.SetDefaults()
+			if tc.expectError && err == nil {
+				subT.Errorf("expected error for autoscaling spec %v", tc.autoscaling)
+			}
+			if !tc.expectError && err != nil {
+				subT.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestAPIManagerStatusSetCondition(t *testing.T) {
+	status := &APIManagerStatus{}
+
+	changed := status.SetCondition(APIManagerCondition{
+		Type:    APIManagerAvailable,
+		Status:  v1.ConditionFalse,
+		Reason:  "ComponentsNotReady",
+		Message: "Components not ready: System",
+	})
+	if !changed {
+		t.Errorf("Expected status to change when adding a new condition")
+	}
+
+	condition := status.GetCondition(APIManagerAvailable)
+	if condition == nil {
+		t.Fatalf("Expected condition %s to exist", APIManagerAvailable)
+	}
+	if condition.LastTransitionTime.IsZero() {
+		t.Errorf("Expected LastTransitionTime to be set")
+	}
+
+	transitionTime := metav1.NewTime(condition.LastTransitionTime.Add(-time.Hour))
+	condition.LastTransitionTime = transitionTime
+
+	changed = status.SetCondition(APIManagerCondition{
+		Type:    APIManagerAvailable,
+		Status:  v1.ConditionFalse,
+		Reason:  "ComponentsNotReady",
+		Message: "Components not ready: System, Zync",
+	})
+	if !changed {
+		t.Errorf("Expected status to change when updating the message")
+	}
+	if !status.GetCondition(APIManagerAvailable).LastTransitionTime.Equal(&transitionTime) {
+		t.Errorf("Expected LastTransitionTime not to change when the status is the same")
+	}
+
+	changed = status.SetCondition(APIManagerCondition{
+		Type:    APIManagerAvailable,
+		Status:  v1.ConditionTrue,
+		Reason:  "AllComponentsReady",
+		Message: "All the APIManager components are ready",
+	})
+	if !changed {
+		t.Errorf("Expected status to change when updating the status")
+	}
+	if status.GetCondition(APIManagerAvailable).LastTransitionTime.Equal(&transitionTime) {
+		t.Errorf("Expected LastTransitionTime to change when the status changes")
+	}
+	if !status.IsConditionTrue(APIManagerAvailable) {
+		t.Errorf("Expected condition %s to be true", APIManagerAvailable)
+	}
+
+	if !status.RemoveCondition(APIManagerAvailable) {
+		t.Errorf("Expected status to change when removing an existing condition")
+	}
+	if status.GetCondition(APIManagerAvailable) != nil {
+		t.Errorf("Expected condition %s to be removed", APIManagerAvailable)
+	}
+}
+
+func TestExposureSpecCoversTenantHosts(t *testing.T) {
+	wildcard := &EndpointExposureSpec{AdditionalHosts: []string{"api.example.com", "*.apps.example.com"}}
+	noWildcard := &EndpointExposureSpec{AdditionalHosts: []string{"api.example.com"}}
+
+	cases := []struct {
+		testName string
+		exposure ExposureSpec
+		expected bool
+	}{
+		{"NoAdditionalHosts", ExposureSpec{}, false},
+		{"AllWildcards", ExposureSpec{Provider: wildcard, Developer: wildcard, ApicastStaging: wildcard, ApicastProduction: wildcard}, true},
+		{"MissingEndpoint", ExposureSpec{Provider: wildcard, Developer: wildcard, ApicastProduction: wildcard}, false},
+		{"NoWildcard", ExposureSpec{Provider: wildcard, Developer: wildcard, ApicastStaging: noWildcard, ApicastProduction: wildcard}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			if covers := tc.exposure.CoversTenantHosts(); covers != tc.expected {
+				subT.Errorf("expected covers tenant hosts: %t, got: %t", tc.expected, covers)
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var apimanagerlog = logf.Log.WithName("apimanager-webhook")
+
+// SetupWebhookWithManager registers the APIManager defaulting and
+// validating admission webhooks in the webhook server of the manager
+func (apimanager *APIManager) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(apimanager).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-apps-3scale-net-v1alpha1-apimanager,mutating=true,failurePolicy=fail,groups=apps.3scale.net,resources=apimanagers,verbs=create;update,versions=v1alpha1,name=mapimanager.kb.io
+
+var _ webhook.Defaulter = &APIManager{}
+
+// Default implements webhook.Defaulter. Invalid APIManagers are left
+// untouched so the validating webhook can report the errors instead of
+// the defaulting logic silently fixing them
+func (apimanager *APIManager) Default() {
+	if len(apimanager.Validate()) > 0 {
+		return
+	}
+
+	apimanagerlog.V(1).Info("default", "name", apimanager.Name)
+	// the spec has been validated, so no error can be returned here
+	_, _ = apimanager.SetDefaults()
+}
+
+// +kubebuilder:webhook:path=/validate-apps-3scale-net-v1alpha1-apimanager,mutating=false,failurePolicy=fail,groups=apps.3scale.net,resources=apimanagers,verbs=create;update,versions=v1alpha1,name=vapimanager.kb.io
+
+var _ webhook.Validator = &APIManager{}
+
+// ValidateCreate implements webhook.Validator
+func (apimanager *APIManager) ValidateCreate() error {
+	apimanagerlog.V(1).Info("validate create", "name", apimanager.Name)
+	return apimanager.invalidError(apimanager.Validate())
+}
+
+// ValidateUpdate implements webhook.Validator. The errors already present
+// in the old APIManager are not reported, so existing invalid objects can
+// still be updated, e.g. by the operator itself, as long as the update
+// doesn't add new errors
+func (apimanager *APIManager) ValidateUpdate(old runtime.Object) error {
+	apimanagerlog.V(1).Info("validate update", "name", apimanager.Name)
+	errs := apimanager.Validate()
+	if oldAPIManager, ok := old.(*APIManager); ok {
+		errs = newErrors(errs, oldAPIManager.Validate())
+		errs = append(errs, apimanager.validatePlatformUpdate(oldAPIManager)...)
+		errs = append(errs, apimanager.validatePersistentVolumeClaimUpdate(oldAPIManager)...)
+	}
+	return apimanager.invalidError(errs)
+}
+
+// ValidateDelete implements webhook.Validator
+func (apimanager *APIManager) ValidateDelete() error {
+	return nil
+}
+
+// Validate returns the list of invalid fields of the APIManager spec
+func (apimanager *APIManager) Validate() field.ErrorList {
+	errs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
+	if apimanager.Spec.WildcardDomain == "" {
+		errs = append(errs, field.Required(specPath.Child("wildcardDomain"), "a wildcard domain is required"))
+	}
+
+	if apimanager.Spec.System != nil {
+		systemPath := specPath.Child("system")
+		fileStorageSpec := apimanager.Spec.System.FileStorageSpec
+		if fileStorageSpec != nil {
+			fileStoragePath := systemPath.Child("fileStorage")
+			selected := []string{}
+			if fileStorageSpec.PVC != nil {
+				selected = append(selected, "persistentVolumeClaim")
+			}
+			if fileStorageSpec.DeprecatedS3 != nil {
+				selected = append(selected, "amazonSimpleStorageService")
+			}
+			if fileStorageSpec.S3 != nil {
+				selected = append(selected, "simpleStorageService")
+			}
+			if len(selected) > 1 {
+				errs = append(errs, field.Forbidden(fileStoragePath, fmt.Sprintf("only one file storage can be chosen at the same time, got: %s", strings.Join(selected, ", "))))
+			}
+			if fileStorageSpec.S3 != nil && fileStorageSpec.S3.ConfigurationSecretRef.Name == "" {
+				errs = append(errs, field.Required(fileStoragePath.Child("simpleStorageService", "configurationSecretRef", "name"), "a configuration secret name is required"))
+			}
+		}
+
+		databaseSpec := apimanager.Spec.System.DatabaseSpec
+		if databaseSpec != nil {
+			databasePath := systemPath.Child("database")
+			if apimanager.IsExternalDatabaseEnabled() {
+				errs = append(errs, field.Forbidden(databasePath, "system database cannot be set when highAvailability is enabled, external databases are used instead"))
+			}
+			if databaseSpec.MySQL != nil && databaseSpec.PostgreSQL != nil {
+				errs = append(errs, field.Forbidden(databasePath, "only one of mysql or postgresql can be chosen at the same time"))
+			}
+		}
+	}
+
+	errs = append(errs, apimanager.validateAutoscalingSpecFields()...)
+
+	platformPath := specPath.Child("platform")
+	if apimanager.Spec.Platform != nil && *apimanager.Spec.Platform != PlatformOpenShift && *apimanager.Spec.Platform != PlatformKubernetes {
+		errs = append(errs, field.NotSupported(platformPath, *apimanager.Spec.Platform, []string{string(PlatformOpenShift), string(PlatformKubernetes)}))
+	}
+
+	if apimanager.Spec.Exposure != nil {
+		errs = append(errs, apimanager.Spec.Exposure.validate(specPath.Child("exposure"))...)
+		if apimanager.IsKubernetesPlatform() && apimanager.ExposureType() == ExposureTypeRoute {
+			errs = append(errs, field.Forbidden(specPath.Child("exposure", "type"), "Routes are not available in the Kubernetes platform, use Ingress instead"))
+		}
+	}
+
+	errs = append(errs, validateSecretSources(specPath.Child("secretSources"), apimanager.Spec.SecretSources)...)
+
+	for _, pvcField := range apimanager.persistentVolumeClaimFields() {
+		errs = append(errs, pvcField.spec.validate(pvcField.path)...)
+	}
+
+	return errs
+}
+
+// newErrors returns the errors not included in oldErrs
+func newErrors(errs, oldErrs field.ErrorList) field.ErrorList {
+	existing := map[string]bool{}
+	for _, err := range oldErrs {
+		existing[err.Error()] = true
+	}
+
+	result := field.ErrorList{}
+	for _, err := range errs {
+		if !existing[err.Error()] {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
+// validatePlatformUpdate forbids switching the platform of an existing
+// APIManager, as the deployed resources would be duplicated
+func (apimanager *APIManager) validatePlatformUpdate(old *APIManager) field.ErrorList {
+	errs := field.ErrorList{}
+	if old.specPlatform() != apimanager.specPlatform() {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "platform"), "the platform cannot be changed once set"))
+	}
+	return errs
+}
+
+// validatePersistentVolumeClaimUpdate forbids decreasing the storage request of
+// the PersistentVolumeClaims, as claims cannot be shrunk
+func (apimanager *APIManager) validatePersistentVolumeClaimUpdate(old *APIManager) field.ErrorList {
+	errs := field.ErrorList{}
+
+	oldStorageRequests := map[string]resource.Quantity{}
+	for _, pvcField := range old.persistentVolumeClaimFields() {
+		if storageRequest, err := pvcField.spec.storageRequest(); err == nil && storageRequest != nil {
+			oldStorageRequests[pvcField.path.String()] = *storageRequest
+		}
+	}
+
+	for _, pvcField := range apimanager.persistentVolumeClaimFields() {
+		oldStorageRequest, ok := oldStorageRequests[pvcField.path.String()]
+		if !ok {
+			continue
+		}
+		storageRequest, err := pvcField.spec.storageRequest()
+		if err != nil || storageRequest == nil {
+			continue
+		}
+		if storageRequest.Cmp(oldStorageRequest) < 0 {
+			errs = append(errs, field.Forbidden(pvcField.path.Child("storageRequest"), fmt.Sprintf("the storage request cannot be decreased from %s", oldStorageRequest.String())))
+		}
+	}
+
+	return errs
+}
+
+type persistentVolumeClaimField struct {
+	path *field.Path
+	spec *PersistentVolumeClaimSpec
+}
+
+// persistentVolumeClaimFields returns the PersistentVolumeClaim configurations
+// set in the APIManager spec together with their paths
+func (apimanager *APIManager) persistentVolumeClaimFields() []persistentVolumeClaimField {
+	fields := []persistentVolumeClaimField{}
+	specPath := field.NewPath("spec")
+
+	if backend := apimanager.Spec.Backend; backend != nil && backend.RedisPersistentVolumeClaimSpec != nil {
+		fields = append(fields, persistentVolumeClaimField{specPath.Child("backend", "redisPersistentVolumeClaim"), backend.RedisPersistentVolumeClaimSpec})
+	}
+
+	if system := apimanager.Spec.System; system != nil {
+		systemPath := specPath.Child("system")
+		if system.RedisPersistentVolumeClaimSpec != nil {
+			fields = append(fields, persistentVolumeClaimField{systemPath.Child("redisPersistentVolumeClaim"), system.RedisPersistentVolumeClaimSpec})
+		}
+		if system.FileStorageSpec != nil && system.FileStorageSpec.PVC != nil {
+			fields = append(fields, persistentVolumeClaimField{systemPath.Child("fileStorage", "persistentVolumeClaim"), system.FileStorageSpec.PVC.PersistentVolumeClaimSpec()})
+		}
+		if database := system.DatabaseSpec; database != nil {
+			if database.MySQL != nil && database.MySQL.PersistentVolumeClaimSpec != nil {
+				fields = append(fields, persistentVolumeClaimField{systemPath.Child("database", "mysql", "persistentVolumeClaim"), database.MySQL.PersistentVolumeClaimSpec})
+			}
+			if database.PostgreSQL != nil && database.PostgreSQL.PersistentVolumeClaimSpec != nil {
+				fields = append(fields, persistentVolumeClaimField{systemPath.Child("database", "postgresql", "persistentVolumeClaim"), database.PostgreSQL.PersistentVolumeClaimSpec})
+			}
+		}
+	}
+
+	if zync := apimanager.Spec.Zync; zync != nil && zync.DatabasePersistentVolumeClaimSpec != nil {
+		fields = append(fields, persistentVolumeClaimField{specPath.Child("zync", "databasePersistentVolumeClaim"), zync.DatabasePersistentVolumeClaimSpec})
+	}
+
+	return fields
+}
+
+var supportedPersistentVolumeAccessModes = []string{
+	string(v1.ReadWriteOnce),
+	string(v1.ReadOnlyMany),
+	string(v1.ReadWriteMany),
+}
+
+func (s *PersistentVolumeClaimSpec) validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	storageRequest, err := s.storageRequest()
+	if err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("storageRequest"), *s.StorageRequest, err.Error()))
+	} else if storageRequest != nil && storageRequest.Sign() <= 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("storageRequest"), *s.StorageRequest, "must be greater than zero"))
+	}
+
+	for idx, accessMode := range s.AccessModes {
+		supported := false
+		for _, supportedAccessMode := range supportedPersistentVolumeAccessModes {
+			supported = supported || string(accessMode) == supportedAccessMode
+		}
+		if !supported {
+			errs = append(errs, field.NotSupported(fldPath.Child("accessModes").Index(idx), accessMode, supportedPersistentVolumeAccessModes))
+		}
+	}
+
+	if s.Selector != nil {
+		errs = append(errs, metav1validation.ValidateLabelSelector(s.Selector, fldPath.Child("selector"))...)
+	}
+
+	return errs
+}
+
+func (s *PersistentVolumeClaimSpec) storageRequest() (*resource.Quantity, error) {
+	if s.StorageRequest == nil {
+		return nil, nil
+	}
+	storageRequest, err := resource.ParseQuantity(*s.StorageRequest)
+	if err != nil {
+		return nil, err
+	}
+	return &storageRequest, nil
+}
+
+func (e *ExposureSpec) validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	exposureType := e.Type
+	if exposureType == "" {
+		exposureType = ExposureTypeRoute
+	}
+	if exposureType != ExposureTypeRoute && exposureType != ExposureTypeIngress {
+		errs = append(errs, field.NotSupported(fldPath.Child("type"), e.Type, []string{string(ExposureTypeRoute), string(ExposureTypeIngress)}))
+	}
+
+	endpoints := []struct {
+		name string
+		spec *EndpointExposureSpec
+	}{
+		{"master", e.Master},
+		{"provider", e.Provider},
+		{"developer", e.Developer},
+		{"apicastStaging", e.ApicastStaging},
+		{"apicastProduction", e.ApicastProduction},
+		{"backend", e.Backend},
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.spec == nil {
+			continue
+		}
+		errs = append(errs, endpoint.spec.validateHosts(fldPath.Child(endpoint.name))...)
+		if endpoint.spec.TLS != nil {
+			errs = append(errs, endpoint.spec.TLS.validate(fldPath.Child(endpoint.name, "tls"), exposureType)...)
+		}
+	}
+
+	return errs
+}
+
+func (e *EndpointExposureSpec) validateHosts(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if e.Host != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(*e.Host) {
+			errs = append(errs, field.Invalid(fldPath.Child("host"), *e.Host, msg))
+		}
+	}
+
+	for idx, host := range e.AdditionalHosts {
+		hostPath := fldPath.Child("additionalHosts").Index(idx)
+		msgs := validation.IsDNS1123Subdomain(host)
+		if strings.HasPrefix(host, "*.") {
+			msgs = validation.IsWildcardDNS1123Subdomain(host)
+		}
+		for _, msg := range msgs {
+			errs = append(errs, field.Invalid(hostPath, host, msg))
+		}
+	}
+
+	return errs
+}
+
+func (t *EndpointTLSSpec) validate(fldPath *field.Path, exposureType ExposureType) field.ErrorList {
+	errs := field.ErrorList{}
+	terminationPath := fldPath.Child("termination")
+
+	switch t.Termination {
+	case "", TLSTerminationEdge:
+	case TLSTerminationReencrypt, TLSTerminationPassthrough:
+		if exposureType == ExposureTypeIngress {
+			errs = append(errs, field.Forbidden(terminationPath, "only edge termination is supported with Ingress exposure"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(terminationPath, t.Termination, []string{string(TLSTerminationEdge), string(TLSTerminationReencrypt), string(TLSTerminationPassthrough)}))
+	}
+
+	if t.Termination == TLSTerminationPassthrough && t.CertificateSecretRef != nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("certificateSecretRef"), "the certificate is served by the service when the termination is passthrough"))
+	}
+
+	if t.DestinationCACertificateSecretRef != nil && t.Termination != TLSTerminationReencrypt {
+		errs = append(errs, field.Forbidden(fldPath.Child("destinationCACertificateSecretRef"), "only supported when the termination is reencrypt"))
+	}
+
+	if t.CertificateSecretRef != nil && t.CertificateSecretRef.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("certificateSecretRef", "name"), "a certificate secret name is required"))
+	}
+
+	return errs
+}
+
+func validateSecretSources(fldPath *field.Path, sources []SecretSourceSpec) field.ErrorList {
+	errs := field.ErrorList{}
+
+	secrets := map[string]bool{}
+	for idx, source := range sources {
+		secretPath := fldPath.Index(idx).Child("secret")
+		if !isSecretSourcedSecret(source.Secret) {
+			errs = append(errs, field.NotSupported(secretPath, source.Secret, SecretSourcedSecrets))
+		} else if secrets[source.Secret] {
+			errs = append(errs, field.Duplicate(secretPath, source.Secret))
+		}
+		secrets[source.Secret] = true
+
+		if source.CSI != nil && source.CSI.Driver == "" {
+			errs = append(errs, field.Required(fldPath.Index(idx).Child("csi", "driver"), "a CSI driver is required"))
+		}
+	}
+
+	return errs
+}
+
+func isSecretSourcedSecret(name string) bool {
+	for _, secret := range SecretSourcedSecrets {
+		if secret == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (apimanager *APIManager) invalidError(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.NewInvalid(SchemeGroupVersion.WithKind("APIManager").GroupKind(), apimanager.Name, errs)
+}
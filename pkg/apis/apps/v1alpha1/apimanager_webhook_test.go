@@ -0,0 +1,350 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAPIManagerValidate(t *testing.T) {
+	cases := []struct {
+		name           string
+		spec           APIManagerSpec
+		expectedFields []string
+	}{
+		{"Valid", APIManagerSpec{}, []string{}},
+		{"PVCAndS3",
+			APIManagerSpec{
+				System: &SystemSpec{
+					FileStorageSpec: &SystemFileStorageSpec{
+						PVC: &SystemPVCSpec{},
+						S3:  &SystemS3Spec{ConfigurationSecretRef: v1.LocalObjectReference{Name: "s3"}},
+					},
+				},
+			},
+			[]string{"spec.system.fileStorage"},
+		},
+		{"DeprecatedS3AndS3",
+			APIManagerSpec{
+				System: &SystemSpec{
+					FileStorageSpec: &SystemFileStorageSpec{
+						DeprecatedS3: &DeprecatedSystemS3Spec{},
+						S3:           &SystemS3Spec{ConfigurationSecretRef: v1.LocalObjectReference{Name: "s3"}},
+					},
+				},
+			},
+			[]string{"spec.system.fileStorage"},
+		},
+		{"S3WithoutSecret",
+			APIManagerSpec{
+				System: &SystemSpec{
+					FileStorageSpec: &SystemFileStorageSpec{S3: &SystemS3Spec{}},
+				},
+			},
+			[]string{"spec.system.fileStorage.simpleStorageService.configurationSecretRef.name"},
+		},
+		{"DatabaseWithHighAvailability",
+			APIManagerSpec{
+				HighAvailability: &HighAvailabilitySpec{Enabled: true},
+				System: &SystemSpec{
+					DatabaseSpec: &SystemDatabaseSpec{MySQL: &SystemMySQLSpec{}},
+				},
+			},
+			[]string{"spec.system.database"},
+		},
+		{"MySQLAndPostgreSQL",
+			APIManagerSpec{
+				System: &SystemSpec{
+					DatabaseSpec: &SystemDatabaseSpec{MySQL: &SystemMySQLSpec{}, PostgreSQL: &SystemPostgreSQLSpec{}},
+				},
+			},
+			[]string{"spec.system.database"},
+		},
+		{"InvalidAutoscaling",
+			APIManagerSpec{
+				Apicast: &ApicastSpec{
+					ProductionSpec: &ApicastProductionSpec{Autoscaling: &AutoscalingSpec{Enabled: true}},
+				},
+			},
+			[]string{"spec.apicast.productionSpec.autoscaling.maxReplicas"},
+		},
+		{"RouteExposure",
+			APIManagerSpec{
+				Exposure: &ExposureSpec{
+					Master:  &EndpointExposureSpec{TLS: &EndpointTLSSpec{Termination: TLSTerminationPassthrough}},
+					Backend: &EndpointExposureSpec{TLS: &EndpointTLSSpec{Termination: TLSTerminationReencrypt, DestinationCACertificateSecretRef: &v1.LocalObjectReference{Name: "ca"}}},
+				},
+			},
+			[]string{},
+		},
+		{"UnknownExposureType",
+			APIManagerSpec{Exposure: &ExposureSpec{Type: "LoadBalancer"}},
+			[]string{"spec.exposure.type"},
+		},
+		{"IngressReencrypt",
+			APIManagerSpec{
+				Exposure: &ExposureSpec{
+					Type:     ExposureTypeIngress,
+					Provider: &EndpointExposureSpec{TLS: &EndpointTLSSpec{Termination: TLSTerminationReencrypt}},
+				},
+			},
+			[]string{"spec.exposure.provider.tls.termination"},
+		},
+		{"PassthroughWithCertificate",
+			APIManagerSpec{
+				Exposure: &ExposureSpec{
+					ApicastProduction: &EndpointExposureSpec{TLS: &EndpointTLSSpec{Termination: TLSTerminationPassthrough, CertificateSecretRef: &v1.LocalObjectReference{Name: "cert"}}},
+				},
+			},
+			[]string{"spec.exposure.apicastProduction.tls.certificateSecretRef"},
+		},
+		{"DestinationCAWithoutReencrypt",
+			APIManagerSpec{
+				Exposure: &ExposureSpec{
+					Developer: &EndpointExposureSpec{TLS: &EndpointTLSSpec{DestinationCACertificateSecretRef: &v1.LocalObjectReference{Name: "ca"}}},
+				},
+			},
+			[]string{"spec.exposure.developer.tls.destinationCACertificateSecretRef"},
+		},
+		{"AdditionalHosts",
+			APIManagerSpec{
+				Exposure: &ExposureSpec{
+					ApicastProduction: &EndpointExposureSpec{AdditionalHosts: []string{"api.example.com", "*.apps.example.com"}},
+				},
+			},
+			[]string{},
+		},
+		{"InvalidHosts",
+			APIManagerSpec{
+				Exposure: &ExposureSpec{
+					Master:         &EndpointExposureSpec{Host: &[]string{"*.example.com"}[0]},
+					ApicastStaging: &EndpointExposureSpec{AdditionalHosts: []string{"api.example.com", "api_example.com"}},
+				},
+			},
+			[]string{"spec.exposure.master.host", "spec.exposure.apicastStaging.additionalHosts[1]"},
+		},
+		{"UnknownPlatform",
+			APIManagerSpec{APIManagerCommonSpec: APIManagerCommonSpec{Platform: &[]PlatformType{"Mesos"}[0]}},
+			[]string{"spec.platform"},
+		},
+		{"KubernetesPlatformWithRoutes",
+			APIManagerSpec{
+				APIManagerCommonSpec: APIManagerCommonSpec{Platform: &[]PlatformType{PlatformKubernetes}[0]},
+				Exposure:             &ExposureSpec{},
+			},
+			[]string{"spec.exposure.type"},
+		},
+		{"KubernetesPlatformWithIngresses",
+			APIManagerSpec{
+				APIManagerCommonSpec: APIManagerCommonSpec{Platform: &[]PlatformType{PlatformKubernetes}[0]},
+				Exposure:             &ExposureSpec{Type: ExposureTypeIngress},
+			},
+			[]string{},
+		},
+		{"SecretSources",
+			APIManagerSpec{
+				SecretSources: []SecretSourceSpec{
+					{Secret: "system-seed", SecretName: &[]string{"vault-system-seed"}[0]},
+					{Secret: "backend-redis", CSI: &v1.CSIVolumeSource{Driver: "secrets-store.csi.k8s.io"}},
+				},
+			},
+			[]string{},
+		},
+		{"InvalidSecretSources",
+			APIManagerSpec{
+				SecretSources: []SecretSourceSpec{
+					{Secret: "aws-auth"},
+					{Secret: "zync"},
+					{Secret: "zync"},
+					{Secret: "system-app", CSI: &v1.CSIVolumeSource{}},
+				},
+			},
+			[]string{"spec.secretSources[0].secret", "spec.secretSources[2].secret", "spec.secretSources[3].csi.driver"},
+		},
+		{"PersistentVolumeClaims",
+			APIManagerSpec{
+				Backend: &BackendSpec{
+					RedisPersistentVolumeClaimSpec: &PersistentVolumeClaimSpec{StorageRequest: &[]string{"10Gi"}[0]},
+				},
+				System: &SystemSpec{
+					FileStorageSpec: &SystemFileStorageSpec{
+						PVC: &SystemPVCSpec{AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteMany}},
+					},
+				},
+				Zync: &ZyncSpec{
+					DatabasePersistentVolumeClaimSpec: &PersistentVolumeClaimSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"volume": "zync-database"}},
+					},
+				},
+			},
+			[]string{},
+		},
+		{"InvalidPersistentVolumeClaims",
+			APIManagerSpec{
+				Backend: &BackendSpec{
+					RedisPersistentVolumeClaimSpec: &PersistentVolumeClaimSpec{StorageRequest: &[]string{"ten"}[0]},
+				},
+				System: &SystemSpec{
+					RedisPersistentVolumeClaimSpec: &PersistentVolumeClaimSpec{StorageRequest: &[]string{"0"}[0]},
+					FileStorageSpec: &SystemFileStorageSpec{
+						PVC: &SystemPVCSpec{AccessModes: []v1.PersistentVolumeAccessMode{"ReadWriteAlways"}},
+					},
+				},
+				Zync: &ZyncSpec{
+					DatabasePersistentVolumeClaimSpec: &PersistentVolumeClaimSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"volume": "zync database"}},
+					},
+				},
+			},
+			[]string{
+				"spec.backend.redisPersistentVolumeClaim.storageRequest",
+				"spec.system.redisPersistentVolumeClaim.storageRequest",
+				"spec.system.fileStorage.persistentVolumeClaim.accessModes[0]",
+				"spec.zync.databasePersistentVolumeClaim.selector.matchLabels",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			apimanager := &APIManager{
+				ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager"},
+				Spec:       tc.spec,
+			}
+			apimanager.Spec.WildcardDomain = "test.3scale.com"
+
+			errs := apimanager.Validate()
+			if len(errs) != len(tc.expectedFields) {
+				subT.Fatalf("expected %d errors, got: %v", len(tc.expectedFields), errs)
+			}
+			for idx, expectedField := range tc.expectedFields {
+				if errs[idx].Field != expectedField {
+					subT.Errorf("expected error on field %s, got: %s", expectedField, errs[idx].Field)
+				}
+			}
+
+			err := apimanager.ValidateCreate()
+			if len(tc.expectedFields) == 0 && err != nil {
+				subT.Errorf("unexpected error: %s", err)
+			}
+			if len(tc.expectedFields) > 0 && !errors.IsInvalid(err) {
+				subT.Errorf("expected invalid error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIManagerValidateUpdatePlatform(t *testing.T) {
+	kubernetesPlatform := PlatformKubernetes
+	old := &APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager"},
+		Spec: APIManagerSpec{
+			APIManagerCommonSpec: APIManagerCommonSpec{WildcardDomain: "test.3scale.com"},
+		},
+	}
+
+	updated := old.DeepCopy()
+	updated.Spec.Platform = &kubernetesPlatform
+	err := updated.ValidateUpdate(old)
+	if !errors.IsInvalid(err) {
+		t.Errorf("expected invalid error when changing the platform, got: %v", err)
+	}
+
+	unchanged := updated.DeepCopy()
+	err = unchanged.ValidateUpdate(updated)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestAPIManagerValidateUpdatePersistentVolumeClaims(t *testing.T) {
+	old := &APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager"},
+		Spec: APIManagerSpec{
+			APIManagerCommonSpec: APIManagerCommonSpec{WildcardDomain: "test.3scale.com"},
+			System: &SystemSpec{
+				DatabaseSpec: &SystemDatabaseSpec{
+					MySQL: &SystemMySQLSpec{
+						PersistentVolumeClaimSpec: &PersistentVolumeClaimSpec{StorageRequest: &[]string{"2Gi"}[0]},
+					},
+				},
+			},
+		},
+	}
+
+	expanded := old.DeepCopy()
+	expanded.Spec.System.DatabaseSpec.MySQL.PersistentVolumeClaimSpec.StorageRequest = &[]string{"4Gi"}[0]
+	err := expanded.ValidateUpdate(old)
+	if err != nil {
+		t.Errorf("unexpected error when expanding the storage request: %s", err)
+	}
+
+	shrunk := old.DeepCopy()
+	shrunk.Spec.System.DatabaseSpec.MySQL.PersistentVolumeClaimSpec.StorageRequest = &[]string{"1024Mi"}[0]
+	err = shrunk.ValidateUpdate(old)
+	if !errors.IsInvalid(err) {
+		t.Errorf("expected invalid error when decreasing the storage request, got: %v", err)
+	}
+}
+
+func TestAPIManagerValidateUpdateExistingErrors(t *testing.T) {
+	old := &APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager"},
+		Spec: APIManagerSpec{
+			APIManagerCommonSpec: APIManagerCommonSpec{WildcardDomain: "test.3scale.com"},
+			Exposure:             &ExposureSpec{Type: "LoadBalancer"},
+		},
+	}
+
+	// Updates not adding errors, like the annotations set by the operator, are allowed
+	annotated := old.DeepCopy()
+	annotated.Annotations = map[string]string{OperatorVersionAnnotation: "1.0.0"}
+	err := annotated.ValidateUpdate(old)
+	if err != nil {
+		t.Errorf("unexpected error when the old APIManager has the same errors: %s", err)
+	}
+
+	// New errors are reported
+	invalid := old.DeepCopy()
+	invalid.Spec.WildcardDomain = ""
+	err = invalid.ValidateUpdate(old)
+	if !errors.IsInvalid(err) {
+		t.Fatalf("expected invalid error when adding errors, got: %v", err)
+	}
+	details := err.(*errors.StatusError).ErrStatus.Details
+	if details == nil || len(details.Causes) != 1 || details.Causes[0].Field != "spec.wildcardDomain" {
+		t.Errorf("expected only the new error to be reported, got: %v", details)
+	}
+}
+
+func TestAPIManagerDefault(t *testing.T) {
+	apimanager := &APIManager{
+		Spec: APIManagerSpec{
+			APIManagerCommonSpec: APIManagerCommonSpec{
+				WildcardDomain: "test.3scale.com",
+			},
+		},
+	}
+	apimanager.Default()
+	if apimanager.Spec.AppLabel == nil || apimanager.Spec.Apicast == nil {
+		t.Errorf("expected defaults to be set")
+	}
+
+	invalid := &APIManager{
+		Spec: APIManagerSpec{
+			APIManagerCommonSpec: APIManagerCommonSpec{
+				WildcardDomain: "test.3scale.com",
+			},
+			HighAvailability: &HighAvailabilitySpec{Enabled: true},
+			System: &SystemSpec{
+				DatabaseSpec: &SystemDatabaseSpec{MySQL: &SystemMySQLSpec{}},
+			},
+		},
+	}
+	invalid.Default()
+	if invalid.Spec.System.DatabaseSpec == nil {
+		t.Errorf("invalid APIManager should not be modified by defaulting")
+	}
+}
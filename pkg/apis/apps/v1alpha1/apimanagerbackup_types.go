@@ -0,0 +1,160 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Label of the secrets stored by an APIManagerBackup. Its value is the
+	// name of the backup
+	BackupSecretLabel = "apps.3scale.net/apimanager-backup"
+	// Annotation of the secrets stored by an APIManagerBackup. Its value is
+	// the name of the backed up secret
+	BackupSecretSourceAnnotation = "apps.3scale.net/backup-secret-source"
+	// Annotation of the DeploymentConfigs, or Deployments, stopped by an
+	// APIManagerRestore. Its value is the number of replicas they had before
+	RestoreStoppedReplicasAnnotation = "apps.3scale.net/restore-stopped-replicas"
+)
+
+const (
+	defaultBackupPersistentVolumeClaimStorageRequest = "2Gi"
+)
+
+// APIManagerBackupSpec defines the desired state of APIManagerBackup
+type APIManagerBackupSpec struct {
+	// Name of the APIManager to back up. Defaults to the only APIManager
+	// of the namespace
+	// +optional
+	APIManagerName *string `json:"apiManagerName,omitempty"`
+	// Where the backup is stored
+	BackupDestination APIManagerBackupDestinationSpec `json:"backupDestination"`
+}
+
+type APIManagerBackupDestinationSpec struct {
+	// Union type. Only one of the fields can be set
+	// +optional
+	PersistentVolumeClaim *PersistentVolumeClaimBackupSpec `json:"persistentVolumeClaim,omitempty"`
+	// +optional
+	SimpleStorageService *SimpleStorageServiceBackupSpec `json:"simpleStorageService,omitempty"`
+}
+
+type PersistentVolumeClaimBackupSpec struct {
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Name of the PersistentVolume the backup PersistentVolumeClaim is bound to
+	// +optional
+	VolumeName *string `json:"volumeName,omitempty"`
+	// Storage requested by the backup PersistentVolumeClaim, as a resource
+	// quantity. Defaults to 2Gi
+	// +optional
+	StorageRequest *string `json:"storageRequest,omitempty"`
+}
+
+type SimpleStorageServiceBackupSpec struct {
+	// Secret with the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_BUCKET
+	// and AWS_REGION fields of the bucket the backup is uploaded to
+	ConfigurationSecretRef v1.LocalObjectReference `json:"configurationSecretRef"`
+	// Path of the backup in the bucket. Defaults to the backup name
+	// +optional
+	Path *string `json:"path,omitempty"`
+}
+
+type APIManagerBackupPhase string
+
+const (
+	APIManagerBackupPhaseRunning   APIManagerBackupPhase = "Running"
+	APIManagerBackupPhaseCompleted APIManagerBackupPhase = "Completed"
+	APIManagerBackupPhaseFailed    APIManagerBackupPhase = "Failed"
+)
+
+// APIManagerBackupStatus defines the observed state of APIManagerBackup
+type APIManagerBackupStatus struct {
+	// +optional
+	Phase APIManagerBackupPhase `json:"phase,omitempty"`
+	// Name of the APIManager that has been backed up
+	// +optional
+	APIManagerName string `json:"apiManagerName,omitempty"`
+	// Name of the PersistentVolumeClaim where the backup is stored
+	// +optional
+	BackupPersistentVolumeClaimName string `json:"backupPersistentVolumeClaimName,omitempty"`
+	// Backup steps already completed
+	// +optional
+	CompletedSteps []string `json:"completedSteps,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsStepCompleted returns true when the given backup step has already been run
+func (s *APIManagerBackupStatus) IsStepCompleted(step string) bool {
+	for _, completedStep := range s.CompletedSteps {
+		if completedStep == step {
+			return true
+		}
+	}
+	return false
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// APIManagerBackup is the Schema for the apimanagerbackups API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=apimanagerbackups,scope=Namespaced
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="APIManagerBackup"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Job,batch/v1"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="PersistentVolumeClaim,v1"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Secret,v1"
+type APIManagerBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIManagerBackupSpec   `json:"spec,omitempty"`
+	Status APIManagerBackupStatus `json:"status,omitempty"`
+}
+
+// SetDefaults sets the default values of the backup spec and returns true
+// if it has been changed
+func (backup *APIManagerBackup) SetDefaults() bool {
+	pvcSpec := backup.Spec.BackupDestination.PersistentVolumeClaim
+	if pvcSpec == nil || pvcSpec.StorageRequest != nil {
+		return false
+	}
+
+	storageRequest := defaultBackupPersistentVolumeClaimStorageRequest
+	pvcSpec.StorageRequest = &storageRequest
+	return true
+}
+
+// Validate checks that one and only one backup destination is set and that
+// the storage request of the backup PersistentVolumeClaim is a quantity
+func (backup *APIManagerBackup) Validate() error {
+	destination := backup.Spec.BackupDestination
+	if (destination.PersistentVolumeClaim == nil) == (destination.SimpleStorageService == nil) {
+		return fmt.Errorf("one and only one of persistentVolumeClaim or simpleStorageService backup destination has to be set")
+	}
+
+	if destination.PersistentVolumeClaim != nil && destination.PersistentVolumeClaim.StorageRequest != nil {
+		_, err := resource.ParseQuantity(*destination.PersistentVolumeClaim.StorageRequest)
+		if err != nil {
+			return fmt.Errorf("invalid persistentVolumeClaim storageRequest: %v", err)
+		}
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// APIManagerBackupList contains a list of APIManagerBackup
+type APIManagerBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIManagerBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIManagerBackup{}, &APIManagerBackupList{})
+}
@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestAPIManagerBackupSetDefaults(t *testing.T) {
+	backup := &APIManagerBackup{
+		Spec: APIManagerBackupSpec{
+			BackupDestination: APIManagerBackupDestinationSpec{
+				PersistentVolumeClaim: &PersistentVolumeClaimBackupSpec{},
+			},
+		},
+	}
+
+	if !backup.SetDefaults() {
+		t.Fatal("expected the backup to be changed")
+	}
+	storageRequest := backup.Spec.BackupDestination.PersistentVolumeClaim.StorageRequest
+	if storageRequest == nil || *storageRequest != defaultBackupPersistentVolumeClaimStorageRequest {
+		t.Errorf("unexpected storage request: %v", storageRequest)
+	}
+	if backup.SetDefaults() {
+		t.Error("expected the defaulted backup not to be changed")
+	}
+}
+
+func TestAPIManagerBackupValidate(t *testing.T) {
+	invalidStorageRequest := "lots"
+
+	cases := []struct {
+		testName    string
+		destination APIManagerBackupDestinationSpec
+		expectedErr bool
+	}{
+		{"NoDestination", APIManagerBackupDestinationSpec{}, true},
+		{"BothDestinations", APIManagerBackupDestinationSpec{
+			PersistentVolumeClaim: &PersistentVolumeClaimBackupSpec{},
+			SimpleStorageService:  &SimpleStorageServiceBackupSpec{ConfigurationSecretRef: v1.LocalObjectReference{Name: "aws"}},
+		}, true},
+		{"InvalidStorageRequest", APIManagerBackupDestinationSpec{
+			PersistentVolumeClaim: &PersistentVolumeClaimBackupSpec{StorageRequest: &invalidStorageRequest},
+		}, true},
+		{"PersistentVolumeClaim", APIManagerBackupDestinationSpec{
+			PersistentVolumeClaim: &PersistentVolumeClaimBackupSpec{},
+		}, false},
+		{"S3", APIManagerBackupDestinationSpec{
+			SimpleStorageService: &SimpleStorageServiceBackupSpec{ConfigurationSecretRef: v1.LocalObjectReference{Name: "aws"}},
+		}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			backup := &APIManagerBackup{Spec: APIManagerBackupSpec{BackupDestination: tc.destination}}
+			err := backup.Validate()
+			if tc.expectedErr != (err != nil) {
+				subT.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// APIManagerRestoreSpec defines the desired state of APIManagerRestore
+type APIManagerRestoreSpec struct {
+	// Name of the completed APIManagerBackup of the same namespace to restore
+	APIManagerBackupName string `json:"apiManagerBackupName"`
+}
+
+type APIManagerRestorePhase string
+
+const (
+	APIManagerRestorePhaseRunning   APIManagerRestorePhase = "Running"
+	APIManagerRestorePhaseCompleted APIManagerRestorePhase = "Completed"
+	APIManagerRestorePhaseFailed    APIManagerRestorePhase = "Failed"
+)
+
+// APIManagerRestoreStatus defines the observed state of APIManagerRestore
+type APIManagerRestoreStatus struct {
+	// +optional
+	Phase APIManagerRestorePhase `json:"phase,omitempty"`
+	// Name of the APIManager that has been restored
+	// +optional
+	APIManagerName string `json:"apiManagerName,omitempty"`
+	// Restore steps already completed
+	// +optional
+	CompletedSteps []string `json:"completedSteps,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsStepCompleted returns true when the given restore step has already been run
+func (s *APIManagerRestoreStatus) IsStepCompleted(step string) bool {
+	for _, completedStep := range s.CompletedSteps {
+		if completedStep == step {
+			return true
+		}
+	}
+	return false
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// APIManagerRestore is the Schema for the apimanagerrestores API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=apimanagerrestores,scope=Namespaced
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="APIManagerRestore"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Job,batch/v1"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Secret,v1"
+type APIManagerRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIManagerRestoreSpec   `json:"spec,omitempty"`
+	Status APIManagerRestoreStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// APIManagerRestoreList contains a list of APIManagerRestore
+type APIManagerRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIManagerRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIManagerRestore{}, &APIManagerRestoreList{})
+}
@@ -5,7 +5,9 @@
 package v1alpha1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -36,6 +38,136 @@ func (in *APIManager) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackup) DeepCopyInto(out *APIManagerBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackup.
+func (in *APIManagerBackup) DeepCopy() *APIManagerBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupDestinationSpec) DeepCopyInto(out *APIManagerBackupDestinationSpec) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PersistentVolumeClaimBackupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SimpleStorageService != nil {
+		in, out := &in.SimpleStorageService, &out.SimpleStorageService
+		*out = new(SimpleStorageServiceBackupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupDestinationSpec.
+func (in *APIManagerBackupDestinationSpec) DeepCopy() *APIManagerBackupDestinationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupDestinationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupList) DeepCopyInto(out *APIManagerBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]APIManagerBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupList.
+func (in *APIManagerBackupList) DeepCopy() *APIManagerBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupSpec) DeepCopyInto(out *APIManagerBackupSpec) {
+	*out = *in
+	if in.APIManagerName != nil {
+		in, out := &in.APIManagerName, &out.APIManagerName
+		*out = new(string)
+		**out = **in
+	}
+	in.BackupDestination.DeepCopyInto(&out.BackupDestination)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupSpec.
+func (in *APIManagerBackupSpec) DeepCopy() *APIManagerBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupStatus) DeepCopyInto(out *APIManagerBackupStatus) {
+	*out = *in
+	if in.CompletedSteps != nil {
+		in, out := &in.CompletedSteps, &out.CompletedSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupStatus.
+func (in *APIManagerBackupStatus) DeepCopy() *APIManagerBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerCommonSpec) DeepCopyInto(out *APIManagerCommonSpec) {
 	*out = *in
@@ -59,6 +191,11 @@ func (in *APIManagerCommonSpec) DeepCopyInto(out *APIManagerCommonSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(PlatformType)
+		**out = **in
+	}
 	return
 }
 
@@ -75,6 +212,7 @@ func (in *APIManagerCommonSpec) DeepCopy() *APIManagerCommonSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerCondition) DeepCopyInto(out *APIManagerCondition) {
 	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
@@ -121,6 +259,104 @@ func (in *APIManagerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerRestore) DeepCopyInto(out *APIManagerRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestore.
+func (in *APIManagerRestore) DeepCopy() *APIManagerRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerRestoreList) DeepCopyInto(out *APIManagerRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]APIManagerRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreList.
+func (in *APIManagerRestoreList) DeepCopy() *APIManagerRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerRestoreSpec) DeepCopyInto(out *APIManagerRestoreSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreSpec.
+func (in *APIManagerRestoreSpec) DeepCopy() *APIManagerRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerRestoreStatus) DeepCopyInto(out *APIManagerRestoreStatus) {
+	*out = *in
+	if in.CompletedSteps != nil {
+		in, out := &in.CompletedSteps, &out.CompletedSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreStatus.
+func (in *APIManagerRestoreStatus) DeepCopy() *APIManagerRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerSpec) DeepCopyInto(out *APIManagerSpec) {
 	*out = *in
@@ -155,6 +391,23 @@ func (in *APIManagerSpec) DeepCopyInto(out *APIManagerSpec) {
 		*out = new(PodDisruptionBudgetSpec)
 		**out = **in
 	}
+	if in.Exposure != nil {
+		in, out := &in.Exposure, &out.Exposure
+		*out = new(ExposureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialRotation != nil {
+		in, out := &in.CredentialRotation, &out.CredentialRotation
+		*out = new(CredentialRotationSpec)
+		**out = **in
+	}
+	if in.SecretSources != nil {
+		in, out := &in.SecretSources, &out.SecretSources
+		*out = make([]SecretSourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -174,9 +427,21 @@ func (in *APIManagerStatus) DeepCopyInto(out *APIManagerStatus) {
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]APIManagerCondition, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.Deployments.DeepCopyInto(&out.Deployments)
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(APIManagerUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialRotation != nil {
+		in, out := &in.CredentialRotation, &out.CredentialRotation
+		*out = new(CredentialRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -190,6 +455,27 @@ func (in *APIManagerStatus) DeepCopy() *APIManagerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerUpgradeStatus) DeepCopyInto(out *APIManagerUpgradeStatus) {
+	*out = *in
+	if in.CompletedSteps != nil {
+		in, out := &in.CompletedSteps, &out.CompletedSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerUpgradeStatus.
+func (in *APIManagerUpgradeStatus) DeepCopy() *APIManagerUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApicastProductionSpec) DeepCopyInto(out *ApicastProductionSpec) {
 	*out = *in
@@ -198,6 +484,17 @@ func (in *ApicastProductionSpec) DeepCopyInto(out *ApicastProductionSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -270,6 +567,12 @@ func (in *ApicastStagingSpec) DeepCopyInto(out *ApicastStagingSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -283,6 +586,37 @@ func (in *ApicastStagingSpec) DeepCopy() *ApicastStagingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendCronSpec) DeepCopyInto(out *BackendCronSpec) {
 	*out = *in
@@ -291,6 +625,12 @@ func (in *BackendCronSpec) DeepCopyInto(out *BackendCronSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -312,6 +652,17 @@ func (in *BackendListenerSpec) DeepCopyInto(out *BackendListenerSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -353,6 +704,21 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 		*out = new(BackendCronSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RedisPersistentVolumeClaimSpec != nil {
+		in, out := &in.RedisPersistentVolumeClaimSpec, &out.RedisPersistentVolumeClaimSpec
+		*out = new(PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedisResources != nil {
+		in, out := &in.RedisResources, &out.RedisResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedisPodPlacement != nil {
+		in, out := &in.RedisPodPlacement, &out.RedisPodPlacement
+		*out = new(PodPlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -374,6 +740,12 @@ func (in *BackendWorkerSpec) DeepCopyInto(out *BackendWorkerSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -387,6 +759,43 @@ func (in *BackendWorkerSpec) DeepCopy() *BackendWorkerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationSpec) DeepCopyInto(out *CredentialRotationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationSpec.
+func (in *CredentialRotationSpec) DeepCopy() *CredentialRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationStatus) DeepCopyInto(out *CredentialRotationStatus) {
+	*out = *in
+	out.Applied = in.Applied
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationStatus.
+func (in *CredentialRotationStatus) DeepCopy() *CredentialRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeprecatedSystemS3Spec) DeepCopyInto(out *DeprecatedSystemS3Spec) {
 	*out = *in
@@ -404,6 +813,114 @@ func (in *DeprecatedSystemS3Spec) DeepCopy() *DeprecatedSystemS3Spec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointExposureSpec) DeepCopyInto(out *EndpointExposureSpec) {
+	*out = *in
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdditionalHosts != nil {
+		in, out := &in.AdditionalHosts, &out.AdditionalHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(EndpointTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointExposureSpec.
+func (in *EndpointExposureSpec) DeepCopy() *EndpointExposureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointExposureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointTLSSpec) DeepCopyInto(out *EndpointTLSSpec) {
+	*out = *in
+	if in.CertificateSecretRef != nil {
+		in, out := &in.CertificateSecretRef, &out.CertificateSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.DestinationCACertificateSecretRef != nil {
+		in, out := &in.DestinationCACertificateSecretRef, &out.DestinationCACertificateSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointTLSSpec.
+func (in *EndpointTLSSpec) DeepCopy() *EndpointTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposureSpec) DeepCopyInto(out *ExposureSpec) {
+	*out = *in
+	if in.IngressClass != nil {
+		in, out := &in.IngressClass, &out.IngressClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.Master != nil {
+		in, out := &in.Master, &out.Master
+		*out = new(EndpointExposureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(EndpointExposureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Developer != nil {
+		in, out := &in.Developer, &out.Developer
+		*out = new(EndpointExposureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApicastStaging != nil {
+		in, out := &in.ApicastStaging, &out.ApicastStaging
+		*out = new(EndpointExposureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApicastProduction != nil {
+		in, out := &in.ApicastProduction, &out.ApicastProduction
+		*out = new(EndpointExposureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(EndpointExposureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposureSpec.
+func (in *ExposureSpec) DeepCopy() *ExposureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HighAvailabilitySpec) DeepCopyInto(out *HighAvailabilitySpec) {
 	*out = *in
@@ -420,6 +937,73 @@ func (in *HighAvailabilitySpec) DeepCopy() *HighAvailabilitySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimBackupSpec) DeepCopyInto(out *PersistentVolumeClaimBackupSpec) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.VolumeName != nil {
+		in, out := &in.VolumeName, &out.VolumeName
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageRequest != nil {
+		in, out := &in.StorageRequest, &out.StorageRequest
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimBackupSpec.
+func (in *PersistentVolumeClaimBackupSpec) DeepCopy() *PersistentVolumeClaimBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimSpec) DeepCopyInto(out *PersistentVolumeClaimSpec) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageRequest != nil {
+		in, out := &in.StorageRequest, &out.StorageRequest
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimSpec.
+func (in *PersistentVolumeClaimSpec) DeepCopy() *PersistentVolumeClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
 	*out = *in
@@ -436,6 +1020,96 @@ func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodPlacementSpec) DeepCopyInto(out *PodPlacementSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPlacementSpec.
+func (in *PodPlacementSpec) DeepCopy() *PodPlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodPlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretSourceSpec) DeepCopyInto(out *SecretSourceSpec) {
+	*out = *in
+	if in.SecretName != nil {
+		in, out := &in.SecretName, &out.SecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CSI != nil {
+		in, out := &in.CSI, &out.CSI
+		*out = new(v1.CSIVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretSourceSpec.
+func (in *SecretSourceSpec) DeepCopy() *SecretSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimpleStorageServiceBackupSpec) DeepCopyInto(out *SimpleStorageServiceBackupSpec) {
+	*out = *in
+	out.ConfigurationSecretRef = in.ConfigurationSecretRef
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimpleStorageServiceBackupSpec.
+func (in *SimpleStorageServiceBackupSpec) DeepCopy() *SimpleStorageServiceBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SimpleStorageServiceBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemAppSpec) DeepCopyInto(out *SystemAppSpec) {
 	*out = *in
@@ -444,6 +1118,22 @@ func (in *SystemAppSpec) DeepCopyInto(out *SystemAppSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.MasterContainerResources != nil {
+		in, out := &in.MasterContainerResources, &out.MasterContainerResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderContainerResources != nil {
+		in, out := &in.ProviderContainerResources, &out.ProviderContainerResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeveloperContainerResources != nil {
+		in, out := &in.DeveloperContainerResources, &out.DeveloperContainerResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -522,6 +1212,17 @@ func (in *SystemMySQLSpec) DeepCopyInto(out *SystemMySQLSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PersistentVolumeClaimSpec != nil {
+		in, out := &in.PersistentVolumeClaimSpec, &out.PersistentVolumeClaimSpec
+		*out = new(PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -543,6 +1244,21 @@ func (in *SystemPVCSpec) DeepCopyInto(out *SystemPVCSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.StorageRequest != nil {
+		in, out := &in.StorageRequest, &out.StorageRequest
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -564,6 +1280,17 @@ func (in *SystemPostgreSQLSpec) DeepCopyInto(out *SystemPostgreSQLSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PersistentVolumeClaimSpec != nil {
+		in, out := &in.PersistentVolumeClaimSpec, &out.PersistentVolumeClaimSpec
+		*out = new(PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -602,6 +1329,12 @@ func (in *SystemSidekiqSpec) DeepCopyInto(out *SystemSidekiqSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -633,6 +1366,41 @@ func (in *SystemSpec) DeepCopyInto(out *SystemSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RedisPersistentVolumeClaimSpec != nil {
+		in, out := &in.RedisPersistentVolumeClaimSpec, &out.RedisPersistentVolumeClaimSpec
+		*out = new(PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedisResources != nil {
+		in, out := &in.RedisResources, &out.RedisResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedisPodPlacement != nil {
+		in, out := &in.RedisPodPlacement, &out.RedisPodPlacement
+		*out = new(PodPlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemcachedResources != nil {
+		in, out := &in.MemcachedResources, &out.MemcachedResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemcachedPodPlacement != nil {
+		in, out := &in.MemcachedPodPlacement, &out.MemcachedPodPlacement
+		*out = new(PodPlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SphinxResources != nil {
+		in, out := &in.SphinxResources, &out.SphinxResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SphinxPodPlacement != nil {
+		in, out := &in.SphinxPodPlacement, &out.SphinxPodPlacement
+		*out = new(PodPlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FileStorageSpec != nil {
 		in, out := &in.FileStorageSpec, &out.FileStorageSpec
 		*out = new(SystemFileStorageSpec)
@@ -674,6 +1442,12 @@ func (in *ZyncAppSpec) DeepCopyInto(out *ZyncAppSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -695,6 +1469,12 @@ func (in *ZyncQueSpec) DeepCopyInto(out *ZyncQueSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodPlacementSpec.DeepCopyInto(&out.PodPlacementSpec)
 	return
 }
 
@@ -721,6 +1501,21 @@ func (in *ZyncSpec) DeepCopyInto(out *ZyncSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DatabasePersistentVolumeClaimSpec != nil {
+		in, out := &in.DatabasePersistentVolumeClaimSpec, &out.DatabasePersistentVolumeClaimSpec
+		*out = new(PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DatabaseResources != nil {
+		in, out := &in.DatabaseResources, &out.DatabaseResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DatabasePodPlacement != nil {
+		in, out := &in.DatabasePodPlacement, &out.DatabasePodPlacement
+		*out = new(PodPlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AppSpec != nil {
 		in, out := &in.AppSpec, &out.AppSpec
 		*out = new(ZyncAppSpec)
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -25,6 +26,19 @@ import (
 type APISpec struct {
 	APIBase      `json:",inline"`
 	APISelectors `json:",inline"`
+	// OpenAPI document the metrics and mapping rules of the API are derived from,
+	// on top of the ones selected by label
+	// +optional
+	OpenAPI *OpenAPISpec `json:"openapi,omitempty"`
+	// What happens to the 3scale service when the API is no longer selected by
+	// the Binding or the Binding is deleted. Defaults to the Binding deletionPolicy
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// When set, an existing 3scale service with the same system name is adopted
+	// by the Binding and managed from then on. Otherwise, existing services not
+	// created by the Binding are left untouched
+	// +optional
+	Adopt bool `json:"adopt,omitempty"`
 }
 
 type APIBase struct {
@@ -93,17 +107,33 @@ func (api *API) getIntegrationMethodType() string {
 	}
 	return ""
 }
-func (api API) getInternalAPIfrom3scale(c *portaClient.ThreeScaleClient) (*InternalAPI, error) {
+
+// getInternalAPIfrom3scale reads the service of the API from 3scale. The IDs of the
+// 3scale objects are returned apart, they are not part of the API definition
+func (api API) getInternalAPIfrom3scale(c *portaClient.ThreeScaleClient) (*InternalAPI, *InternalAPIIDs, error) {
 
 	service, err := getServiceFromInternalAPI(c, api.Name)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	proxyConfig, err := c.ReadProxy(service.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	applicationPlans, err := c.ListAppPlanByServiceId(service.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	policies, err := newInternalPoliciesFrom3scale(proxyConfig.PoliciesConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := InternalAPIIDs{
+		ServiceID: service.ID,
+		Metrics:   map[string]string{},
+		Plans:     map[string]string{},
+	}
 
 	// Initialize the InternalAPI with whatever info we have.
 	internalAPI := InternalAPI{
@@ -194,6 +224,7 @@ func (api API) getInternalAPIfrom3scale(c *portaClient.ThreeScaleClient) (*Inter
 				StagingPublicBaseURL:    proxyConfig.SandboxEndpoint,
 				ProductionPublicBaseURL: proxyConfig.Endpoint,
 				MappingRules:            *mappingRules,
+				Policies:                policies,
 			},
 		}
 
@@ -217,6 +248,7 @@ func (api API) getInternalAPIfrom3scale(c *portaClient.ThreeScaleClient) (*Inter
 					},
 				},
 				MappingRules: *mappingRules,
+				Policies:     policies,
 			},
 		}
 
@@ -231,11 +263,12 @@ func (api API) getInternalAPIfrom3scale(c *portaClient.ThreeScaleClient) (*Inter
 		}
 
 	default:
-		return nil, fmt.Errorf("invalid_deployment")
+		return nil, nil, fmt.Errorf("invalid_deployment")
 	}
 
 	// Grab the metrics from 3scale.
 	for _, metric := range service.Metrics.Metrics {
+		ids.Metrics[metric.FriendlyName] = metric.ID
 		internalMetric := InternalMetric{
 			Name:        metric.FriendlyName,
 			Unit:        metric.Unit,
@@ -253,6 +286,7 @@ func (api API) getInternalAPIfrom3scale(c *portaClient.ThreeScaleClient) (*Inter
 		setupFee := applicationPlan.SetupFee
 		costMonth := applicationPlan.CostPerMonth
 
+		ids.Plans[applicationPlan.PlanName] = applicationPlan.ID
 		internalPlan := InternalPlan{
 			Name:             applicationPlan.PlanName,
 			TrialPeriodDays:  trialPeriodDays,
@@ -281,7 +315,7 @@ func (api API) getInternalAPIfrom3scale(c *portaClient.ThreeScaleClient) (*Inter
 		internalAPI.Plans = append(internalAPI.Plans, internalPlan)
 	}
 
-	return &internalAPI, nil
+	return &internalAPI, &ids, nil
 }
 
 func (api API) GetInternalAPI(c client.Client) (*InternalAPI, error) {
@@ -342,7 +376,6 @@ func (api API) GetInternalAPI(c client.Client) (*InternalAPI, error) {
 		}
 		internalAPI.IntegrationMethod.ApicastOnPrem = internalApicastOnPrem
 
-		// TODO: Policies
 	case "CodePlugin":
 		internalCodePlugin := InternalCodePlugin{
 			AuthenticationSettings: CodePluginAuthenticationSettings{
@@ -353,6 +386,13 @@ func (api API) GetInternalAPI(c client.Client) (*InternalAPI, error) {
 	default:
 		return nil, fmt.Errorf("Not supported integration method")
 	}
+
+	if api.Spec.OpenAPI != nil {
+		err := internalAPI.addOpenAPIObjects(api.Spec.OpenAPI, api.Namespace, c)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &internalAPI, nil
 }
 
@@ -363,6 +403,7 @@ type ApicastHosted struct {
 type InternalApicastHosted struct {
 	APIcastBaseOptions
 	MappingRules []InternalMappingRule `json:"mappingRules"`
+	Policies     []InternalPolicy      `json:"policies,omitempty"`
 }
 
 func (i *InternalApicastHosted) GetCredentialTypeName() string {
@@ -378,6 +419,9 @@ func (i *InternalApicastHosted) GetCredentialTypeName() string {
 func (i *InternalApicastHosted) GetMappingRules() []InternalMappingRule {
 	return i.MappingRules
 }
+func (i *InternalApicastHosted) GetPolicies() []InternalPolicy {
+	return i.Policies
+}
 
 type APIcastBaseOptions struct {
 	PrivateBaseURL         string                        `json:"privateBaseURL"`
@@ -403,6 +447,7 @@ type InternalApicastOnPrem struct {
 	StagingPublicBaseURL    string                `json:"stagingPublicBaseURL"`
 	ProductionPublicBaseURL string                `json:"productionPublicBaseURL"`
 	MappingRules            []InternalMappingRule `json:"mappingRules"`
+	Policies                []InternalPolicy      `json:"policies,omitempty"`
 }
 
 func (i *InternalApicastOnPrem) GetCredentialTypeName() string {
@@ -420,6 +465,9 @@ func (i *InternalApicastOnPrem) GetCredentialTypeName() string {
 func (i *InternalApicastOnPrem) GetMappingRules() []InternalMappingRule {
 	return i.MappingRules
 }
+func (i *InternalApicastOnPrem) GetPolicies() []InternalPolicy {
+	return i.Policies
+}
 
 type ApicastAuthenticationSettings struct {
 	HostHeader  string                 `json:"hostHeader"`
@@ -490,6 +538,9 @@ func (i *InternalCodePlugin) GetCredentialTypeName() string {
 func (i *InternalCodePlugin) GetMappingRules() []InternalMappingRule {
 	return []InternalMappingRule{}
 }
+func (i *InternalCodePlugin) GetPolicies() []InternalPolicy {
+	return nil
+}
 
 type CodePluginAuthenticationSettings struct {
 	Credentials IntegrationCredentials `json:"credentials"`
@@ -507,6 +558,14 @@ var IntegrationMethodToDeploymentType = map[string]string{
 	"CodePlugin":    "plugin_rest",
 }
 
+// InternalAPIIDs are the IDs of the 3scale objects of an API
+type InternalAPIIDs struct {
+	ServiceID string
+	// IDs of the metrics and plans, indexed by name
+	Metrics map[string]string
+	Plans   map[string]string
+}
+
 type InternalAPI struct {
 	Name            string `json:"name"`
 	APIBaseInternal `json:",omitempty"`
@@ -583,6 +642,24 @@ func (api InternalAPI) getIntegration() Integration {
 	return nil
 }
 
+// getMappingRules returns the mapping rules of the integration method, if any
+func (api InternalAPI) getMappingRules() []InternalMappingRule {
+	integration := api.getIntegration()
+	if integration == nil {
+		return nil
+	}
+	return integration.GetMappingRules()
+}
+
+// getPolicies returns the policy chain of the integration method, if any
+func (api InternalAPI) getPolicies() []InternalPolicy {
+	integration := api.getIntegration()
+	if integration == nil {
+		return nil
+	}
+	return integration.GetPolicies()
+}
+
 // createIn3scale Creates the InternalAPI in 3scale
 func (api InternalAPI) createIn3scale(c *portaClient.ThreeScaleClient) error {
 
@@ -731,6 +808,7 @@ type InternalIntegration struct {
 
 type Integration interface {
 	GetMappingRules() []InternalMappingRule
+	GetPolicies() []InternalPolicy
 	GetCredentialTypeName() string
 }
 
@@ -752,126 +830,200 @@ type APIPair struct {
 	B InternalAPI
 }
 
-// reconcileWith3scale creates/modifies/deletes APIs based on the information of the APIsDiff object.
-func (d *APIsDiff) ReconcileWith3scale(creds InternalCredentials) error {
+// PlannedChanges describes the changes ReconcileWith3scale would make in 3scale, without calling it
+func (d *APIsDiff) PlannedChanges() []string {
+	changes := []string{}
 
-	c, err := helper.PortaClientFromURLString(creds.AdminURL, creds.AuthToken)
+	for _, api := range d.MissingFromB {
+		changes = append(changes, fmt.Sprintf("create API %s", api.Name))
+		// The API is created with all its objects
+		changes = append(changes, apiPlannedChanges(APIPair{A: api})...)
+	}
 
-	if err != nil {
-		return err
+	for _, api := range d.MissingFromA {
+		changes = append(changes, fmt.Sprintf("delete API %s", api.Name))
 	}
 
+	for _, apiPair := range d.NotEqual {
+		updatedFields := []string{}
+		if IntegrationMethodToDeploymentType[apiPair.A.getIntegrationName()] != IntegrationMethodToDeploymentType[apiPair.B.getIntegrationName()] {
+			updatedFields = append(updatedFields, "integration method")
+		}
+		if CredentialTypeToBackendVersion[apiPair.A.getIntegration().GetCredentialTypeName()] != CredentialTypeToBackendVersion[apiPair.B.getIntegration().GetCredentialTypeName()] {
+			updatedFields = append(updatedFields, "credentials")
+		}
+		if apiPair.A.Description != apiPair.B.Description {
+			updatedFields = append(updatedFields, "description")
+		}
+		desiredProxy, desiredErr := get3scaleProxyFromInternalAPI(apiPair.A)
+		existingProxy, existingErr := get3scaleProxyFromInternalAPI(apiPair.B)
+		if desiredErr != nil || existingErr != nil || desiredProxy != existingProxy {
+			updatedFields = append(updatedFields, "proxy")
+		}
+		if len(updatedFields) > 0 {
+			changes = append(changes, fmt.Sprintf("update API %s: %s", apiPair.A.Name, strings.Join(updatedFields, ", ")))
+		}
+		changes = append(changes, apiPlannedChanges(apiPair)...)
+	}
+
+	return changes
+}
+
+// apiPlannedChanges describes the changes of the metrics, mapping rules and
+// plans needed to turn the API B into the API A
+func apiPlannedChanges(apiPair APIPair) []string {
+	changes := []string{}
+
+	metricsDiff := diffMetrics(apiPair.A.Metrics, apiPair.B.Metrics)
+	for _, change := range metricsDiff.plannedChanges() {
+		changes = append(changes, fmt.Sprintf("API %s: %s", apiPair.A.Name, change))
+	}
+
+	mappingRulesDiff := diffMappingRules(apiPair.A.getMappingRules(), apiPair.B.getMappingRules())
+	for _, change := range mappingRulesDiff.plannedChanges() {
+		changes = append(changes, fmt.Sprintf("API %s: %s", apiPair.A.Name, change))
+	}
+
+	policiesDiff := diffPolicies(apiPair.A.getPolicies(), apiPair.B.getPolicies())
+	for _, change := range policiesDiff.plannedChanges() {
+		changes = append(changes, fmt.Sprintf("API %s: %s", apiPair.A.Name, change))
+	}
+
+	plansDiff := diffPlans(apiPair.A.Plans, apiPair.B.Plans)
+	for _, change := range plansDiff.plannedChanges() {
+		changes = append(changes, fmt.Sprintf("API %s: %s", apiPair.A.Name, change))
+	}
+
+	return changes
+}
+
+// reconcileWith3scale creates/modifies/deletes APIs based on the information of the APIsDiff object.
+// An API that fails does not stop the reconciliation of the others. The errors are returned
+// indexed by API name
+func (d *APIsDiff) ReconcileWith3scale(c *portaClient.ThreeScaleClient) map[string]error {
+
+	apiErrors := map[string]error{}
+
 	for _, api := range d.MissingFromB {
 
 		err := api.createIn3scale(c)
 		if err != nil {
-			return err
+			apiErrors[api.Name] = err
 		}
 	}
 
 	for _, api := range d.MissingFromA {
 		err := api.DeleteFrom3scale(c)
 		if err != nil {
-			return err
+			apiErrors[api.Name] = err
 		}
 	}
 
 	for _, apiPair := range d.NotEqual {
-
-		serviceNeedsUpdate := false
-		service, err := getServiceFromInternalAPI(c, apiPair.A.Name)
+		err := reconcileAPIPairWith3scale(c, apiPair)
 		if err != nil {
-			return err
+			apiErrors[apiPair.A.Name] = err
 		}
-		serviceParams := portaClient.Params{}
+	}
+	return apiErrors
 
-		// Check if DeploymentOption is correct
-		desiredDeploymentOption := IntegrationMethodToDeploymentType[apiPair.A.getIntegrationName()]
-		existingDeploymentOption := IntegrationMethodToDeploymentType[apiPair.B.getIntegrationName()]
+}
 
-		if desiredDeploymentOption != existingDeploymentOption {
-			serviceNeedsUpdate = true
-			serviceParams.AddParam("deployment_option", desiredDeploymentOption)
-		}
+// reconcileAPIPairWith3scale updates the 3scale API B to match the desired API A
+func reconcileAPIPairWith3scale(c *portaClient.ThreeScaleClient, apiPair APIPair) error {
 
-		// Check if BackendVersion is correct
-		desiredBackendVersion := CredentialTypeToBackendVersion[apiPair.A.getIntegration().GetCredentialTypeName()]
-		existingBackendVersion := CredentialTypeToBackendVersion[apiPair.B.getIntegration().GetCredentialTypeName()]
+	serviceNeedsUpdate := false
+	service, err := getServiceFromInternalAPI(c, apiPair.A.Name)
+	if err != nil {
+		return err
+	}
+	serviceParams := portaClient.Params{}
 
-		if desiredBackendVersion != existingBackendVersion {
-			serviceNeedsUpdate = true
-			serviceParams.AddParam("backend_version", desiredBackendVersion)
-		}
+	// Check if DeploymentOption is correct
+	desiredDeploymentOption := IntegrationMethodToDeploymentType[apiPair.A.getIntegrationName()]
+	existingDeploymentOption := IntegrationMethodToDeploymentType[apiPair.B.getIntegrationName()]
 
-		//Check if api description is different and mark it for update
-		if apiPair.A.Description != apiPair.B.Description {
-			serviceNeedsUpdate = true
-			serviceParams.AddParam("description", apiPair.A.Description)
-		}
+	if desiredDeploymentOption != existingDeploymentOption {
+		serviceNeedsUpdate = true
+		serviceParams.AddParam("deployment_option", desiredDeploymentOption)
+	}
 
-		// Update the service with the params
-		if serviceNeedsUpdate {
-			_, err := c.UpdateService(service.ID, serviceParams)
-			if err != nil {
-				return err
-			}
-		}
+	// Check if BackendVersion is correct
+	desiredBackendVersion := CredentialTypeToBackendVersion[apiPair.A.getIntegration().GetCredentialTypeName()]
+	existingBackendVersion := CredentialTypeToBackendVersion[apiPair.B.getIntegration().GetCredentialTypeName()]
 
-		desiredProxy, err := get3scaleProxyFromInternalAPI(apiPair.A)
-		if err != nil {
-			return err
-		}
-		existingProxy, err := get3scaleProxyFromInternalAPI(apiPair.B)
+	if desiredBackendVersion != existingBackendVersion {
+		serviceNeedsUpdate = true
+		serviceParams.AddParam("backend_version", desiredBackendVersion)
+	}
+
+	//Check if api description is different and mark it for update
+	if apiPair.A.Description != apiPair.B.Description {
+		serviceNeedsUpdate = true
+		serviceParams.AddParam("description", apiPair.A.Description)
+	}
+
+	// Update the service with the params
+	if serviceNeedsUpdate {
+		_, err := c.UpdateService(service.ID, serviceParams)
 		if err != nil {
 			return err
 		}
+	}
 
-		if desiredProxy != existingProxy {
+	desiredProxy, err := get3scaleProxyFromInternalAPI(apiPair.A)
+	if err != nil {
+		return err
+	}
+	existingProxy, err := get3scaleProxyFromInternalAPI(apiPair.B)
+	if err != nil {
+		return err
+	}
 
-			proxyParams := getProxyParamsFromProxy(desiredProxy, desiredDeploymentOption, desiredBackendVersion)
+	if desiredProxy != existingProxy {
 
-			_, err = c.UpdateProxy(service.ID, proxyParams)
-			if err != nil {
-				return err
-			}
-		}
+		proxyParams := getProxyParamsFromProxy(desiredProxy, desiredDeploymentOption, desiredBackendVersion)
 
-		// Get the Difference in Metrics for the API
-		metricsDiff := diffMetrics(apiPair.A.Metrics, apiPair.B.Metrics)
-		err = metricsDiff.ReconcileWith3scale(c, service.ID, apiPair.A)
+		_, err = c.UpdateProxy(service.ID, proxyParams)
 		if err != nil {
 			return err
 		}
+	}
 
-		// reconcileWith3scale Mapping Rules
-		mappingRulesDiff := diffMappingRules(apiPair.A.getIntegration().GetMappingRules(), apiPair.B.getIntegration().GetMappingRules())
-		err = mappingRulesDiff.reconcileWith3scale(c, service.ID, apiPair.A)
-		if err != nil {
-			return err
-		}
+	// Get the Difference in Metrics for the API
+	metricsDiff := diffMetrics(apiPair.A.Metrics, apiPair.B.Metrics)
+	err = metricsDiff.ReconcileWith3scale(c, service.ID, apiPair.A)
+	if err != nil {
+		return err
+	}
 
-		// Because MappingRules are not Unique, let's remove duplicated mappingRules
+	// reconcileWith3scale Mapping Rules
+	mappingRulesDiff := diffMappingRules(apiPair.A.getIntegration().GetMappingRules(), apiPair.B.getIntegration().GetMappingRules())
+	err = mappingRulesDiff.reconcileWith3scale(c, service.ID, apiPair.A)
+	if err != nil {
+		return err
+	}
 
-		// reconcileWith3scale Plans
-		plansDiff := diffPlans(apiPair.A.Plans, apiPair.B.Plans)
-		err = plansDiff.reconcileWith3scale(c, service.ID, apiPair.A)
+	// Because MappingRules are not Unique, let's remove duplicated mappingRules
+
+	// reconcileWith3scale Plans
+	plansDiff := diffPlans(apiPair.A.Plans, apiPair.B.Plans)
+	err = plansDiff.reconcileWith3scale(c, service.ID, apiPair.A)
+	if err != nil {
+		return err
+	}
+
+	// Promote config if needed
+	productionProxy, _ := c.GetLatestProxyConfig(service.ID, "production")
+	sandboxProxy, _ := c.GetLatestProxyConfig(service.ID, "sandbox")
+	if productionProxy.ProxyConfig.Version != sandboxProxy.ProxyConfig.Version {
+		_, err := c.PromoteProxyConfig(service.ID, "sandbox", strconv.Itoa(sandboxProxy.ProxyConfig.Version), "production")
 		if err != nil {
 			return err
 		}
-
-		// Promote config if needed
-		productionProxy, _ := c.GetLatestProxyConfig(service.ID, "production")
-		sandboxProxy, _ := c.GetLatestProxyConfig(service.ID, "sandbox")
-		if productionProxy.ProxyConfig.Version != sandboxProxy.ProxyConfig.Version {
-			_, err := c.PromoteProxyConfig(service.ID, "sandbox", strconv.Itoa(sandboxProxy.ProxyConfig.Version), "production")
-			if err != nil {
-				return err
-			}
-		}
-
 	}
-	return nil
 
+	return nil
 }
 
 // DiffAPIs generate an APIsDiff object with equal, different and missing APIs from two InternalAPI slices.
@@ -950,6 +1102,12 @@ func newInternalApicastHostedFromApicastHosted(namespace string, hosted ApicastH
 			}
 		}
 	}
+	// Get Policies
+	policies, err := newInternalPoliciesFromSelector(namespace, hosted.PoliciesSelector, c)
+	if err != nil {
+		return nil, err
+	}
+	internalApicastHosted.Policies = policies
 	return &internalApicastHosted, nil
 }
 
@@ -989,57 +1147,67 @@ func newInternalApicastOnPremFromApicastOnPrem(namespace string, prem ApicastOnP
 			}
 		}
 	}
+	// Get Policies
+	policies, err := newInternalPoliciesFromSelector(namespace, prem.PoliciesSelector, c)
+	if err != nil {
+		return nil, err
+	}
+	internalApicastOnPrem.Policies = policies
 
 	return &internalApicastOnPrem, nil
 }
 
 // CompareInternalAPI Compares two InternalAPIs and return true or false.
 func CompareInternalAPI(APIA, APIB InternalAPI) bool {
-	for i := range APIA.Plans {
-		for j := range APIA.Plans[i].Limits {
-			APIA.Plans[i].Limits[j].Name = "limit"
-		}
-	}
+	APIA.normalize()
+	APIB.normalize()
 
-	for i := range APIB.Plans {
-		for j := range APIB.Plans[i].Limits {
-			APIB.Plans[i].Limits[j].Name = "limit"
+	A, _ := json.Marshal(APIA)
+	B, _ := json.Marshal(APIB)
+
+	return reflect.DeepEqual(A, B)
+}
+
+// normalize clears the differences between the APIs defined by the objects and the ones
+// read from 3scale that are not relevant: the names of the limits and mapping rules and
+// the default ports of the public URLs
+func (api *InternalAPI) normalize() {
+	for i := range api.Plans {
+		for j := range api.Plans[i].Limits {
+			api.Plans[i].Limits[j].Name = "limit"
 		}
 	}
 
-	if APIA.getIntegrationName() != APIB.getIntegrationName() {
-		return false
-	} else {
-		switch APIA.getIntegrationName() {
-		case "ApicastOnPrem":
-
-			// Always set he port number, because porta adds it automatically and makes the sync fail.
-			APIA.IntegrationMethod.ApicastOnPrem.ProductionPublicBaseURL = helper.SetURLDefaultPort(APIA.IntegrationMethod.ApicastOnPrem.ProductionPublicBaseURL)
-			APIA.IntegrationMethod.ApicastOnPrem.StagingPublicBaseURL = helper.SetURLDefaultPort(APIA.IntegrationMethod.ApicastOnPrem.StagingPublicBaseURL)
+	switch api.getIntegrationName() {
+	case "ApicastOnPrem":
 
-			APIB.IntegrationMethod.ApicastOnPrem.ProductionPublicBaseURL = helper.SetURLDefaultPort(APIB.IntegrationMethod.ApicastOnPrem.ProductionPublicBaseURL)
-			APIB.IntegrationMethod.ApicastOnPrem.StagingPublicBaseURL = helper.SetURLDefaultPort(APIB.IntegrationMethod.ApicastOnPrem.StagingPublicBaseURL)
+		// Always set he port number, because porta adds it automatically and makes the sync fail.
+		api.IntegrationMethod.ApicastOnPrem.ProductionPublicBaseURL = helper.SetURLDefaultPort(api.IntegrationMethod.ApicastOnPrem.ProductionPublicBaseURL)
+		api.IntegrationMethod.ApicastOnPrem.StagingPublicBaseURL = helper.SetURLDefaultPort(api.IntegrationMethod.ApicastOnPrem.StagingPublicBaseURL)
 
-			for i := range APIA.IntegrationMethod.ApicastOnPrem.MappingRules {
-				APIA.IntegrationMethod.ApicastOnPrem.MappingRules[i].Name = "mapping_rule"
-			}
-			for i := range APIB.IntegrationMethod.ApicastOnPrem.MappingRules {
-				APIB.IntegrationMethod.ApicastOnPrem.MappingRules[i].Name = "mapping_rule"
-			}
-		case "ApicastHosted":
-			for i := range APIA.IntegrationMethod.ApicastHosted.MappingRules {
-				APIA.IntegrationMethod.ApicastHosted.MappingRules[i].Name = "mapping_rule"
-			}
-			for i := range APIB.IntegrationMethod.ApicastHosted.MappingRules {
-				APIB.IntegrationMethod.ApicastHosted.MappingRules[i].Name = "mapping_rule"
-			}
+		for i := range api.IntegrationMethod.ApicastOnPrem.MappingRules {
+			api.IntegrationMethod.ApicastOnPrem.MappingRules[i].Name = "mapping_rule"
+		}
+	case "ApicastHosted":
+		for i := range api.IntegrationMethod.ApicastHosted.MappingRules {
+			api.IntegrationMethod.ApicastHosted.MappingRules[i].Name = "mapping_rule"
 		}
 	}
+}
 
-	A, _ := json.Marshal(APIA)
-	B, _ := json.Marshal(APIB)
+// Hash returns a hash of the API definition. Two APIs have the same hash when
+// they are equal for CompareStates
+func (api InternalAPI) Hash() string {
+	// Work on a copy, normalize and sort modify the API
+	var apiCopy InternalAPI
+	data, _ := json.Marshal(api)
+	_ = json.Unmarshal(data, &apiCopy)
 
-	return reflect.DeepEqual(A, B)
+	apiCopy.sort()
+	apiCopy.normalize()
+
+	data, _ = json.Marshal(apiCopy)
+	return fmt.Sprintf("%x", sha256.Sum256(data))
 }
 
 func get3scaleProxyFromInternalAPI(api InternalAPI) (portaClient.Proxy, error) {
@@ -1060,6 +1228,11 @@ func get3scaleProxyFromInternalAPI(api InternalAPI) (portaClient.Proxy, error) {
 		proxy.ErrorStatusAuthMissing = strconv.FormatInt(integration.ApicastHosted.AuthenticationSettings.Errors.AuthenticationMissing.ResponseCode, 10)
 		proxy.ErrorHeadersAuthMissing = integration.ApicastHosted.AuthenticationSettings.Errors.AuthenticationMissing.ContentType
 		proxy.SecretToken = integration.ApicastHosted.AuthenticationSettings.SecretToken
+		policiesConfig, err := get3scalePoliciesConfig(integration.ApicastHosted.Policies)
+		if err != nil {
+			return proxy, err
+		}
+		proxy.PoliciesConfig = policiesConfig
 		if integration.ApicastHosted.AuthenticationSettings.Credentials.OpenIDConnector != nil {
 			proxy.CredentialsLocation = integration.ApicastHosted.AuthenticationSettings.Credentials.OpenIDConnector.CredentialsLocation
 			proxy.OidcIssuerEndpoint = integration.ApicastHosted.AuthenticationSettings.Credentials.OpenIDConnector.Issuer
@@ -1087,6 +1260,11 @@ func get3scaleProxyFromInternalAPI(api InternalAPI) (portaClient.Proxy, error) {
 		proxy.ErrorStatusAuthMissing = strconv.FormatInt(integration.ApicastOnPrem.AuthenticationSettings.Errors.AuthenticationMissing.ResponseCode, 10)
 		proxy.ErrorHeadersAuthMissing = integration.ApicastOnPrem.AuthenticationSettings.Errors.AuthenticationMissing.ContentType
 		proxy.SecretToken = integration.ApicastOnPrem.AuthenticationSettings.SecretToken
+		policiesConfig, err := get3scalePoliciesConfig(integration.ApicastOnPrem.Policies)
+		if err != nil {
+			return proxy, err
+		}
+		proxy.PoliciesConfig = policiesConfig
 
 		if integration.ApicastOnPrem.AuthenticationSettings.Credentials.OpenIDConnector != nil {
 			proxy.CredentialsLocation = integration.ApicastOnPrem.AuthenticationSettings.Credentials.OpenIDConnector.CredentialsLocation
@@ -1154,6 +1332,7 @@ func getProxyParamsFromProxy(proxy portaClient.Proxy, deploymentOption, backendV
 		proxyParams.AddParam("error_status_auth_missing", proxy.ErrorStatusAuthMissing)
 		proxyParams.AddParam("error_headers_auth_missing", proxy.ErrorHeadersAuthMissing)
 		proxyParams.AddParam("secret_token", proxy.SecretToken)
+		proxyParams.AddParam("policies_config", proxy.PoliciesConfig)
 	case "self_managed":
 		proxyParams.AddParam("hostname_rewrite", proxy.HostnameRewrite)
 		proxyParams.AddParam("api_backend", proxy.ApiBackend)
@@ -1168,6 +1347,7 @@ func getProxyParamsFromProxy(proxy portaClient.Proxy, deploymentOption, backendV
 		proxyParams.AddParam("error_status_auth_missing", proxy.ErrorStatusAuthMissing)
 		proxyParams.AddParam("error_headers_auth_missing", proxy.ErrorHeadersAuthMissing)
 		proxyParams.AddParam("secret_token", proxy.SecretToken)
+		proxyParams.AddParam("policies_config", proxy.PoliciesConfig)
 
 	case "plugin_rest":
 		// Nothing!
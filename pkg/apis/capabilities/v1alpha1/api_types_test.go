@@ -0,0 +1,88 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func plannedChangesTestAPI(name, description string, metrics []InternalMetric, mappingRules []InternalMappingRule, plans []InternalPlan) InternalAPI {
+	api := InternalAPI{
+		Name:    name,
+		Metrics: metrics,
+		Plans:   plans,
+	}
+	api.Description = description
+	api.IntegrationMethod.ApicastHosted = &InternalApicastHosted{
+		APIcastBaseOptions: APIcastBaseOptions{PrivateBaseURL: "https://echo-api.3scale.net:443"},
+		MappingRules:       mappingRules,
+	}
+	return api
+}
+
+func TestAPIsDiffPlannedChanges(t *testing.T) {
+	hits := InternalMetric{Name: "Hits", Unit: "hit", Description: "Number of API hits"}
+	mappingRule := InternalMappingRule{Name: "get", Path: "/", Method: "get", Increment: 1, Metric: "Hits"}
+	plan := InternalPlan{Name: "basic", Limits: []InternalLimit{{Name: "limit01", Period: "day", MaxValue: 10, Metric: "Hits"}}}
+
+	newAPI := plannedChangesTestAPI("api01", "new", []InternalMetric{hits}, []InternalMappingRule{mappingRule}, []InternalPlan{plan})
+	removedAPI := plannedChangesTestAPI("api02", "removed", nil, nil, nil)
+
+	updatedPlan := plan
+	updatedPlan.Limits = []InternalLimit{{Name: "limit01", Period: "day", MaxValue: 20, Metric: "Hits"}}
+	desiredAPI := plannedChangesTestAPI("api03", "updated", []InternalMetric{hits}, nil, []InternalPlan{updatedPlan})
+	existingAPI := plannedChangesTestAPI("api03", "old", []InternalMetric{hits}, []InternalMappingRule{mappingRule}, []InternalPlan{plan})
+
+	apisDiff := DiffAPIs([]InternalAPI{newAPI, desiredAPI}, []InternalAPI{removedAPI, existingAPI})
+
+	expected := []string{
+		"create API api01",
+		"API api01: create metric Hits",
+		"API api01: create mapping rule GET /, metric Hits increment 1",
+		"API api01: create plan basic",
+		"API api01: plan basic: create limit of metric Hits, 10 per day",
+		"delete API api02",
+		"update API api03: description",
+		"API api03: delete mapping rule GET /, metric Hits increment 1",
+		"API api03: update plan basic",
+		"API api03: plan basic: delete limit of metric Hits, 10 per day",
+		"API api03: plan basic: create limit of metric Hits, 20 per day",
+	}
+	plannedChanges := apisDiff.PlannedChanges()
+	if !reflect.DeepEqual(plannedChanges, expected) {
+		t.Errorf("unexpected planned changes.\ngot:\n%v\nexpected:\n%v", plannedChanges, expected)
+	}
+}
+
+func TestAPIsDiffPlannedChangesInSync(t *testing.T) {
+	api := plannedChangesTestAPI("api01", "same", nil, nil, nil)
+
+	apisDiff := DiffAPIs([]InternalAPI{api}, []InternalAPI{api})
+	if plannedChanges := apisDiff.PlannedChanges(); len(plannedChanges) != 0 {
+		t.Errorf("expected no planned changes, got: %v", plannedChanges)
+	}
+}
+
+func TestInternalAPIHash(t *testing.T) {
+	plan := InternalPlan{Name: "basic", Limits: []InternalLimit{{Name: "limit01", Period: "day", MaxValue: 10, Metric: "Hits"}}}
+	mappingRule := InternalMappingRule{Name: "get", Path: "/", Method: "GET", Increment: 1, Metric: "Hits"}
+	desiredAPI := plannedChangesTestAPI("api01", "same", nil, []InternalMappingRule{mappingRule}, []InternalPlan{plan})
+
+	// 3scale doesn't keep the names of the limits and mapping rules
+	currentPlan := plan
+	currentPlan.Limits = []InternalLimit{{Name: "", Period: "day", MaxValue: 10, Metric: "Hits"}}
+	currentMappingRule := mappingRule
+	currentMappingRule.Name = ""
+	currentAPI := plannedChangesTestAPI("api01", "same", nil, []InternalMappingRule{currentMappingRule}, []InternalPlan{currentPlan})
+
+	if desiredAPI.Hash() != currentAPI.Hash() {
+		t.Error("expected equal hashes")
+	}
+	if desiredAPI.IntegrationMethod.ApicastHosted.MappingRules[0].Name != "get" || desiredAPI.Plans[0].Limits[0].Name != "limit01" {
+		t.Error("expected the API not to be modified")
+	}
+
+	updatedAPI := plannedChangesTestAPI("api01", "updated", nil, []InternalMappingRule{mappingRule}, []InternalPlan{plan})
+	if desiredAPI.Hash() == updatedAPI.Hash() {
+		t.Error("expected different hashes")
+	}
+}
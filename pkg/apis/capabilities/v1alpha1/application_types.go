@@ -0,0 +1,221 @@
+package v1alpha1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	portaClient "github.com/3scale/3scale-porta-go-client/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const APPLICATION_FINALIZER = "application.capabilities.3scale.net"
+
+// Keys of the application credentials secret. The user_key is set for the APIs
+// authenticated by API key, the app_id and app_key for the rest
+const (
+	ApplicationUserKeySecretField = "user_key"
+	ApplicationAppIDSecretField   = "app_id"
+	ApplicationAppKeySecretField  = "app_key"
+)
+
+// ApplicationSpec defines the desired state of Application
+// +k8s:openapi-gen=true
+type ApplicationSpec struct {
+	// DeveloperAccount the application belongs to
+	AccountRef v1.LocalObjectReference `json:"accountRef"`
+	// API of the plan. The application is created in the 3scale service of the API
+	APIRef v1.LocalObjectReference `json:"apiRef"`
+	// Plan the application is subscribed to. It has to be selected by the API
+	PlanRef v1.LocalObjectReference `json:"planRef"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Secret the credentials of the application are written to. Defaults to
+	// the name of the application
+	// +optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// ApplicationStatus defines the observed state of Application
+// +k8s:openapi-gen=true
+type ApplicationStatus struct {
+	// ID of the 3scale application
+	// +optional
+	ApplicationID int64 `json:"applicationID,omitempty"`
+	// ID of the 3scale plan the application is subscribed to
+	// +optional
+	PlanID int64 `json:"planID,omitempty"`
+	// State of the 3scale application, like live or pending
+	// +optional
+	State string `json:"state,omitempty"`
+	// Why the application could not be provisioned
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Application is the Schema for the applications API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=applications,scope=Namespaced
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="Application"
+type Application struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationSpec   `json:"spec,omitempty"`
+	Status ApplicationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApplicationList contains a list of Application
+type ApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Application `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Application{}, &ApplicationList{})
+}
+
+// GetCredentialsSecretName returns the name of the secret with the credentials of the application
+func (a Application) GetCredentialsSecretName() string {
+	if a.Spec.CredentialsSecretName != "" {
+		return a.Spec.CredentialsSecretName
+	}
+	return a.Name
+}
+
+// ApplicationPlan is the 3scale plan an application is subscribed to
+type ApplicationPlan struct {
+	ID string
+	// Backend version of the service of the plan, which defines the credentials of the application
+	BackendVersion string
+}
+
+// GetPlan returns the 3scale plan of the referenced Plan object, in the service of the referenced API
+func (a Application) GetPlan(c client.Client, pc *portaClient.ThreeScaleClient) (*ApplicationPlan, error) {
+	api := &API{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: a.Spec.APIRef.Name, Namespace: a.Namespace}, api)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: a.Spec.PlanRef.Name, Namespace: a.Namespace}, plan)
+	if err != nil {
+		return nil, err
+	}
+	if api.Spec.PlanSelector == nil || !labels.SelectorFromSet(api.Spec.PlanSelector.MatchLabels).Matches(labels.Set(plan.Labels)) {
+		return nil, fmt.Errorf("plan %s is not selected by API %s", plan.Name, api.Name)
+	}
+
+	services, err := pc.ListServices()
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services.Services {
+		if service.SystemName != api.Name {
+			continue
+		}
+
+		plans, err := pc.ListAppPlanByServiceId(service.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, applicationPlan := range plans.Plans {
+			if applicationPlan.PlanName == plan.threescaleName() {
+				return &ApplicationPlan{ID: applicationPlan.ID, BackendVersion: service.BackendVersion}, nil
+			}
+		}
+		return nil, fmt.Errorf("plan %s not found in 3scale service %s", plan.threescaleName(), api.Name)
+	}
+	return nil, fmt.Errorf("3scale service %s not found", api.Name)
+}
+
+// NewCredentials generates the secret data with the credentials of an application of a
+// service with the given backend version
+func NewCredentials(backendVersion string) (map[string]string, error) {
+	if backendVersion == "1" {
+		userKey, err := NewUserKey()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{ApplicationUserKeySecretField: userKey}, nil
+	}
+
+	appID, err := randomHex(4)
+	if err != nil {
+		return nil, err
+	}
+	appKey, err := NewApplicationKey()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		ApplicationAppIDSecretField:  appID,
+		ApplicationAppKeySecretField: appKey,
+	}, nil
+}
+
+// CredentialsParams returns the parameters of the 3scale application with the credentials of the secret data
+func CredentialsParams(credentials map[string]string) map[string]string {
+	params := map[string]string{}
+	if userKey, ok := credentials[ApplicationUserKeySecretField]; ok {
+		params["user_key"] = userKey
+	}
+	if appID, ok := credentials[ApplicationAppIDSecretField]; ok {
+		params["application_id"] = appID
+	}
+	if appKey, ok := credentials[ApplicationAppKeySecretField]; ok {
+		params["application_key"] = appKey
+	}
+	return params
+}
+
+// NewUserKey generates a user_key
+func NewUserKey() (string, error) {
+	return randomHex(16)
+}
+
+// NewApplicationKey generates an app_key
+func NewApplicationKey() (string, error) {
+	return randomHex(16)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HasFinalizer checks if the application object has the application finalizer set
+func (a Application) HasFinalizer() bool {
+	return hasFinalizer(a.Finalizers, APPLICATION_FINALIZER)
+}
+
+// AddFinalizer adds the application finalizer to the meta of the application object
+func (a *Application) AddFinalizer(c client.Client) error {
+	a.SetFinalizers(append(a.GetFinalizers(), APPLICATION_FINALIZER))
+	return c.Update(context.TODO(), a)
+}
+
+// RemoveFinalizer removes the application finalizer from the meta of the application object
+func (a *Application) RemoveFinalizer(c client.Client) error {
+	a.SetFinalizers(withoutFinalizer(a.GetFinalizers(), APPLICATION_FINALIZER))
+	return c.Update(context.TODO(), a)
+}
@@ -0,0 +1,90 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewCredentials(t *testing.T) {
+	cases := []struct {
+		testName       string
+		backendVersion string
+		expectedKeys   []string
+		expectedParams []string
+	}{
+		{"APIKey", "1", []string{ApplicationUserKeySecretField}, []string{"user_key"}},
+		{"AppID", "2", []string{ApplicationAppIDSecretField, ApplicationAppKeySecretField}, []string{"application_id", "application_key"}},
+		{"OpenIDConnect", "oidc", []string{ApplicationAppIDSecretField, ApplicationAppKeySecretField}, []string{"application_id", "application_key"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			credentials, err := NewCredentials(tc.backendVersion)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if len(credentials) != len(tc.expectedKeys) {
+				subT.Errorf("unexpected credentials: %v", credentials)
+			}
+			for _, key := range tc.expectedKeys {
+				if credentials[key] == "" {
+					subT.Errorf("expected credential %s, got: %v", key, credentials)
+				}
+			}
+
+			params := CredentialsParams(credentials)
+			for _, param := range tc.expectedParams {
+				if params[param] == "" {
+					subT.Errorf("expected param %s, got: %v", param, params)
+				}
+			}
+		})
+	}
+
+	other, _ := NewCredentials("1")
+	current, _ := NewCredentials("1")
+	if reflect.DeepEqual(other, current) {
+		t.Error("expected random credentials")
+	}
+}
+
+func TestApplicationGetPlanNotSelected(t *testing.T) {
+	namespace := "someNS"
+	objects := []runtime.Object{
+		&API{
+			ObjectMeta: metav1.ObjectMeta{Name: "api01", Namespace: namespace},
+			Spec: APISpec{APISelectors: APISelectors{
+				PlanSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"api": "api01"}},
+			}},
+		},
+		&Plan{ObjectMeta: metav1.ObjectMeta{Name: "plan01", Namespace: namespace, Labels: map[string]string{"api": "api02"}}},
+	}
+	s := runtime.NewScheme()
+	err := SchemeBuilder.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewFakeClientWithScheme(s, objects...)
+
+	app := Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app01", Namespace: namespace},
+		Spec: ApplicationSpec{
+			APIRef:  v1.LocalObjectReference{Name: "api01"},
+			PlanRef: v1.LocalObjectReference{Name: "plan01"},
+		},
+	}
+	_, err = app.GetPlan(cl, nil)
+	if err == nil || !strings.Contains(err.Error(), "not selected") {
+		t.Errorf("expected not selected error, got: %v", err)
+	}
+
+	if app.GetCredentialsSecretName() != "app01" {
+		t.Errorf("unexpected credentials secret name: %s", app.GetCredentialsSecretName())
+	}
+}
@@ -8,8 +8,10 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/3scale/3scale-operator/pkg/helper"
+	portaClient "github.com/3scale/3scale-porta-go-client/client"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,6 +21,9 @@ import (
 
 const BINDING_FINALIZER = "binding.capabilities.3scale.net"
 
+// DefaultBindingSyncPeriod is how often 3scale is read when the Binding has no syncPeriodSeconds
+const DefaultBindingSyncPeriod = 1 * time.Minute
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 // Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
@@ -29,19 +34,127 @@ type BindingSpec struct {
 	CredentialsRef v1.SecretReference `json:"credentialsRef"`
 	//+optional
 	APISelector metav1.LabelSelector `json:"apiSelector,omitempty"`
+	// When set, the changes needed to sync the APIs are only published in the
+	// status. 3scale is not modified
+	//+optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// Verification of the certificate of the 3scale admin portal
+	//+optional
+	TLS *TLSSpec `json:"tls,omitempty"`
+	// What happens to the 3scale services owned by the Binding when their APIs
+	// are no longer selected or the Binding is deleted. Defaults to Delete
+	//+optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// How often, in seconds, 3scale is read to detect and revert the changes
+	// made outside the Binding. Defaults to 60
+	// +kubebuilder:validation:Minimum=1
+	//+optional
+	SyncPeriodSeconds int64 `json:"syncPeriodSeconds,omitempty"`
 }
 
+// DeletionPolicy defines what happens to a 3scale service owned by a Binding,
+// or to a tenant account created by a Tenant, when it is no longer managed
+// +kubebuilder:validation:Enum=Delete;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the 3scale service or tenant account
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan keeps the 3scale service or tenant account, which is no longer owned
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
 // BindingStatus defines the observed state of Binding
 // +k8s:openapi-gen=true
 type BindingStatus struct {
 	//+optional
 	LastSync *metav1.Timestamp `json:"lastSync,omitempty"`
+	// Deprecated: JSON encoded state of the previous versions of the binding.
+	// It is only read to migrate the binding and removed afterwards
 	//+optional
 	CurrentState *string `json:"currentState,omitempty"`
+	// Current state of the Binding
+	//+optional
+	Conditions []BindingCondition `json:"conditions,omitempty"`
+	// Sync result of every API selected by the Binding
+	//+optional
+	APIs []BindingAPIStatus `json:"apis,omitempty"`
+	// Generation of the Binding last reconciled
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Changes needed to sync the APIs. Only set in dry run mode
+	//+optional
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+	// 3scale services created or adopted by the Binding. Only these services
+	// are ever modified or deleted
+	//+optional
+	Services []BindingService `json:"services,omitempty"`
+}
+
+type BindingConditionType string
+
+const (
+	// BindingSynced means all the APIs selected by the Binding are in sync
+	// with 3scale
+	BindingSynced BindingConditionType = "Synced"
+	// BindingFailed means the last reconciliation of the Binding failed. The
+	// reason and message of the condition contain the details
+	BindingFailed BindingConditionType = "Failed"
+)
+
+type BindingCondition struct {
+	Type   BindingConditionType `json:"type" description:"type of Binding condition"`
+	Status v1.ConditionStatus   `json:"status" description:"status of the condition, one of True, False, Unknown"`
+
+	// +optional
+	Reason string `json:"reason,omitempty" description:"one-word CamelCase reason for the condition's last transition"`
+	// +optional
+	Message string `json:"message,omitempty" description:"human-readable message indicating details about last transition"`
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" description:"last time the condition transit from one status to another"`
+}
+
+// BindingAPIStatus is the sync result of one API of the Binding
+type BindingAPIStatus struct {
+	Name   string `json:"name"`
+	Synced bool   `json:"synced"`
+	// Why the API could not be synced
+	//+optional
+	Error string `json:"error,omitempty"`
+	// ID of the 3scale service of the API
+	//+optional
+	ServiceID string `json:"serviceID,omitempty"`
+	// 3scale metrics of the API
+	//+optional
+	Metrics []BindingObjectStatus `json:"metrics,omitempty"`
+	// 3scale application plans of the API
 	//+optional
-	DesiredState *string `json:"desiredState,omitempty"`
+	Plans []BindingObjectStatus `json:"plans,omitempty"`
+	// Hash of the API defined by the capabilities objects
 	//+optional
-	PreviousState *string `json:"previousState,omitempty"`
+	DesiredHash string `json:"desiredHash,omitempty"`
+	// Hash of the API read from 3scale. The API is in sync when both hashes match
+	//+optional
+	CurrentHash string `json:"currentHash,omitempty"`
+}
+
+// BindingObjectStatus is a 3scale object of an API
+type BindingObjectStatus struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// BindingService is a 3scale service owned by the Binding
+type BindingService struct {
+	// Name of the API, the system name of the service
+	Name string `json:"name"`
+	// ID of the service
+	ID string `json:"id"`
+	// Deletion policy of the API when the service was last synced
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy"`
+	// Adopted is true when the service existed in 3scale before the Binding claimed it
+	// +optional
+	Adopted bool `json:"adopted,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -76,6 +189,9 @@ func init() {
 type State struct {
 	Credentials InternalCredentials `json:"credentials"`
 	APIs        []InternalAPI       `json:"apis"`
+	// IDs of the 3scale objects of the APIs, indexed by API name. Only set
+	// in the states read from 3scale
+	IDs map[string]InternalAPIIDs `json:"-"`
 }
 
 func (s *State) sort() {
@@ -119,7 +235,7 @@ func CompareStates(A, B State) bool {
 	return true
 }
 
-// UpdateStatus Updates the Binding Object Status with the Desired and Current State
+// UpdateStatus updates the Binding Object Status
 func (b *Binding) UpdateStatus(c client.Client) error {
 
 	err := c.Status().Update(context.TODO(), b)
@@ -130,6 +246,186 @@ func (b *Binding) UpdateStatus(c client.Client) error {
 	return nil
 }
 
+// GetCondition returns the condition of the given type or nil if it does not exist
+func (s *BindingStatus) GetCondition(conditionType BindingConditionType) *BindingCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns true when the condition of the given type exists and its status is True
+func (s *BindingStatus) IsConditionTrue(conditionType BindingConditionType) bool {
+	condition := s.GetCondition(conditionType)
+	return condition != nil && condition.Status == v1.ConditionTrue
+}
+
+// SetCondition adds or updates the condition with the same type. The
+// LastTransitionTime is only updated when the status of the condition changes.
+// Returns true if the status was changed
+func (s *BindingStatus) SetCondition(newCondition BindingCondition) bool {
+	existing := s.GetCondition(newCondition.Type)
+	if existing == nil {
+		if newCondition.LastTransitionTime.IsZero() {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+		s.Conditions = append(s.Conditions, newCondition)
+		return true
+	}
+
+	changed := false
+	if existing.Status != newCondition.Status {
+		existing.Status = newCondition.Status
+		existing.LastTransitionTime = newCondition.LastTransitionTime
+		if existing.LastTransitionTime.IsZero() {
+			existing.LastTransitionTime = metav1.Now()
+		}
+		changed = true
+	}
+
+	if existing.Reason != newCondition.Reason {
+		existing.Reason = newCondition.Reason
+		changed = true
+	}
+
+	if existing.Message != newCondition.Message {
+		existing.Message = newCondition.Message
+		changed = true
+	}
+
+	return changed
+}
+
+// SetSynced sets the Synced condition and clears the Failed one. Returns
+// true if the status was changed
+func (s *BindingStatus) SetSynced() bool {
+	changed := s.SetCondition(BindingCondition{Type: BindingSynced, Status: v1.ConditionTrue, Reason: "Synced"})
+	return s.SetCondition(BindingCondition{Type: BindingFailed, Status: v1.ConditionFalse}) || changed
+}
+
+// SetFailed sets the Failed condition with the given reason and message and
+// clears the Synced one. Returns true if the status was changed
+func (s *BindingStatus) SetFailed(reason, message string) bool {
+	changed := s.SetCondition(BindingCondition{Type: BindingSynced, Status: v1.ConditionFalse, Reason: reason})
+	return s.SetCondition(BindingCondition{Type: BindingFailed, Status: v1.ConditionTrue, Reason: reason, Message: message}) || changed
+}
+
+// SetDryRun sets the Synced condition to False because of the given number
+// of planned changes and clears the Failed one. Returns true if the status
+// was changed
+func (s *BindingStatus) SetDryRun(plannedChanges int) bool {
+	message := fmt.Sprintf("%d changes planned. Disable dryRun to apply them", plannedChanges)
+	changed := s.SetCondition(BindingCondition{Type: BindingSynced, Status: v1.ConditionFalse, Reason: "DryRun", Message: message})
+	return s.SetCondition(BindingCondition{Type: BindingFailed, Status: v1.ConditionFalse}) || changed
+}
+
+// SetAPIStatuses sets the sync result of the given APIs, sorted by name, with
+// the IDs and hashes of the desired and current states. The APIs with an entry
+// in apiErrors or different hashes are not synced. Returns true if the status
+// was changed
+func (s *BindingStatus) SetAPIStatuses(apiNames []string, apiErrors map[string]error, desiredState, currentState *State) bool {
+	apiStatuses := []BindingAPIStatus{}
+	for _, apiName := range apiNames {
+		apiStatus := BindingAPIStatus{Name: apiName, Synced: true}
+		if desiredState != nil && currentState != nil {
+			apiStatus.DesiredHash = desiredState.apiHash(apiName)
+			apiStatus.CurrentHash = currentState.apiHash(apiName)
+			apiStatus.Synced = apiStatus.DesiredHash != "" && apiStatus.DesiredHash == apiStatus.CurrentHash
+		}
+		if currentState != nil {
+			if ids, ok := currentState.IDs[apiName]; ok {
+				apiStatus.ServiceID = ids.ServiceID
+				apiStatus.Metrics = objectStatuses(ids.Metrics)
+				apiStatus.Plans = objectStatuses(ids.Plans)
+			}
+		}
+		if err, ok := apiErrors[apiName]; ok {
+			apiStatus.Synced = false
+			apiStatus.Error = err.Error()
+		}
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+	sort.Slice(apiStatuses, func(i, j int) bool { return apiStatuses[i].Name < apiStatuses[j].Name })
+
+	if len(apiStatuses) == 0 {
+		apiStatuses = nil
+	}
+	if reflect.DeepEqual(s.APIs, apiStatuses) {
+		return false
+	}
+	s.APIs = apiStatuses
+	return true
+}
+
+// apiHash returns the hash of the API of the state with the given name or an
+// empty string if the state doesn't have it
+func (s State) apiHash(apiName string) string {
+	for _, api := range s.APIs {
+		if api.Name == apiName {
+			return api.Hash()
+		}
+	}
+	return ""
+}
+
+// objectStatuses returns the 3scale objects with the given IDs indexed by name, sorted by name
+func objectStatuses(ids map[string]string) []BindingObjectStatus {
+	var objects []BindingObjectStatus
+	for name, id := range ids {
+		objects = append(objects, BindingObjectStatus{Name: name, ID: id})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+	return objects
+}
+
+// GetService returns the owned service of the given API or nil if the binding does not own it
+func (s *BindingStatus) GetService(apiName string) *BindingService {
+	for i := range s.Services {
+		if s.Services[i].Name == apiName {
+			return &s.Services[i]
+		}
+	}
+	return nil
+}
+
+// SetService adds or updates the owned service with the same name. Returns true if the status was changed
+func (s *BindingStatus) SetService(service BindingService) bool {
+	existing := s.GetService(service.Name)
+	if existing == nil {
+		s.Services = append(s.Services, service)
+		sort.Slice(s.Services, func(i, j int) bool { return s.Services[i].Name < s.Services[j].Name })
+		return true
+	}
+	if *existing == service {
+		return false
+	}
+	*existing = service
+	return true
+}
+
+// RemoveService removes the owned service of the given API. Returns true if the status was changed
+func (s *BindingStatus) RemoveService(apiName string) bool {
+	for i := range s.Services {
+		if s.Services[i].Name == apiName {
+			s.Services = append(s.Services[:i], s.Services[i+1:]...)
+			if len(s.Services) == 0 {
+				s.Services = nil
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// DeletesService returns true when the service of the given API is owned by the binding and
+// is deleted from 3scale once the API is no longer managed
+func (s *BindingStatus) DeletesService(apiName string) bool {
+	service := s.GetService(apiName)
+	return service != nil && service.DeletionPolicy != DeletionPolicyOrphan
+}
+
 // SetLastSuccessfulSync adds a timestamp to the binding object
 func (b *Binding) SetLastSuccessfulSync() {
 	now := metav1.Now()
@@ -137,24 +433,37 @@ func (b *Binding) SetLastSuccessfulSync() {
 	b.Status.LastSync = timestamp
 }
 
+// GetSyncPeriod returns how often 3scale is read for the binding
+func (b Binding) GetSyncPeriod() time.Duration {
+	if b.Spec.SyncPeriodSeconds <= 0 {
+		return DefaultBindingSyncPeriod
+	}
+	return time.Duration(b.Spec.SyncPeriodSeconds) * time.Second
+}
+
 // IsTerminating checks if the objects has been marked for deletion
 func (b *Binding) IsTerminating() bool {
 	return b.HasFinalizer() && b.DeletionTimestamp != nil
 }
 
-// CleanUp remove all the objects referenced by the binding object current state.
+// CleanUp removes from 3scale the services owned by the binding with the Delete policy
+// and the finalizer of the binding
 func (b *Binding) CleanUp(c client.Client) error {
 
-	state, err := b.GetCurrentState()
-	if state != nil {
-		portaClient, err := helper.PortaClientFromURLString(state.Credentials.AdminURL, state.Credentials.AuthToken)
-
-		if err != nil {
-			for _, api := range state.APIs {
-				_ = api.DeleteFrom3scale(portaClient)
+	if len(b.Status.Services) > 0 {
+		credentials, err := b.newInternalCredentials(c)
+		if err == nil {
+			portaClient, err := b.PortaClient(c, *credentials)
+			if err == nil {
+				apiNames := []string{}
+				for _, service := range b.Status.Services {
+					apiNames = append(apiNames, service.Name)
+				}
+				_ = b.ReleaseServices(portaClient, apiNames)
 			}
 		}
 	}
+
 	//Remove finalizer
 	finalizers := b.GetFinalizers()
 	var setFinalizers []string
@@ -164,76 +473,177 @@ func (b *Binding) CleanUp(c client.Client) error {
 		}
 	}
 	b.SetFinalizers(setFinalizers)
-	err = c.Update(context.TODO(), b)
+	err := c.Update(context.TODO(), b)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-// AddFinalizer adds the binding finalizer to the meta of the binding object
-func (b *Binding) AddFinalizer(c client.Client) error {
-	finalizers := b.GetFinalizers()
-	bindingFinalizer := BINDING_FINALIZER
-	finalizers = append(finalizers, bindingFinalizer)
-	b.SetFinalizers(finalizers)
-	return c.Update(context.TODO(), b)
-
-}
+// ClaimServices records the 3scale services of the selected APIs as owned by the binding. When
+// created is false, only the services of the owned APIs and the APIs with adopt set are claimed.
+// Adopted services are orphaned on release unless their API sets the deletion policy.
+// The errors of the APIs whose services exist in 3scale, but can't be managed by the binding,
+// are returned indexed by API name
+func (b *Binding) ClaimServices(c client.Client, pc *portaClient.ThreeScaleClient, apiNames []string, created bool) (map[string]error, error) {
+	apiErrors := map[string]error{}
+	if len(apiNames) == 0 {
+		return apiErrors, nil
+	}
 
-// SetDesiredState adds the referenced state to the bindingStatus object
-func (b *Binding) SetDesiredState(state State) error {
-	byteState, err := json.Marshal(state)
+	apis, err := b.getAPIs(c)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	desiredState := string(byteState)
-	b.Status.DesiredState = &desiredState
-	return nil
-}
 
-// SetCurrentState adds the referenced state to the bindingStatus object
-func (b *Binding) SetCurrentState(state State) error {
-	byteState, err := json.Marshal(state)
+	services, err := pc.ListServices()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	currentState := string(byteState)
-	b.Status.CurrentState = &currentState
-	return nil
+
+	for _, apiName := range apiNames {
+		var api *API
+		for idx := range apis.Items {
+			if apis.Items[idx].Name == apiName {
+				api = &apis.Items[idx]
+				break
+			}
+		}
+		if api == nil {
+			continue
+		}
+
+		var service *portaClient.Service
+		for idx := range services.Services {
+			if services.Services[idx].SystemName == apiName {
+				service = &services.Services[idx]
+				break
+			}
+		}
+		if service == nil {
+			continue
+		}
+
+		owned := b.Status.GetService(apiName)
+		if owned != nil && owned.ID != service.ID && !created {
+			apiErrors[apiName] = fmt.Errorf("service %s was replaced in 3scale, it is no longer owned by the binding. Set adopt to manage it", apiName)
+			continue
+		}
+		if owned == nil && !created && !api.Spec.Adopt {
+			apiErrors[apiName] = fmt.Errorf("service %s already exists in 3scale and is not owned by the binding. Set adopt to manage it", apiName)
+			continue
+		}
+
+		// Adopted services were not created by the binding, it keeps adopting them until replaced
+		adopted := (owned == nil && !created) || (owned != nil && owned.ID == service.ID && owned.Adopted)
+		deletionPolicy := b.deletionPolicy(*api)
+		if adopted && api.Spec.DeletionPolicy == "" {
+			deletionPolicy = DeletionPolicyOrphan
+		}
+
+		b.Status.SetService(BindingService{
+			Name:           apiName,
+			ID:             service.ID,
+			DeletionPolicy: deletionPolicy,
+			Adopted:        adopted,
+		})
+	}
+
+	return apiErrors, nil
 }
 
-// SetPreviousState adds the referenced state to the bindingStatus object
-func (b *Binding) SetPreviousState(state State) error {
-	byteState, err := json.Marshal(state)
+// ReleaseServices stops owning the services of the given APIs. The ones with the Delete policy
+// are deleted from 3scale. The services not owned by the binding are never deleted. The errors
+// are returned indexed by API name
+func (b *Binding) ReleaseServices(pc *portaClient.ThreeScaleClient, apiNames []string) map[string]error {
+	apiErrors := map[string]error{}
+	if len(apiNames) == 0 {
+		return apiErrors
+	}
+
+	services, err := pc.ListServices()
 	if err != nil {
-		return err
+		for _, apiName := range apiNames {
+			apiErrors[apiName] = err
+		}
+		return apiErrors
 	}
-	previousState := string(byteState)
-	b.Status.PreviousState = &previousState
-	return nil
-}
 
-// StateInSync compares the current and desired state of the binding object and returns if those are in sync
-func (b *Binding) StateInSync() bool {
+	for _, apiName := range apiNames {
+		owned := b.Status.GetService(apiName)
+		if owned == nil {
+			continue
+		}
 
-	if b.Status.CurrentState == nil || b.Status.DesiredState == nil {
-		return false
+		if owned.DeletionPolicy != DeletionPolicyOrphan {
+			var deleteErr error
+			// The service may have been deleted already
+			for _, service := range services.Services {
+				if service.ID == owned.ID {
+					deleteErr = pc.DeleteService(service.ID)
+					break
+				}
+			}
+			if deleteErr != nil {
+				apiErrors[apiName] = deleteErr
+				continue
+			}
+		}
+		b.Status.RemoveService(apiName)
 	}
 
-	var err error
+	return apiErrors
+}
+
+// MigrateStatus moves a binding from the JSON encoded state of the previous versions
+// to the structured status. The services of the APIs in the legacy state were managed
+// by the binding, so they are claimed. Returns true if the status was changed
+func (b *Binding) MigrateStatus(c client.Client, pc *portaClient.ThreeScaleClient) (bool, error) {
+	if b.Status.CurrentState == nil {
+		return false, nil
+	}
 
-	currentState, err := b.GetCurrentState()
+	legacyState := State{}
+	err := json.Unmarshal([]byte(*b.Status.CurrentState), &legacyState)
 	if err != nil {
-		return false
+		// Nothing can be claimed from an invalid state, it is just removed
+		log.Printf("Binding: %s in namespace: %s has an invalid legacy state: %s", b.Name, b.Namespace, err)
+		b.Status.CurrentState = nil
+		return true, nil
 	}
 
-	desiredState, err := b.GetDesiredState()
+	apiNames := []string{}
+	for _, api := range legacyState.APIs {
+		apiNames = append(apiNames, api.Name)
+	}
+	_, err = b.ClaimServices(c, pc, apiNames, true)
 	if err != nil {
-		return false
+		return false, err
 	}
 
-	return CompareStates(*desiredState, *currentState)
+	b.Status.CurrentState = nil
+	return true, nil
+}
+
+// deletionPolicy returns the deletion policy of the API, which defaults to the binding one
+func (b Binding) deletionPolicy(api API) DeletionPolicy {
+	if api.Spec.DeletionPolicy != "" {
+		return api.Spec.DeletionPolicy
+	}
+	if b.Spec.DeletionPolicy != "" {
+		return b.Spec.DeletionPolicy
+	}
+	return DeletionPolicyDelete
+}
+
+// AddFinalizer adds the binding finalizer to the meta of the binding object
+func (b *Binding) AddFinalizer(c client.Client) error {
+	finalizers := b.GetFinalizers()
+	bindingFinalizer := BINDING_FINALIZER
+	finalizers = append(finalizers, bindingFinalizer)
+	b.SetFinalizers(finalizers)
+	return c.Update(context.TODO(), b)
+
 }
 
 // GetLastSuccessfulSync gets the status field LastSync
@@ -261,12 +671,14 @@ func (b Binding) HasFinalizer() bool {
 	return false
 }
 
-// NewDesiredState creates a new state from the CRDs objects
-func (b Binding) NewDesiredState(c client.Client) (*State, error) {
+// NewDesiredState creates a new state from the CRDs objects. The APIs whose
+// objects are not valid are left out of the state and their errors are
+// returned indexed by API name
+func (b Binding) NewDesiredState(c client.Client) (*State, map[string]error, error) {
 
 	internalCredentials, err := b.newInternalCredentials(c)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	state := State{
@@ -277,23 +689,25 @@ func (b Binding) NewDesiredState(c client.Client) (*State, error) {
 	apis, err := b.getAPIs(c)
 	if err != nil && errors.IsNotFound(err) {
 		// No API objects
-		return nil, err
+		return nil, nil, err
 	} else if err != nil {
 		// Something is broken
-		return nil, err
+		return nil, nil, err
 	}
 
+	apiErrors := map[string]error{}
 	for _, api := range apis.Items {
 		internalAPI, err := api.GetInternalAPI(c)
 		if err != nil {
 			log.Printf("Error on InternalAPI: %s", err)
+			apiErrors[api.Name] = err
 		} else {
 			state.APIs = append(state.APIs, *internalAPI)
 		}
 	}
 
 	state.sort()
-	return &state, nil
+	return &state, apiErrors, nil
 
 }
 
@@ -308,6 +722,7 @@ func (b Binding) NewCurrentState(c client.Client) (*State, error) {
 	state := State{
 		Credentials: *internalCredentials,
 		APIs:        nil,
+		IDs:         map[string]InternalAPIIDs{},
 	}
 
 	apis, err := b.getAPIs(c)
@@ -320,13 +735,13 @@ func (b Binding) NewCurrentState(c client.Client) (*State, error) {
 		return nil, err
 	}
 
-	portaClient, err := helper.PortaClientFromURLString(state.Credentials.AdminURL, state.Credentials.AuthToken)
+	portaClient, err := b.PortaClient(c, state.Credentials)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, api := range apis.Items {
-		internalAPI, err := api.getInternalAPIfrom3scale(portaClient)
+		internalAPI, ids, err := api.getInternalAPIfrom3scale(portaClient)
 		if err != nil && strings.Contains(err.Error(), "NotFound") {
 			// Nothing has been found
 			log.Printf("API is missing from 3scale: %s\n", api.Name)
@@ -335,6 +750,7 @@ func (b Binding) NewCurrentState(c client.Client) (*State, error) {
 			return nil, err
 		} else {
 			state.APIs = append(state.APIs, *internalAPI)
+			state.IDs[api.Name] = *ids
 		}
 	}
 
@@ -342,49 +758,16 @@ func (b Binding) NewCurrentState(c client.Client) (*State, error) {
 	return &state, nil
 }
 
-// GetPreviousState returns the status field PreviousState
-func (b Binding) GetPreviousState() (*State, error) {
-	if b.Status.PreviousState != nil {
-
-		previousState := State{}
-		err := json.Unmarshal([]byte(*b.Status.PreviousState), &previousState)
-		if err != nil {
-			return nil, err
-		}
-		return &previousState, nil
-	}
-	return nil, nil
-}
-
-// GetDesiredState returns the status field DesiredState
-func (b Binding) GetDesiredState() (*State, error) {
-	if b.Status.DesiredState != nil {
-		currentState := State{}
-
-		err := json.Unmarshal([]byte(*b.Status.DesiredState), &currentState)
-		if err != nil {
-			return nil, err
-		}
-
-		return &currentState, nil
+// PortaClient returns a 3scale client for the credentials, verifying the
+// certificate of the admin portal as configured in the binding TLS spec
+func (b Binding) PortaClient(c client.Client, creds InternalCredentials) (*portaClient.ThreeScaleClient, error) {
+	tlsConfig, err := b.Spec.TLS.NewTLSConfig(c, b.Namespace)
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil
+	return helper.PortaClientFromURLString(creds.AdminURL, creds.AuthToken, tlsConfig)
 }
 
-// GetCurrentState returns the status field CurrentState
-func (b Binding) GetCurrentState() (*State, error) {
-	if b.Status.CurrentState != nil {
-
-		desiredState := State{}
-
-		err := json.Unmarshal([]byte(*b.Status.CurrentState), &desiredState)
-		if err != nil {
-			return nil, err
-		}
-		return &desiredState, nil
-	}
-	return nil, nil
-}
 func (b Binding) getAPIs(c client.Client) (*APIList, error) {
 	apis := &APIList{}
 	opts := []client.ListOption{
@@ -394,12 +777,19 @@ func (b Binding) getAPIs(c client.Client) (*APIList, error) {
 	err := c.List(context.TODO(), apis, opts...)
 	return apis, err
 }
+
 func (b Binding) newInternalCredentials(c client.Client) (*InternalCredentials, error) {
+	return newInternalCredentials(c, b.Namespace, b.Spec.CredentialsRef)
+}
+
+// newInternalCredentials reads the credentials of the 3scale tenant from the
+// referenced secret, in the given namespace
+func newInternalCredentials(c client.Client, namespace string, credentialsRef v1.SecretReference) (*InternalCredentials, error) {
 
 	// GET SECRET
 	secret := &v1.Secret{}
 	// TODO: fix namespace default
-	err := c.Get(context.TODO(), types.NamespacedName{Name: b.Spec.CredentialsRef.Name, Namespace: b.Namespace}, secret)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: credentialsRef.Name, Namespace: namespace}, secret)
 
 	if err != nil && errors.IsNotFound(err) {
 		return nil, fmt.Errorf("credentialsNotFound")
@@ -0,0 +1,289 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBindingStatusConditions(t *testing.T) {
+	status := &BindingStatus{}
+
+	if !status.SetFailed("APISyncFailed", "APIs failed to sync: api01") {
+		t.Fatal("expected the status to be changed")
+	}
+	if !status.IsConditionTrue(BindingFailed) || status.IsConditionTrue(BindingSynced) {
+		t.Errorf("unexpected conditions: %v", status.Conditions)
+	}
+	if status.GetCondition(BindingFailed).Message != "APIs failed to sync: api01" {
+		t.Errorf("unexpected Failed message: %s", status.GetCondition(BindingFailed).Message)
+	}
+	if status.SetFailed("APISyncFailed", "APIs failed to sync: api01") {
+		t.Error("expected the status not to be changed")
+	}
+
+	if !status.SetSynced() {
+		t.Fatal("expected the status to be changed")
+	}
+	if status.IsConditionTrue(BindingFailed) || !status.IsConditionTrue(BindingSynced) {
+		t.Errorf("unexpected conditions: %v", status.Conditions)
+	}
+	if len(status.Conditions) != 2 {
+		t.Errorf("expected 2 conditions, got: %v", status.Conditions)
+	}
+	if status.SetSynced() {
+		t.Error("expected the status not to be changed")
+	}
+}
+
+func TestBindingStatusSetAPIStatuses(t *testing.T) {
+	status := &BindingStatus{}
+
+	apiErrors := map[string]error{"api02": fmt.Errorf("metric not found")}
+	if !status.SetAPIStatuses([]string{"api03", "api02", "api01"}, apiErrors, nil, nil) {
+		t.Fatal("expected the status to be changed")
+	}
+
+	expected := []BindingAPIStatus{
+		{Name: "api01", Synced: true},
+		{Name: "api02", Synced: false, Error: "metric not found"},
+		{Name: "api03", Synced: true},
+	}
+	if !reflect.DeepEqual(status.APIs, expected) {
+		t.Errorf("unexpected API statuses: %v", status.APIs)
+	}
+
+	if status.SetAPIStatuses([]string{"api01", "api02", "api03"}, apiErrors, nil, nil) {
+		t.Error("expected the status not to be changed")
+	}
+
+	if !status.SetAPIStatuses([]string{}, nil, nil, nil) || status.APIs != nil {
+		t.Errorf("expected API statuses to be cleared, got: %v", status.APIs)
+	}
+}
+
+func TestBindingStatusSetAPIStatusesStates(t *testing.T) {
+	status := &BindingStatus{}
+
+	syncedAPI := plannedChangesTestAPI("api01", "synced", nil, nil, nil)
+	desiredAPI := plannedChangesTestAPI("api02", "updated", nil, nil, nil)
+	currentAPI := plannedChangesTestAPI("api02", "old", nil, nil, nil)
+	desiredState := &State{APIs: []InternalAPI{syncedAPI, desiredAPI}}
+	currentState := &State{
+		APIs: []InternalAPI{syncedAPI, currentAPI},
+		IDs: map[string]InternalAPIIDs{
+			"api01": {ServiceID: "1", Metrics: map[string]string{"orders": "11", "Hits": "10"}, Plans: map[string]string{"basic": "12"}},
+			"api02": {ServiceID: "2", Metrics: map[string]string{"Hits": "20"}, Plans: map[string]string{}},
+		},
+	}
+
+	status.SetAPIStatuses([]string{"api01", "api02"}, nil, desiredState, currentState)
+
+	expected := []BindingAPIStatus{
+		{
+			Name:        "api01",
+			Synced:      true,
+			ServiceID:   "1",
+			Metrics:     []BindingObjectStatus{{Name: "Hits", ID: "10"}, {Name: "orders", ID: "11"}},
+			Plans:       []BindingObjectStatus{{Name: "basic", ID: "12"}},
+			DesiredHash: syncedAPI.Hash(),
+			CurrentHash: syncedAPI.Hash(),
+		},
+		{
+			Name:        "api02",
+			Synced:      false,
+			ServiceID:   "2",
+			Metrics:     []BindingObjectStatus{{Name: "Hits", ID: "20"}},
+			DesiredHash: desiredAPI.Hash(),
+			CurrentHash: currentAPI.Hash(),
+		},
+	}
+	if !reflect.DeepEqual(status.APIs, expected) {
+		t.Errorf("unexpected API statuses.\ngot:\n%v\nexpected:\n%v", status.APIs, expected)
+	}
+}
+
+func TestBindingMigrateStatus(t *testing.T) {
+	binding := &Binding{}
+	migrated, err := binding.MigrateStatus(nil, nil)
+	if err != nil || migrated {
+		t.Errorf("expected nothing to migrate, got: %v, %v", migrated, err)
+	}
+
+	// An invalid legacy state has nothing to claim, it is removed
+	legacyState := "{invalid"
+	binding.Status.CurrentState = &legacyState
+	migrated, err = binding.MigrateStatus(nil, nil)
+	if err != nil || !migrated || binding.Status.CurrentState != nil {
+		t.Errorf("expected the legacy state to be removed, got: %v, %v", migrated, err)
+	}
+}
+
+func TestBindingStatusConditionTransitionTime(t *testing.T) {
+	status := &BindingStatus{}
+	status.SetSynced()
+	synced := *status.GetCondition(BindingSynced)
+
+	// Only a status change updates the transition time
+	status.SetCondition(BindingCondition{Type: BindingSynced, Status: v1.ConditionTrue, Reason: "Other"})
+	if status.GetCondition(BindingSynced).LastTransitionTime != synced.LastTransitionTime {
+		t.Error("expected the transition time not to be changed")
+	}
+}
+
+func TestBindingStatusServices(t *testing.T) {
+	status := &BindingStatus{}
+
+	if !status.SetService(BindingService{Name: "api02", ID: "2", DeletionPolicy: DeletionPolicyOrphan}) ||
+		!status.SetService(BindingService{Name: "api01", ID: "1", DeletionPolicy: DeletionPolicyDelete}) {
+		t.Fatal("expected the status to be changed")
+	}
+	if status.Services[0].Name != "api01" || status.Services[1].Name != "api02" {
+		t.Errorf("expected services sorted by name, got: %v", status.Services)
+	}
+	if status.SetService(BindingService{Name: "api01", ID: "1", DeletionPolicy: DeletionPolicyDelete}) {
+		t.Error("expected the status not to be changed")
+	}
+
+	cases := []struct {
+		apiName         string
+		expectedDeletes bool
+	}{
+		{"api01", true},
+		{"api02", false},
+		{"api03", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.apiName, func(subT *testing.T) {
+			if status.DeletesService(tc.apiName) != tc.expectedDeletes {
+				subT.Errorf("expected deletes service: %t", tc.expectedDeletes)
+			}
+		})
+	}
+
+	if !status.RemoveService("api01") || !status.RemoveService("api02") || status.Services != nil {
+		t.Errorf("expected services to be cleared, got: %v", status.Services)
+	}
+	if status.RemoveService("api01") {
+		t.Error("expected the status not to be changed")
+	}
+}
+
+func TestBindingDeletionPolicy(t *testing.T) {
+	cases := []struct {
+		testName       string
+		bindingPolicy  DeletionPolicy
+		apiPolicy      DeletionPolicy
+		expectedPolicy DeletionPolicy
+	}{
+		{"Default", "", "", DeletionPolicyDelete},
+		{"Binding", DeletionPolicyOrphan, "", DeletionPolicyOrphan},
+		{"APIOverridesBinding", DeletionPolicyOrphan, DeletionPolicyDelete, DeletionPolicyDelete},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			binding := Binding{Spec: BindingSpec{DeletionPolicy: tc.bindingPolicy}}
+			api := API{Spec: APISpec{DeletionPolicy: tc.apiPolicy}}
+			if policy := binding.deletionPolicy(api); policy != tc.expectedPolicy {
+				subT.Errorf("expected policy %s, got: %s", tc.expectedPolicy, policy)
+			}
+		})
+	}
+}
+
+func TestBindingReleaseAdoptedServices(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch fmt.Sprintf("%s %s", r.Method, r.URL.Path) {
+		case "GET /admin/api/services.xml":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `<services>`+
+				`<service><id>1</id><system_name>api01</system_name></service>`+
+				`<service><id>2</id><system_name>api02</system_name></service>`+
+				`<service><id>3</id><system_name>api03</system_name></service>`+
+				`</services>`)
+		case "DELETE /admin/api/services/1.xml", "DELETE /admin/api/services/2.xml", "DELETE /admin/api/services/3.xml":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	pc, err := helper.PortaClientFromURLString(server.URL, "token", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := map[string]string{"environment": "testing"}
+	newAPI := func(name string, deletionPolicy DeletionPolicy) runtime.Object {
+		return &API{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "operator-unittest", Labels: labels},
+			Spec:       APISpec{Adopt: true, DeletionPolicy: deletionPolicy},
+		}
+	}
+	s := runtime.NewScheme()
+	err = SchemeBuilder.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewFakeClientWithScheme(s,
+		newAPI("api01", ""),
+		newAPI("api02", DeletionPolicyDelete),
+		newAPI("api03", ""),
+	)
+
+	// The binding policy only applies to the services it created
+	binding := &Binding{
+		ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "operator-unittest"},
+		Spec: BindingSpec{
+			APISelector:    metav1.LabelSelector{MatchLabels: labels},
+			DeletionPolicy: DeletionPolicyDelete,
+		},
+	}
+	apiErrors, err := binding.ClaimServices(c, pc, []string{"api01", "api02"}, false)
+	if err != nil || len(apiErrors) > 0 {
+		t.Fatalf("unexpected errors: %v, %v", apiErrors, err)
+	}
+	apiErrors, err = binding.ClaimServices(c, pc, []string{"api03"}, true)
+	if err != nil || len(apiErrors) > 0 {
+		t.Fatalf("unexpected errors: %v, %v", apiErrors, err)
+	}
+
+	expected := []BindingService{
+		{Name: "api01", ID: "1", DeletionPolicy: DeletionPolicyOrphan, Adopted: true},
+		{Name: "api02", ID: "2", DeletionPolicy: DeletionPolicyDelete, Adopted: true},
+		{Name: "api03", ID: "3", DeletionPolicy: DeletionPolicyDelete},
+	}
+	if !reflect.DeepEqual(binding.Status.Services, expected) {
+		t.Errorf("unexpected services.\ngot:\n%v\nexpected:\n%v", binding.Status.Services, expected)
+	}
+
+	// Claiming again keeps the adopted services orphaned
+	_, err = binding.ClaimServices(c, pc, []string{"api01", "api02", "api03"}, false)
+	if err != nil || !reflect.DeepEqual(binding.Status.Services, expected) {
+		t.Errorf("unexpected services: %v, %v", binding.Status.Services, err)
+	}
+
+	apiErrors = binding.ReleaseServices(pc, []string{"api01", "api02", "api03"})
+	if len(apiErrors) > 0 {
+		t.Fatalf("unexpected errors: %v", apiErrors)
+	}
+	expectedDeleted := []string{"/admin/api/services/2.xml", "/admin/api/services/3.xml"}
+	if !reflect.DeepEqual(deleted, expectedDeleted) {
+		t.Errorf("unexpected deleted services: %v, expected: %v", deleted, expectedDeleted)
+	}
+	if len(binding.Status.Services) > 0 {
+		t.Errorf("expected services to be released, got: %v", binding.Status.Services)
+	}
+}
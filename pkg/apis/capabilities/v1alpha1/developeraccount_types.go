@@ -0,0 +1,137 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+	portaClient "github.com/3scale/3scale-porta-go-client/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const DEVELOPER_ACCOUNT_FINALIZER = "developeraccount.capabilities.3scale.net"
+
+// DeveloperAccountSpec defines the desired state of DeveloperAccount
+// +k8s:openapi-gen=true
+type DeveloperAccountSpec struct {
+	// Secret with the credentials of the 3scale tenant, like the Binding one
+	CredentialsRef v1.SecretReference `json:"credentialsRef"`
+	// Verification of the certificate of the 3scale admin portal
+	// +optional
+	TLS *TLSSpec `json:"tls,omitempty"`
+	// Organization name of the account
+	OrganizationName string `json:"organizationName"`
+	// Username of the admin user of the account
+	Username string `json:"username"`
+	// Email of the admin user of the account
+	Email string `json:"email"`
+}
+
+// DeveloperAccountStatus defines the observed state of DeveloperAccount
+// +k8s:openapi-gen=true
+type DeveloperAccountStatus struct {
+	// ID of the 3scale account
+	// +optional
+	AccountID int64 `json:"accountID,omitempty"`
+	// State of the 3scale account, like approved or pending
+	// +optional
+	State string `json:"state,omitempty"`
+	// Why the account could not be provisioned
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeveloperAccount is the Schema for the developeraccounts API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=developeraccounts,scope=Namespaced
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="DeveloperAccount"
+type DeveloperAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeveloperAccountSpec   `json:"spec,omitempty"`
+	Status DeveloperAccountStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeveloperAccountList contains a list of DeveloperAccount
+type DeveloperAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeveloperAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DeveloperAccount{}, &DeveloperAccountList{})
+}
+
+// PortaClient returns a 3scale client for the tenant of the account
+func (a DeveloperAccount) PortaClient(c client.Client) (*portaClient.ThreeScaleClient, error) {
+	credentials, err := newInternalCredentials(c, a.Namespace, a.Spec.CredentialsRef)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := a.Spec.TLS.NewTLSConfig(c, a.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return helper.PortaClientFromURLString(credentials.AdminURL, credentials.AuthToken, tlsConfig)
+}
+
+// DeveloperClient returns a client managing the developer accounts and applications
+// of the tenant of the account
+func (a DeveloperAccount) DeveloperClient(c client.Client) (*helper.DeveloperClient, error) {
+	credentials, err := newInternalCredentials(c, a.Namespace, a.Spec.CredentialsRef)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := a.Spec.TLS.NewTLSConfig(c, a.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return helper.DeveloperClientFromURLString(credentials.AdminURL, credentials.AuthToken, tlsConfig)
+}
+
+// HasFinalizer checks if the account object has the account finalizer set
+func (a DeveloperAccount) HasFinalizer() bool {
+	return hasFinalizer(a.Finalizers, DEVELOPER_ACCOUNT_FINALIZER)
+}
+
+// AddFinalizer adds the account finalizer to the meta of the account object
+func (a *DeveloperAccount) AddFinalizer(c client.Client) error {
+	a.SetFinalizers(append(a.GetFinalizers(), DEVELOPER_ACCOUNT_FINALIZER))
+	return c.Update(context.TODO(), a)
+}
+
+// RemoveFinalizer removes the account finalizer from the meta of the account object
+func (a *DeveloperAccount) RemoveFinalizer(c client.Client) error {
+	a.SetFinalizers(withoutFinalizer(a.GetFinalizers(), DEVELOPER_ACCOUNT_FINALIZER))
+	return c.Update(context.TODO(), a)
+}
+
+func hasFinalizer(finalizers []string, finalizer string) bool {
+	for _, f := range finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutFinalizer(finalizers []string, finalizer string) []string {
+	var filtered []string
+	for _, f := range finalizers {
+		if f != finalizer {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
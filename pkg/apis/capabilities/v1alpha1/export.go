@@ -0,0 +1,297 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	portaClient "github.com/3scale/3scale-porta-go-client/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var exportNameInvalidChars = regexp.MustCompile("[^a-z0-9-]+")
+
+// ExportOptions selects what Export reads from 3scale
+type ExportOptions struct {
+	// Name of the exported Binding, also used as label of the exported APIs
+	BindingName string
+	// Secret with the tenant credentials referenced by the exported Binding
+	CredentialsSecretName string
+	// System names of the exported services. All of them when empty
+	ServiceNames []string
+}
+
+// exporter keeps the names of the exported objects, which have to be unique in a namespace
+type exporter struct {
+	objects  []runtime.Object
+	names    map[string]string
+	warnings []string
+}
+
+// Export reads the services of a 3scale account and returns the API, Metric, MappingRule,
+// Policy, Plan, Limit and Binding objects describing them, to be applied in a single namespace.
+// The warnings list the 3scale names that are not valid object names: applying the objects
+// renames them in 3scale
+func Export(c *portaClient.ThreeScaleClient, opts ExportOptions) ([]runtime.Object, []string, error) {
+	services, err := c.ListServices()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e := &exporter{names: map[string]string{}}
+
+	exported := map[string]bool{}
+	for _, service := range services.Services {
+		if len(opts.ServiceNames) > 0 && !containsString(opts.ServiceNames, service.SystemName) {
+			continue
+		}
+		exported[service.SystemName] = true
+
+		api := API{ObjectMeta: metav1.ObjectMeta{Name: service.SystemName}}
+		internalAPI, _, err := api.getInternalAPIfrom3scale(c)
+		if err != nil {
+			return nil, nil, fmt.Errorf("service %s couldn't be read: %v", service.SystemName, err)
+		}
+		// The API object is matched with the service by system name
+		internalAPI.Name = service.SystemName
+
+		err = e.exportAPI(*internalAPI, opts.BindingName)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, serviceName := range opts.ServiceNames {
+		if !exported[serviceName] {
+			return nil, nil, fmt.Errorf("service %s not found", serviceName)
+		}
+	}
+
+	binding := &Binding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "Binding"},
+		ObjectMeta: metav1.ObjectMeta{Name: opts.BindingName},
+		Spec: BindingSpec{
+			CredentialsRef: v1.SecretReference{Name: opts.CredentialsSecretName},
+			APISelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"binding": opts.BindingName},
+			},
+		},
+	}
+	e.objects = append(e.objects, binding)
+
+	return e.objects, e.warnings, nil
+}
+
+func (e *exporter) exportAPI(internalAPI InternalAPI, bindingName string) error {
+	apiName, err := e.objectName("API", internalAPI.Name, internalAPI.Name, true)
+	if err != nil {
+		return err
+	}
+	apiLabels := map[string]string{"api": apiName}
+	selector := &metav1.LabelSelector{MatchLabels: apiLabels}
+
+	api := &API{
+		TypeMeta: metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "API"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   apiName,
+			Labels: map[string]string{"binding": bindingName},
+		},
+		Spec: APISpec{
+			APIBase: APIBase{Description: internalAPI.Description},
+			APISelectors: APISelectors{
+				PlanSelector:   selector,
+				MetricSelector: selector,
+			},
+			// The service already exists, the Binding has to adopt it to manage it,
+			// and keep it in 3scale when the API is deleted
+			Adopt:          true,
+			DeletionPolicy: DeletionPolicyOrphan,
+		},
+	}
+
+	integration := internalAPI.IntegrationMethod
+	baseSelectors := APIcastBaseSelectors{MappingRulesSelector: selector}
+	if len(internalAPI.getPolicies()) > 0 {
+		baseSelectors.PoliciesSelector = selector
+	}
+	if integration.ApicastHosted != nil {
+		api.Spec.IntegrationMethod.ApicastHosted = &ApicastHosted{
+			APIcastBaseOptions:   integration.ApicastHosted.APIcastBaseOptions,
+			APIcastBaseSelectors: baseSelectors,
+		}
+	} else if integration.ApicastOnPrem != nil {
+		api.Spec.IntegrationMethod.ApicastOnPrem = &ApicastOnPrem{
+			APIcastBaseOptions:      integration.ApicastOnPrem.APIcastBaseOptions,
+			StagingPublicBaseURL:    integration.ApicastOnPrem.StagingPublicBaseURL,
+			ProductionPublicBaseURL: integration.ApicastOnPrem.ProductionPublicBaseURL,
+			APIcastBaseSelectors:    baseSelectors,
+		}
+	} else if integration.CodePlugin != nil {
+		api.Spec.IntegrationMethod.CodePlugin = &CodePlugin{
+			AuthenticationSettings: integration.CodePlugin.AuthenticationSettings,
+		}
+	}
+	e.objects = append(e.objects, api)
+
+	// Metrics and plans of different services can share their names, so the object names are
+	// qualified with the API name and the 3scale names are kept in the spec
+	metricNames := map[string]string{}
+	for _, metric := range internalAPI.Metrics {
+		metricName, err := e.objectName("Metric", fmt.Sprintf("%s-%s", apiName, metric.Name), internalAPI.Name, false)
+		if err != nil {
+			return err
+		}
+		metricNames[metric.Name] = metricName
+		e.objects = append(e.objects, &Metric{
+			TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "Metric"},
+			ObjectMeta: metav1.ObjectMeta{Name: metricName, Labels: apiLabels},
+			Spec: MetricSpec{
+				Name:        metric.Name,
+				Unit:        metric.Unit,
+				Description: metric.Description,
+			},
+		})
+	}
+
+	for idx, mappingRule := range internalAPI.getMappingRules() {
+		name, err := e.objectName("MappingRule", fmt.Sprintf("%s-%s-%d", apiName, mappingRule.Method, idx), internalAPI.Name, false)
+		if err != nil {
+			return err
+		}
+		e.objects = append(e.objects, &MappingRule{
+			TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "MappingRule"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: apiLabels},
+			Spec: MappingRuleSpec{
+				MappingRuleBase: MappingRuleBase{
+					Path:      mappingRule.Path,
+					Method:    mappingRule.Method,
+					Increment: mappingRule.Increment,
+				},
+				MappingRuleMetricRef: MappingRuleMetricRef{
+					MetricRef: v1.ObjectReference{Name: exportMetricRef(mappingRule.Metric, metricNames)},
+				},
+			},
+		})
+	}
+
+	for idx, policy := range internalAPI.getPolicies() {
+		name, err := e.objectName("Policy", fmt.Sprintf("%s-%s", apiName, policy.Name), internalAPI.Name, false)
+		if err != nil {
+			return err
+		}
+		enabled := policy.Enabled
+		e.objects = append(e.objects, &Policy{
+			TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "Policy"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: apiLabels},
+			Spec: PolicySpec{
+				Name:          policy.Name,
+				Version:       policy.Version,
+				Configuration: policy.Configuration,
+				Enabled:       &enabled,
+				Position:      int64(idx),
+			},
+		})
+	}
+
+	for _, plan := range internalAPI.Plans {
+		planName, err := e.objectName("Plan", fmt.Sprintf("%s-%s", apiName, plan.Name), internalAPI.Name, false)
+		if err != nil {
+			return err
+		}
+		e.objects = append(e.objects, &Plan{
+			TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "Plan"},
+			ObjectMeta: metav1.ObjectMeta{Name: planName, Labels: apiLabels},
+			Spec: PlanSpec{
+				PlanBase: PlanBase{
+					Name:             plan.Name,
+					Default:          plan.Default,
+					TrialPeriod:      plan.TrialPeriodDays,
+					ApprovalRequired: plan.ApprovalRequired,
+					Costs:            plan.Costs,
+				},
+				PlanSelectors: PlanSelectors{
+					LimitSelector: metav1.LabelSelector{MatchLabels: map[string]string{"plan": planName}},
+				},
+			},
+		})
+
+		for _, limit := range plan.Limits {
+			metricRef := exportMetricRef(limit.Metric, metricNames)
+			name, err := e.objectName("Limit", fmt.Sprintf("%s-%s-%s-%d", planName, metricRef, limit.Period, limit.MaxValue), internalAPI.Name, false)
+			if err != nil {
+				return err
+			}
+			e.objects = append(e.objects, &Limit{
+				TypeMeta: metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "Limit"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{"api": apiName, "plan": planName},
+				},
+				Spec: LimitSpec{
+					LimitBase: LimitBase{
+						Period:   limit.Period,
+						MaxValue: limit.MaxValue,
+					},
+					LimitObjectRef: LimitObjectRef{
+						Metric: v1.ObjectReference{Name: metricRef},
+					},
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+// objectName returns a valid object name for the 3scale name. When the 3scale object is
+// matched by name, like services, changing it and using it twice in the namespace are not
+// allowed. Otherwise, the name is made unique
+func (e *exporter) objectName(kind, name, apiName string, matchedByName bool) (string, error) {
+	objectName := strings.Trim(exportNameInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if objectName == "" {
+		objectName = strings.ToLower(kind)
+	}
+	if matchedByName && objectName != name {
+		e.warnings = append(e.warnings, fmt.Sprintf("%s %s of service %s exported as %s, applying it renames it in 3scale", kind, name, apiName, objectName))
+	}
+
+	key := fmt.Sprintf("%s/%s", kind, objectName)
+	if owner, ok := e.names[key]; ok {
+		if matchedByName {
+			return "", fmt.Errorf("%s %s exists in services %s and %s, export them to different namespaces", kind, objectName, owner, apiName)
+		}
+		for idx := 1; ; idx++ {
+			uniqueKey := fmt.Sprintf("%s-%d", key, idx)
+			if _, ok := e.names[uniqueKey]; !ok {
+				key = uniqueKey
+				objectName = fmt.Sprintf("%s-%d", objectName, idx)
+				break
+			}
+		}
+	}
+	e.names[key] = apiName
+
+	return objectName, nil
+}
+
+// exportMetricRef returns the object name of the metric, the Hits metric has no object
+func exportMetricRef(metricName string, metricNames map[string]string) string {
+	if strings.ToLower(metricName) == "hits" {
+		return "hits"
+	}
+	if name, ok := metricNames[metricName]; ok {
+		return name
+	}
+	return metricName
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExportAPIRoundTrip(t *testing.T) {
+	namespace := "someNS"
+	orders := InternalMetric{Name: "orders", Unit: "order", Description: "Number of orders"}
+	mappingRule := InternalMappingRule{Name: "post-orders", Path: "/orders", Method: "POST", Increment: 1, Metric: "orders"}
+	plan := InternalPlan{Name: "basic", Default: true, Limits: []InternalLimit{
+		{Name: "limit01", Period: "day", MaxValue: 10, Metric: "orders"},
+		{Name: "limit02", Period: "minute", MaxValue: 100, Metric: "Hits"},
+	}}
+	internalAPI := plannedChangesTestAPI("api01", "exported", []InternalMetric{orders}, []InternalMappingRule{mappingRule}, []InternalPlan{plan})
+	internalAPI.IntegrationMethod.ApicastHosted.Policies = []InternalPolicy{
+		{Name: "cors", Version: "builtin", Configuration: `{"allow_origin":"*"}`, Enabled: true},
+		defaultAPIcastPolicy(),
+	}
+
+	e := &exporter{names: map[string]string{}}
+	err := e.exportAPI(internalAPI, "binding01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(e.warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", e.warnings)
+	}
+
+	var api *API
+	for _, object := range e.objects {
+		object.(interface{ SetNamespace(string) }).SetNamespace(namespace)
+		if exportedAPI, ok := object.(*API); ok {
+			api = exportedAPI
+		}
+	}
+	if api == nil {
+		t.Fatal("API not exported")
+	}
+	if !api.Spec.Adopt || api.Spec.DeletionPolicy != DeletionPolicyOrphan {
+		t.Errorf("expected the exported API to adopt and orphan the service, got: %v, %s", api.Spec.Adopt, api.Spec.DeletionPolicy)
+	}
+
+	s := runtime.NewScheme()
+	err = SchemeBuilder.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewFakeClientWithScheme(s, e.objects...)
+
+	importedAPI, err := api.GetInternalAPI(cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !CompareInternalAPI(*importedAPI, internalAPI) {
+		t.Errorf("exported objects don't describe the API.\ngot:\n%v\nexpected:\n%v", *importedAPI, internalAPI)
+	}
+}
+
+func TestExportObjectName(t *testing.T) {
+	e := &exporter{names: map[string]string{}}
+
+	name, err := e.objectName("API", "Orders_API", "Orders_API", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "orders-api" || len(e.warnings) != 1 {
+		t.Errorf("unexpected name %s, warnings: %v", name, e.warnings)
+	}
+
+	_, err = e.objectName("API", "orders-api", "orders-api", true)
+	if err == nil || !strings.Contains(err.Error(), "Orders_API") {
+		t.Errorf("expected conflict with Orders_API, got: %v", err)
+	}
+
+	for _, expected := range []string{"api01-get-0", "api01-get-0-1", "api01-get-0-2"} {
+		name, err := e.objectName("MappingRule", "api01-GET-0", "api01", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != expected {
+			t.Errorf("expected name %s, got: %s", expected, name)
+		}
+	}
+}
+
+func TestExportSharedNames(t *testing.T) {
+	orders := InternalMetric{Name: "orders", Unit: "order", Description: "Number of orders"}
+	plan := InternalPlan{Name: "Basic Plan", Default: true, Limits: []InternalLimit{
+		{Name: "limit01", Period: "day", MaxValue: 10, Metric: "orders"},
+	}}
+
+	e := &exporter{names: map[string]string{}}
+	for _, apiName := range []string{"api01", "api02"} {
+		internalAPI := plannedChangesTestAPI(apiName, "exported", []InternalMetric{orders}, nil, []InternalPlan{plan})
+		err := e.exportAPI(internalAPI, "binding01")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(e.warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", e.warnings)
+	}
+
+	names := map[string]string{}
+	for _, object := range e.objects {
+		switch exported := object.(type) {
+		case *Metric:
+			names[exported.Name] = exported.Spec.Name
+		case *Plan:
+			names[exported.Name] = exported.Spec.Name
+		case *Limit:
+			names[exported.Name] = exported.Spec.Metric.Name
+		}
+	}
+	expected := map[string]string{
+		"api01-orders":                         "orders",
+		"api02-orders":                         "orders",
+		"api01-basic-plan":                     "Basic Plan",
+		"api02-basic-plan":                     "Basic Plan",
+		"api01-basic-plan-api01-orders-day-10": "api01-orders",
+		"api02-basic-plan-api02-orders-day-10": "api02-orders",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("unexpected exported names.\ngot:\n%v\nexpected:\n%v", names, expected)
+	}
+}
@@ -96,7 +96,7 @@ func newInternalLimitFromLimit(limit Limit, c client.Client) (*InternalLimit, er
 		Name:     limit.Name,
 		Period:   limit.Spec.Period,
 		MaxValue: limit.Spec.MaxValue,
-		Metric:   metric.Name,
+		Metric:   metric.threescaleName(),
 	}
 
 	return &il, nil
@@ -113,6 +113,18 @@ type LimitPair struct {
 	B InternalLimit
 }
 
+// plannedChanges describes the changes reconcileWith3scale would make
+func (d *LimitsDiff) plannedChanges() []string {
+	changes := []string{}
+	for _, limit := range d.MissingFromA {
+		changes = append(changes, fmt.Sprintf("delete limit of metric %s, %d per %s", limit.Metric, limit.MaxValue, limit.Period))
+	}
+	for _, limit := range d.MissingFromB {
+		changes = append(changes, fmt.Sprintf("create limit of metric %s, %d per %s", limit.Metric, limit.MaxValue, limit.Period))
+	}
+	return changes
+}
+
 func (d *LimitsDiff) reconcileWith3scale(c *portaClient.ThreeScaleClient, serviceId string, planID string) error {
 
 	for _, limit := range d.MissingFromA {
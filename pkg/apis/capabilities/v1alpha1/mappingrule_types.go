@@ -161,7 +161,7 @@ func newInternalMappingRuleFromMappingRule(mappingRule MappingRule, c client.Cli
 		Path:      mappingRule.Spec.Path,
 		Method:    mappingRule.Spec.Method,
 		Increment: mappingRule.Spec.Increment,
-		Metric:    metric.Name,
+		Metric:    metric.threescaleName(),
 	}
 
 	return &internalMappingRule, nil
@@ -225,6 +225,18 @@ func diffMappingRules(mappingRules1, mappingRules2 []InternalMappingRule) Mappin
 	return mappingRuleDiff
 }
 
+// plannedChanges describes the changes reconcileWith3scale would make
+func (m MappingRuleDiff) plannedChanges() []string {
+	changes := []string{}
+	for _, mappingRule := range m.MissingFromB {
+		changes = append(changes, fmt.Sprintf("create mapping rule %s %s, metric %s increment %d", strings.ToUpper(mappingRule.Method), mappingRule.Path, mappingRule.Metric, mappingRule.Increment))
+	}
+	for _, mappingRule := range m.MissingFromA {
+		changes = append(changes, fmt.Sprintf("delete mapping rule %s %s, metric %s increment %d", strings.ToUpper(mappingRule.Method), mappingRule.Path, mappingRule.Metric, mappingRule.Increment))
+	}
+	return changes
+}
+
 func (m MappingRuleDiff) reconcileWith3scale(c *portaClient.ThreeScaleClient, serviceId string, api InternalAPI) error {
 	for _, mappingRule := range m.MissingFromB {
 		metric, err := metricNametoMetric(c, serviceId, mappingRule.Metric)
@@ -15,6 +15,9 @@ import (
 // MetricSpec defines the desired state of Metric
 // +k8s:openapi-gen=true
 type MetricSpec struct {
+	// Name of the metric in 3scale. Defaults to the object name
+	// +optional
+	Name          string `json:"name,omitempty"`
 	Unit          string `json:"unit"`
 	Description   string `json:"description"`
 	IncrementHits bool   `json:"incrementHits"`
@@ -113,6 +116,22 @@ func diffMetrics(metrics1, metrics2 []InternalMetric) MetricsDiff {
 	}
 	return metricsDiff
 }
+
+// plannedChanges describes the changes ReconcileWith3scale would make
+func (d *MetricsDiff) plannedChanges() []string {
+	changes := []string{}
+	for _, metric := range d.MissingFromB {
+		changes = append(changes, fmt.Sprintf("create metric %s", metric.Name))
+	}
+	for _, metric := range d.MissingFromA {
+		changes = append(changes, fmt.Sprintf("delete metric %s", metric.Name))
+	}
+	for _, metric := range d.NotEqual {
+		changes = append(changes, fmt.Sprintf("update metric %s: unit %q, description %q", metric.A.Name, metric.A.Unit, metric.A.Description))
+	}
+	return changes
+}
+
 func (d *MetricsDiff) ReconcileWith3scale(c *portaClient.ThreeScaleClient, serviceId string, api InternalAPI) error {
 
 	for _, metric := range d.MissingFromB {
@@ -206,9 +225,18 @@ func createInternalMetricIn3scale(c *portaClient.ThreeScaleClient, api InternalA
 	_, err = c.CreateMetric(service.ID, metric.Name, metric.Description, metric.Unit)
 	return err
 }
+
+// threescaleName returns the name of the metric in 3scale
+func (metric *Metric) threescaleName() string {
+	if metric.Spec.Name != "" {
+		return metric.Spec.Name
+	}
+	return metric.Name
+}
+
 func newInternalMetricFromMetric(metric Metric) *InternalMetric {
 	internalMetric := InternalMetric{
-		Name:        metric.Name,
+		Name:        metric.threescaleName(),
 		Unit:        metric.Spec.Unit,
 		Description: metric.Spec.Description,
 	}
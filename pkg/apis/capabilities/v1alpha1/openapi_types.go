@@ -0,0 +1,263 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OpenAPISpec references an OpenAPI 2 or 3 document, in JSON or YAML, describing the API.
+// One and only one of the fields has to be set
+// +k8s:openapi-gen=true
+type OpenAPISpec struct {
+	// Key of a ConfigMap with the document
+	// +optional
+	ConfigMapRef *v1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+	// Key of a Secret with the document
+	// +optional
+	SecretRef *v1.SecretKeySelector `json:"secretRef,omitempty"`
+	// The document itself
+	// +optional
+	Inline string `json:"inline,omitempty"`
+}
+
+// openAPIMethods are the operations of a path item mapped to 3scale mapping rules
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var openAPIMetricNameInvalidChars = regexp.MustCompile("[^a-zA-Z0-9]+")
+
+// openAPIDocument is the subset of an OpenAPI 2 or 3 document the metrics and
+// mapping rules are derived from
+type openAPIDocument struct {
+	Swagger  string                                `json:"swagger"`
+	OpenAPI  string                                `json:"openapi"`
+	BasePath string                                `json:"basePath"`
+	Servers  []openAPIServer                       `json:"servers"`
+	Paths    map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIOperation struct {
+	OperationID string `json:"operationId"`
+	Summary     string `json:"summary"`
+}
+
+// getDocument returns the referenced document, reading ConfigMaps and Secrets from the given namespace
+func (o *OpenAPISpec) getDocument(c client.Client, namespace string) ([]byte, error) {
+	refs := 0
+	for _, set := range []bool{o.ConfigMapRef != nil, o.SecretRef != nil, o.Inline != ""} {
+		if set {
+			refs++
+		}
+	}
+	if refs != 1 {
+		return nil, fmt.Errorf("one and only one of configMapRef, secretRef and inline has to be set")
+	}
+
+	if o.ConfigMapRef != nil {
+		configMap := &v1.ConfigMap{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: o.ConfigMapRef.Name, Namespace: namespace}, configMap)
+		if err != nil {
+			return nil, err
+		}
+		document, ok := configMap.Data[o.ConfigMapRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in OpenAPI configmap %s", o.ConfigMapRef.Key, o.ConfigMapRef.Name)
+		}
+		return []byte(document), nil
+	}
+
+	if o.SecretRef != nil {
+		secret := &v1.Secret{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: o.SecretRef.Name, Namespace: namespace}, secret)
+		if err != nil {
+			return nil, err
+		}
+		document, ok := secret.Data[o.SecretRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in OpenAPI secret %s", o.SecretRef.Key, o.SecretRef.Name)
+		}
+		return document, nil
+	}
+
+	return []byte(o.Inline), nil
+}
+
+// newInternalObjectsFromOpenAPI derives a metric and a mapping rule from every operation of the document.
+// The metric is named after the operationId or, when missing, after the method and the path.
+// Operations resulting in the same metric name are rejected, as 3scale metric names are case insensitive
+func newInternalObjectsFromOpenAPI(document []byte) ([]InternalMetric, []InternalMappingRule, error) {
+	jsonDocument, err := yaml.YAMLToJSON(document)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid OpenAPI document: %s", err)
+	}
+
+	openAPI := openAPIDocument{}
+	err = json.Unmarshal(jsonDocument, &openAPI)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid OpenAPI document: %s", err)
+	}
+
+	basePath, err := openAPI.basePath()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sorted, so the generated objects don't change between reconciliations
+	paths := []string{}
+	for path := range openAPI.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var metrics []InternalMetric
+	var mappingRules []InternalMappingRule
+	operations := map[string]string{}
+	for _, path := range paths {
+		pathItem := openAPI.Paths[path]
+		for _, method := range openAPIMethods {
+			rawOperation, ok := pathItem[method]
+			if !ok {
+				continue
+			}
+			operation := openAPIOperation{}
+			err := json.Unmarshal(rawOperation, &operation)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid OpenAPI operation %s %s: %s", strings.ToUpper(method), path, err)
+			}
+
+			metricName := openAPIMetricName(method, path, operation)
+			operationName := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+			if existing, ok := operations[strings.ToLower(metricName)]; ok {
+				return nil, nil, fmt.Errorf("OpenAPI operations %s and %s have the same metric name %s", existing, operationName, metricName)
+			}
+			operations[strings.ToLower(metricName)] = operationName
+
+			description := operation.Summary
+			if description == "" {
+				description = operationName
+			}
+
+			metrics = append(metrics, InternalMetric{
+				Name:        metricName,
+				Unit:        "hit",
+				Description: description,
+			})
+			// The path is anchored, so the rule doesn't match the paths of other operations too
+			mappingRules = append(mappingRules, InternalMappingRule{
+				Name:      metricName,
+				Path:      basePath + path + "$",
+				Method:    strings.ToUpper(method),
+				Increment: 1,
+				Metric:    metricName,
+			})
+		}
+	}
+
+	return metrics, mappingRules, nil
+}
+
+// basePath returns the path every path of the document is relative to, without trailing slash
+func (d openAPIDocument) basePath() (string, error) {
+	basePath := ""
+	switch {
+	case d.Swagger == "2.0":
+		basePath = d.BasePath
+	case strings.HasPrefix(d.OpenAPI, "3."):
+		if len(d.Servers) > 0 {
+			serverURL, err := url.Parse(d.Servers[0].URL)
+			if err != nil {
+				return "", fmt.Errorf("invalid OpenAPI server url %s: %s", d.Servers[0].URL, err)
+			}
+			basePath = serverURL.Path
+		}
+	default:
+		return "", fmt.Errorf("unsupported OpenAPI document version, expected swagger 2.0 or openapi 3")
+	}
+	return strings.TrimSuffix(basePath, "/"), nil
+}
+
+func openAPIMetricName(method, path string, operation openAPIOperation) string {
+	name := operation.OperationID
+	if name == "" {
+		name = fmt.Sprintf("%s_%s", method, path)
+	}
+	return strings.Trim(openAPIMetricNameInvalidChars.ReplaceAllString(name, "_"), "_")
+}
+
+// appendInternalMetric appends the metric unless there is already a metric with the same name,
+// returning the name of the metric in the list, which may differ in case
+func appendInternalMetric(metrics []InternalMetric, metric InternalMetric) ([]InternalMetric, string) {
+	for _, existing := range metrics {
+		if strings.EqualFold(existing.Name, metric.Name) {
+			return metrics, existing.Name
+		}
+	}
+	return append(metrics, metric), metric.Name
+}
+
+// appendInternalMappingRule appends the mapping rule unless there is already an equal one
+func appendInternalMappingRule(mappingRules []InternalMappingRule, mappingRule InternalMappingRule) []InternalMappingRule {
+	for _, existing := range mappingRules {
+		if strings.EqualFold(existing.Method, mappingRule.Method) &&
+			existing.Path == mappingRule.Path &&
+			existing.Increment == mappingRule.Increment &&
+			existing.Metric == mappingRule.Metric {
+			return mappingRules
+		}
+	}
+	return append(mappingRules, mappingRule)
+}
+
+// addOpenAPIObjects merges the metrics and mapping rules derived from the OpenAPI document
+// into the API. Metrics and mapping rules defined by objects take precedence
+func (api *InternalAPI) addOpenAPIObjects(openAPI *OpenAPISpec, namespace string, c client.Client) error {
+	document, err := openAPI.getDocument(c, namespace)
+	if err != nil {
+		return err
+	}
+
+	metrics, mappingRules, err := newInternalObjectsFromOpenAPI(document)
+	if err != nil {
+		return err
+	}
+
+	// Generated mapping rules increment the merged metric, whatever the case of its name
+	metricNames := map[string]string{}
+	for _, metric := range metrics {
+		// The Hits metric always exists, it is not part of the API metrics
+		if strings.ToLower(metric.Name) == "hits" {
+			metricNames[metric.Name] = "hits"
+			continue
+		}
+		api.Metrics, metricNames[metric.Name] = appendInternalMetric(api.Metrics, metric)
+	}
+	for idx := range mappingRules {
+		mappingRules[idx].Metric = metricNames[mappingRules[idx].Metric]
+	}
+
+	// Code plugins don't have mapping rules
+	if api.IntegrationMethod.ApicastHosted != nil {
+		for _, mappingRule := range mappingRules {
+			api.IntegrationMethod.ApicastHosted.MappingRules = appendInternalMappingRule(api.IntegrationMethod.ApicastHosted.MappingRules, mappingRule)
+		}
+	} else if api.IntegrationMethod.ApicastOnPrem != nil {
+		for _, mappingRule := range mappingRules {
+			api.IntegrationMethod.ApicastOnPrem.MappingRules = appendInternalMappingRule(api.IntegrationMethod.ApicastOnPrem.MappingRules, mappingRule)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testSwaggerDocument = `
+swagger: "2.0"
+info:
+  title: Pets
+  version: "1.0"
+basePath: /v1/
+paths:
+  /pets:
+    parameters: []
+    get:
+      operationId: listPets
+      summary: List all pets
+    post:
+      summary: Create a pet
+  /pets/{petId}:
+    get:
+      operationId: showPetById
+`
+
+const testOpenAPI3Document = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Pets", "version": "1.0"},
+  "servers": [{"url": "https://petstore.example.com/api"}],
+  "paths": {
+    "/pets": {"get": {"operationId": "listPets"}}
+  }
+}`
+
+func TestNewInternalObjectsFromOpenAPI(t *testing.T) {
+	metrics, mappingRules, err := newInternalObjectsFromOpenAPI([]byte(testSwaggerDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedMetrics := []InternalMetric{
+		{Name: "listPets", Unit: "hit", Description: "List all pets"},
+		{Name: "post_pets", Unit: "hit", Description: "Create a pet"},
+		{Name: "showPetById", Unit: "hit", Description: "GET /pets/{petId}"},
+	}
+	if !reflect.DeepEqual(metrics, expectedMetrics) {
+		t.Errorf("unexpected metrics.\ngot:\n%v\nexpected:\n%v", metrics, expectedMetrics)
+	}
+
+	expectedMappingRules := []InternalMappingRule{
+		{Name: "listPets", Path: "/v1/pets$", Method: "GET", Increment: 1, Metric: "listPets"},
+		{Name: "post_pets", Path: "/v1/pets$", Method: "POST", Increment: 1, Metric: "post_pets"},
+		{Name: "showPetById", Path: "/v1/pets/{petId}$", Method: "GET", Increment: 1, Metric: "showPetById"},
+	}
+	if !reflect.DeepEqual(mappingRules, expectedMappingRules) {
+		t.Errorf("unexpected mapping rules.\ngot:\n%v\nexpected:\n%v", mappingRules, expectedMappingRules)
+	}
+}
+
+func TestNewInternalObjectsFromOpenAPI3(t *testing.T) {
+	_, mappingRules, err := newInternalObjectsFromOpenAPI([]byte(testOpenAPI3Document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mappingRules) != 1 || mappingRules[0].Path != "/api/pets$" {
+		t.Errorf("unexpected mapping rules: %v", mappingRules)
+	}
+}
+
+func TestNewInternalObjectsFromOpenAPIInvalid(t *testing.T) {
+	duplicatedOperationID := `{
+  "swagger": "2.0",
+  "paths": {
+    "/pets": {"get": {"operationId": "listPets"}},
+    "/cats": {"get": {"operationId": "ListPets"}}
+  }
+}`
+	for _, document := range []string{"swagger: [", `{"swagger": "1.2", "paths": {}}`, duplicatedOperationID} {
+		_, _, err := newInternalObjectsFromOpenAPI([]byte(document))
+		if err == nil {
+			t.Errorf("expected error for document %s", document)
+		}
+	}
+}
+
+func TestAddOpenAPIObjects(t *testing.T) {
+	namespace := "someNS"
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "pets-openapi", Namespace: namespace},
+		Data:       map[string]string{"openapi.yaml": testSwaggerDocument},
+	}
+	cl := fake.NewFakeClient(configMap)
+
+	// Objects selected by label take precedence over the generated ones
+	listPets := InternalMetric{Name: "listPets", Unit: "call", Description: "defined by a Metric object"}
+	listPetsRule := InternalMappingRule{Name: "list-pets", Path: "/v1/pets$", Method: "get", Increment: 1, Metric: "listPets"}
+	api := plannedChangesTestAPI("api01", "pets", []InternalMetric{listPets}, []InternalMappingRule{listPetsRule}, nil)
+
+	openAPI := &OpenAPISpec{
+		ConfigMapRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "pets-openapi"}, Key: "openapi.yaml"},
+	}
+	err := api.addOpenAPIObjects(openAPI, namespace, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(api.Metrics) != 3 || api.Metrics[0] != listPets {
+		t.Errorf("unexpected metrics: %v", api.Metrics)
+	}
+	if mappingRules := api.getMappingRules(); len(mappingRules) != 3 || mappingRules[0] != listPetsRule {
+		t.Errorf("unexpected mapping rules: %v", mappingRules)
+	}
+}
+
+func TestAddOpenAPIObjectsMetricNameCase(t *testing.T) {
+	// Metric names are case insensitive, generated rules increment the metric defined by objects
+	showPet := InternalMetric{Name: "ShowPetById", Unit: "call", Description: "defined by a Metric object"}
+	api := plannedChangesTestAPI("api01", "pets", []InternalMetric{showPet}, nil, nil)
+
+	err := api.addOpenAPIObjects(&OpenAPISpec{Inline: testSwaggerDocument}, "someNS", fake.NewFakeClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(api.Metrics) != 3 || api.Metrics[0] != showPet {
+		t.Errorf("unexpected metrics: %v", api.Metrics)
+	}
+	for _, mappingRule := range api.getMappingRules() {
+		if mappingRule.Path == "/v1/pets/{petId}$" && mappingRule.Metric != "ShowPetById" {
+			t.Errorf("unexpected mapping rule metric: %v", mappingRule)
+		}
+	}
+}
+
+func TestOpenAPISpecGetDocument(t *testing.T) {
+	cl := fake.NewFakeClient()
+
+	cases := []struct {
+		testName    string
+		openAPI     *OpenAPISpec
+		expectedErr bool
+	}{
+		{"Inline", &OpenAPISpec{Inline: testOpenAPI3Document}, false},
+		{"NoDocument", &OpenAPISpec{}, true},
+		{"SecretNotFound", &OpenAPISpec{SecretRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "missing"}, Key: "openapi.json"}}, true},
+		{"InlineAndSecret", &OpenAPISpec{
+			Inline:    testOpenAPI3Document,
+			SecretRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "missing"}, Key: "openapi.json"},
+		}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			_, err := tc.openAPI.getDocument(cl, "someNS")
+			if tc.expectedErr != (err != nil) {
+				subT.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -24,9 +24,12 @@ type PlanSpec struct {
 }
 
 type PlanBase struct {
-	Default          bool  `json:"default"`
-	TrialPeriod      int64 `json:"trialPeriod"`
-	ApprovalRequired bool  `json:"approvalRequired"`
+	// Name of the plan in 3scale. Defaults to the object name
+	// +optional
+	Name             string `json:"name,omitempty"`
+	Default          bool   `json:"default"`
+	TrialPeriod      int64  `json:"trialPeriod"`
+	ApprovalRequired bool   `json:"approvalRequired"`
 	// +optional
 	Costs PlanCost `json:"costs,omitempty"`
 }
@@ -108,6 +111,29 @@ type planPair struct {
 	B InternalPlan
 }
 
+// plannedChanges describes the changes reconcileWith3scale would make
+func (d *plansDiff) plannedChanges() []string {
+	changes := []string{}
+	for _, plan := range d.MissingFromA {
+		changes = append(changes, fmt.Sprintf("delete plan %s", plan.Name))
+	}
+	for _, plan := range d.MissingFromB {
+		changes = append(changes, fmt.Sprintf("create plan %s", plan.Name))
+		limitsDiff := diffLimits(plan.Limits, nil)
+		for _, change := range limitsDiff.plannedChanges() {
+			changes = append(changes, fmt.Sprintf("plan %s: %s", plan.Name, change))
+		}
+	}
+	for _, planPair := range d.NotEqual {
+		changes = append(changes, fmt.Sprintf("update plan %s", planPair.A.Name))
+		limitsDiff := diffLimits(planPair.A.Limits, planPair.B.Limits)
+		for _, change := range limitsDiff.plannedChanges() {
+			changes = append(changes, fmt.Sprintf("plan %s: %s", planPair.A.Name, change))
+		}
+	}
+	return changes
+}
+
 func (d *plansDiff) reconcileWith3scale(c *portaClient.ThreeScaleClient, serviceId string, api InternalAPI) error {
 
 	for _, plan := range d.MissingFromA {
@@ -261,11 +287,20 @@ func getPlans(namespace string, matchLabels map[string]string, c client.Client)
 	err := c.List(context.TODO(), plans, opts...)
 	return plans, err
 }
+
+// threescaleName returns the name of the plan in 3scale
+func (plan *Plan) threescaleName() string {
+	if plan.Spec.Name != "" {
+		return plan.Spec.Name
+	}
+	return plan.Name
+}
+
 func newInternalPlanFromPlan(plan Plan, c client.Client) (*InternalPlan, error) {
 
 	// Fill the internal Plan with Plan and Limits.
 	internalPlan := InternalPlan{
-		Name:             plan.Name,
+		Name:             plan.threescaleName(),
 		Default:          plan.Spec.Default,
 		TrialPeriodDays:  plan.Spec.TrialPeriod,
 		ApprovalRequired: plan.Spec.ApprovalRequired,
@@ -286,11 +321,9 @@ func newInternalPlanFromPlan(plan Plan, c client.Client) (*InternalPlan, error)
 		for _, limit := range limits.Items {
 			internalLimit, err := newInternalLimitFromLimit(limit, c)
 			if err != nil {
-				//TODO: UPDATE STATUS OBJECT
-				log.Printf("limit %s couldn't be converted: %s", limit.Name, err)
-			} else {
-				internalPlan.Limits = append(internalPlan.Limits, *internalLimit)
+				return nil, fmt.Errorf("limit %s of plan %s couldn't be converted: %v", limit.Name, plan.Name, err)
 			}
+			internalPlan.Limits = append(internalPlan.Limits, *internalLimit)
 		}
 	}
 	return &internalPlan, nil
@@ -0,0 +1,370 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// APIcastPolicyName is the policy that authorizes and reports the traffic to 3scale.
+	// It is added at the end of the policy chain when no Policy object selects it
+	APIcastPolicyName = "apicast"
+	// BuiltinPolicyVersion is the version of the policies shipped with APIcast
+	BuiltinPolicyVersion = "builtin"
+)
+
+// PolicySpec defines the desired state of Policy
+// +k8s:openapi-gen=true
+type PolicySpec struct {
+	// Name of the APIcast policy, e.g. cors, headers, url_rewriting or ip_check
+	Name string `json:"name"`
+	// Version of the APIcast policy. Defaults to builtin
+	// +optional
+	Version string `json:"version,omitempty"`
+	// JSON configuration of the policy, as defined by the policy schema
+	// +optional
+	Configuration string `json:"configuration,omitempty"`
+	// Whether the policy is applied. Defaults to true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Position of the policy in the policy chain, lower positions run first.
+	// Policies with the same position are ordered by name
+	// +optional
+	Position int64 `json:"position,omitempty"`
+}
+
+// PolicyStatus defines the observed state of Policy
+// +k8s:openapi-gen=true
+type PolicyStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Policy is the Schema for the policies API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=policies,scope=Namespaced
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="Policy"
+type Policy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicySpec   `json:"spec,omitempty"`
+	Status PolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicyList contains a list of Policy
+type PolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Policy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Policy{}, &PolicyList{})
+}
+
+func getPolicies(namespace string, matchLabels map[string]string, c client.Client) (*PolicyList, error) {
+	policies := &PolicyList{}
+	opts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels(matchLabels),
+	}
+	err := c.List(context.TODO(), policies, opts...)
+	return policies, err
+}
+
+// newInternalPoliciesFromSelector returns the policy chain of the policy objects matching the selector.
+// Without selector, the API has the default chain
+func newInternalPoliciesFromSelector(namespace string, selector *metav1.LabelSelector, c client.Client) ([]InternalPolicy, error) {
+	if selector == nil {
+		return nil, nil
+	}
+
+	policies, err := getPolicies(namespace, selector.MatchLabels, c)
+	if err != nil && errors.IsNotFound(err) {
+		// Nothing has been found
+		log.Printf("Error: %s\n", err)
+		return nil, nil
+	} else if err != nil {
+		// Something is broken
+		return nil, err
+	}
+
+	return newInternalPoliciesFromPolicies(policies.Items)
+}
+
+// newInternalPoliciesFromPolicies returns the policy chain of the policy objects, ordered by position.
+// The apicast policy is appended when it is not part of the chain. The default chain,
+// only the apicast policy, is returned as nil
+func newInternalPoliciesFromPolicies(policies []Policy) ([]InternalPolicy, error) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		if policies[i].Spec.Position != policies[j].Spec.Position {
+			return policies[i].Spec.Position < policies[j].Spec.Position
+		}
+		return policies[i].Name < policies[j].Name
+	})
+
+	var internalPolicies []InternalPolicy
+	for _, policy := range policies {
+		internalPolicy, err := newInternalPolicyFromPolicy(policy)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s couldn't be converted: %s", policy.Name, err)
+		}
+		internalPolicies = append(internalPolicies, *internalPolicy)
+	}
+
+	internalPolicies = withAPIcastPolicy(internalPolicies)
+	if isDefaultPolicyChain(internalPolicies) {
+		return nil, nil
+	}
+	return internalPolicies, nil
+}
+
+func newInternalPolicyFromPolicy(policy Policy) (*InternalPolicy, error) {
+	version := policy.Spec.Version
+	if version == "" {
+		version = BuiltinPolicyVersion
+	}
+
+	enabled := true
+	if policy.Spec.Enabled != nil {
+		enabled = *policy.Spec.Enabled
+	}
+
+	configuration, err := normalizePolicyConfiguration(policy.Spec.Configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InternalPolicy{
+		Name:          policy.Spec.Name,
+		Version:       version,
+		Configuration: configuration,
+		Enabled:       enabled,
+	}, nil
+}
+
+// newInternalPoliciesFrom3scale parses the policies_config of a 3scale proxy.
+// The default chain, only the apicast policy, and the empty chain, which APIcast
+// replaces by the default one, are returned as nil
+func newInternalPoliciesFrom3scale(policiesConfig string) ([]InternalPolicy, error) {
+	if policiesConfig == "" {
+		return nil, nil
+	}
+
+	var chain []policyChainElement
+	err := json.Unmarshal([]byte(policiesConfig), &chain)
+	if err != nil {
+		return nil, err
+	}
+
+	var internalPolicies []InternalPolicy
+	for _, element := range chain {
+		configuration, err := normalizePolicyConfiguration(string(element.Configuration))
+		if err != nil {
+			return nil, err
+		}
+		// Policies are enabled unless stated otherwise
+		enabled := element.Enabled == nil || *element.Enabled
+		internalPolicies = append(internalPolicies, InternalPolicy{
+			Name:          element.Name,
+			Version:       element.Version,
+			Configuration: configuration,
+			Enabled:       enabled,
+		})
+	}
+
+	if isDefaultPolicyChain(internalPolicies) {
+		return nil, nil
+	}
+	return internalPolicies, nil
+}
+
+// get3scalePoliciesConfig returns the policies_config of the 3scale proxy for the policy chain.
+// An empty chain is the default chain, only the apicast policy
+func get3scalePoliciesConfig(policies []InternalPolicy) (string, error) {
+	chain := []policyChainElement{}
+	for _, policy := range withAPIcastPolicy(policies) {
+		configuration := json.RawMessage(policy.Configuration)
+		if policy.Configuration == "" {
+			configuration = json.RawMessage("{}")
+		}
+		enabled := policy.Enabled
+		chain = append(chain, policyChainElement{
+			Name:          policy.Name,
+			Version:       policy.Version,
+			Configuration: configuration,
+			Enabled:       &enabled,
+		})
+	}
+
+	policiesConfig, err := json.Marshal(chain)
+	if err != nil {
+		return "", err
+	}
+	return string(policiesConfig), nil
+}
+
+// normalizePolicyConfiguration validates the JSON configuration and returns it in
+// its compact form, with sorted keys, so equal configurations compare as equal strings
+func normalizePolicyConfiguration(configuration string) (string, error) {
+	if configuration == "" {
+		return "", nil
+	}
+
+	var value interface{}
+	err := json.Unmarshal([]byte(configuration), &value)
+	if err != nil {
+		return "", fmt.Errorf("invalid configuration: %s", err)
+	}
+	if value == nil {
+		return "", nil
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid configuration: not a JSON object")
+	}
+	if len(object) == 0 {
+		return "", nil
+	}
+
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+func withAPIcastPolicy(policies []InternalPolicy) []InternalPolicy {
+	if len(policies) == 0 {
+		return []InternalPolicy{defaultAPIcastPolicy()}
+	}
+	for _, policy := range policies {
+		if policy.Name == APIcastPolicyName {
+			return policies
+		}
+	}
+	return append(policies, defaultAPIcastPolicy())
+}
+
+func isDefaultPolicyChain(policies []InternalPolicy) bool {
+	return len(policies) == 1 && policies[0] == defaultAPIcastPolicy()
+}
+
+func defaultAPIcastPolicy() InternalPolicy {
+	return InternalPolicy{
+		Name:    APIcastPolicyName,
+		Version: BuiltinPolicyVersion,
+		Enabled: true,
+	}
+}
+
+// policyChainElement is an element of the policies_config of the 3scale proxy
+type policyChainElement struct {
+	Name          string          `json:"name"`
+	Version       string          `json:"version"`
+	Configuration json.RawMessage `json:"configuration"`
+	Enabled       *bool           `json:"enabled,omitempty"`
+}
+
+type InternalPolicy struct {
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	Configuration string `json:"configuration,omitempty"`
+	Enabled       bool   `json:"enabled"`
+}
+
+// PolicyDiff holds the differences between two policy chains
+type PolicyDiff struct {
+	MissingFromA []InternalPolicy
+	MissingFromB []InternalPolicy
+	NotEqual     []PolicyPair
+	// The policies of both chains are the same, in a different order
+	Reordered bool
+}
+type PolicyPair struct {
+	A InternalPolicy
+	B InternalPolicy
+}
+
+// diffPolicies compares two policy chains. Policies are matched by name
+func diffPolicies(policies1, policies2 []InternalPolicy) PolicyDiff {
+	var policyDiff PolicyDiff
+
+	policies1 = withAPIcastPolicy(policies1)
+	policies2 = withAPIcastPolicy(policies2)
+
+	for _, policy1 := range policies1 {
+		found := false
+		for _, policy2 := range policies2 {
+			if policy1.Name == policy2.Name {
+				if policy1 != policy2 {
+					policyDiff.NotEqual = append(policyDiff.NotEqual, PolicyPair{A: policy1, B: policy2})
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			policyDiff.MissingFromB = append(policyDiff.MissingFromB, policy1)
+		}
+	}
+
+	for _, policy2 := range policies2 {
+		found := false
+		for _, policy1 := range policies1 {
+			if policy1.Name == policy2.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			policyDiff.MissingFromA = append(policyDiff.MissingFromA, policy2)
+		}
+	}
+
+	// The order is only compared between chains with the same policies
+	if len(policyDiff.MissingFromA) == 0 && len(policyDiff.MissingFromB) == 0 && len(policies1) == len(policies2) {
+		for i := range policies1 {
+			if policies1[i].Name != policies2[i].Name {
+				policyDiff.Reordered = true
+				break
+			}
+		}
+	}
+
+	return policyDiff
+}
+
+// plannedChanges describes the changes of the policy chain the proxy update would make
+func (p PolicyDiff) plannedChanges() []string {
+	changes := []string{}
+	for _, policy := range p.MissingFromB {
+		changes = append(changes, fmt.Sprintf("add policy %s %s", policy.Name, policy.Version))
+	}
+	for _, policyPair := range p.NotEqual {
+		changes = append(changes, fmt.Sprintf("update policy %s %s", policyPair.A.Name, policyPair.A.Version))
+	}
+	for _, policy := range p.MissingFromA {
+		changes = append(changes, fmt.Sprintf("remove policy %s %s", policy.Name, policy.Version))
+	}
+	if p.Reordered {
+		changes = append(changes, "reorder policy chain")
+	}
+	return changes
+}
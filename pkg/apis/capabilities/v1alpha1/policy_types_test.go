@@ -0,0 +1,143 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testPolicy(objectName, name, configuration string, position int64) Policy {
+	return Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: objectName},
+		Spec: PolicySpec{
+			Name:          name,
+			Configuration: configuration,
+			Position:      position,
+		},
+	}
+}
+
+func TestNewInternalPoliciesFromPolicies(t *testing.T) {
+	disabled := false
+	ipCheck := testPolicy("api01-ip-check", "ip_check", "", 2)
+	ipCheck.Spec.Enabled = &disabled
+
+	policies := []Policy{
+		ipCheck,
+		testPolicy("api01-headers", "headers", "", 1),
+		testPolicy("api01-cors", "cors", `{ "allow_methods": ["GET"],  "allow_origin": "*" }`, 1),
+	}
+
+	internalPolicies, err := newInternalPoliciesFromPolicies(policies)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []InternalPolicy{
+		{Name: "cors", Version: "builtin", Configuration: `{"allow_methods":["GET"],"allow_origin":"*"}`, Enabled: true},
+		{Name: "headers", Version: "builtin", Enabled: true},
+		{Name: "ip_check", Version: "builtin", Enabled: false},
+		{Name: "apicast", Version: "builtin", Enabled: true},
+	}
+	if !reflect.DeepEqual(internalPolicies, expected) {
+		t.Errorf("unexpected policy chain.\ngot:\n%v\nexpected:\n%v", internalPolicies, expected)
+	}
+}
+
+func TestNewInternalPoliciesFromPoliciesDefaultChain(t *testing.T) {
+	cases := []struct {
+		testName string
+		policies []Policy
+	}{
+		{"NoPolicies", nil},
+		{"OnlyAPIcast", []Policy{testPolicy("api01-apicast", "apicast", "{}", 0)}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			internalPolicies, err := newInternalPoliciesFromPolicies(tc.policies)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if internalPolicies != nil {
+				subT.Errorf("expected the default chain as nil, got: %v", internalPolicies)
+			}
+		})
+	}
+}
+
+func TestNewInternalPoliciesFromPoliciesInvalidConfiguration(t *testing.T) {
+	for _, configuration := range []string{"{", `["allow_origin"]`} {
+		_, err := newInternalPoliciesFromPolicies([]Policy{testPolicy("api01-cors", "cors", configuration, 0)})
+		if err == nil {
+			t.Errorf("expected error for configuration %s", configuration)
+		}
+	}
+}
+
+func TestPoliciesConfigFrom3scale(t *testing.T) {
+	policies := []InternalPolicy{
+		{Name: "apicast", Version: "builtin", Enabled: true},
+		{Name: "headers", Version: "builtin", Configuration: `{"request":[{"header":"X-Custom","op":"set","value":"1"}]}`, Enabled: false},
+	}
+
+	policiesConfig, err := get3scalePoliciesConfig(policies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	internalPolicies, err := newInternalPoliciesFrom3scale(policiesConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(internalPolicies, policies) {
+		t.Errorf("unexpected policy chain.\ngot:\n%v\nexpected:\n%v", internalPolicies, policies)
+	}
+
+	for _, defaultConfig := range []string{"", "[]", `[{"name":"apicast","version":"builtin","configuration":{}}]`} {
+		internalPolicies, err := newInternalPoliciesFrom3scale(defaultConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if internalPolicies != nil {
+			t.Errorf("expected the default chain of %s as nil, got: %v", defaultConfig, internalPolicies)
+		}
+	}
+}
+
+func TestDiffPoliciesPlannedChanges(t *testing.T) {
+	cors := InternalPolicy{Name: "cors", Version: "builtin", Enabled: true}
+	headers := InternalPolicy{Name: "headers", Version: "builtin", Enabled: true}
+	ipCheck := InternalPolicy{Name: "ip_check", Version: "builtin", Enabled: true}
+	apicast := defaultAPIcastPolicy()
+
+	disabledCors := cors
+	disabledCors.Enabled = false
+
+	cases := []struct {
+		testName string
+		desired  []InternalPolicy
+		existing []InternalPolicy
+		expected []string
+	}{
+		{"InSync", []InternalPolicy{cors, apicast}, []InternalPolicy{cors, apicast}, []string{}},
+		{"DefaultChain", nil, []InternalPolicy{apicast}, []string{}},
+		{"Changes", []InternalPolicy{disabledCors, headers, apicast}, []InternalPolicy{cors, ipCheck, apicast}, []string{
+			"add policy headers builtin",
+			"update policy cors builtin",
+			"remove policy ip_check builtin",
+		}},
+		{"Reordered", []InternalPolicy{headers, cors, apicast}, []InternalPolicy{cors, headers, apicast}, []string{
+			"reorder policy chain",
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			plannedChanges := diffPolicies(tc.desired, tc.existing).plannedChanges()
+			if !reflect.DeepEqual(plannedChanges, tc.expected) {
+				subT.Errorf("unexpected planned changes.\ngot:\n%v\nexpected:\n%v", plannedChanges, tc.expected)
+			}
+		})
+	}
+}
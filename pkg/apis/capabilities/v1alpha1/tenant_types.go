@@ -1,16 +1,20 @@
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+const TENANT_FINALIZER = "tenant.capabilities.3scale.net"
+
 // TenantSpec defines the desired state of Tenant
 // +k8s:openapi-gen=true
 type TenantSpec struct {
@@ -21,6 +25,17 @@ type TenantSpec struct {
 	TenantSecretRef        v1.SecretReference `json:"tenantSecretRef"`
 	PasswordCredentialsRef v1.SecretReference `json:"passwordCredentialsRef"`
 	MasterCredentialsRef   v1.SecretReference `json:"masterCredentialsRef"`
+	// Verification of the certificate of the 3scale master portal
+	// +optional
+	TLS *TLSSpec `json:"tls,omitempty"`
+	// What happens to the 3scale tenant account when the Tenant is deleted.
+	// Delete schedules the account for deletion in 3scale. Defaults to Orphan
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// Increase to regenerate the provider key of the tenant and write it
+	// into the tenant secret
+	// +optional
+	AccessTokenRotation int64 `json:"accessTokenRotation,omitempty"`
 }
 
 // TenantStatus defines the observed state of Tenant
@@ -28,6 +43,110 @@ type TenantSpec struct {
 type TenantStatus struct {
 	TenantId int64 `json:"tenantId"`
 	AdminId  int64 `json:"adminId"`
+	// URL of the admin portal of the tenant
+	// +optional
+	AdminURL string `json:"adminURL,omitempty"`
+	// Current state of the Tenant
+	// +optional
+	Conditions []TenantCondition `json:"conditions,omitempty"`
+	// Generation of the Tenant last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Resource version of the admin password secret last set to the admin user
+	// +optional
+	PasswordSecretVersion string `json:"passwordSecretVersion,omitempty"`
+	// Last accessTokenRotation of the spec applied to the tenant secret
+	// +optional
+	AccessTokenRotation int64 `json:"accessTokenRotation,omitempty"`
+}
+
+type TenantConditionType string
+
+const (
+	// TenantReady means the tenant account, its admin user and the tenant
+	// secret match the Tenant
+	TenantReady TenantConditionType = "Ready"
+	// TenantFailed means the last reconciliation of the Tenant failed. The
+	// reason and message of the condition contain the details
+	TenantFailed TenantConditionType = "Failed"
+)
+
+type TenantCondition struct {
+	Type   TenantConditionType `json:"type" description:"type of Tenant condition"`
+	Status v1.ConditionStatus  `json:"status" description:"status of the condition, one of True, False, Unknown"`
+
+	// +optional
+	Reason string `json:"reason,omitempty" description:"one-word CamelCase reason for the condition's last transition"`
+	// +optional
+	Message string `json:"message,omitempty" description:"human-readable message indicating details about last transition"`
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" description:"last time the condition transit from one status to another"`
+}
+
+// GetCondition returns the condition of the given type or nil if it does not exist
+func (s *TenantStatus) GetCondition(conditionType TenantConditionType) *TenantCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns true when the condition of the given type exists and its status is True
+func (s *TenantStatus) IsConditionTrue(conditionType TenantConditionType) bool {
+	condition := s.GetCondition(conditionType)
+	return condition != nil && condition.Status == v1.ConditionTrue
+}
+
+// SetCondition adds or updates the condition with the same type. The
+// LastTransitionTime is only updated when the status of the condition changes.
+// Returns true if the status was changed
+func (s *TenantStatus) SetCondition(newCondition TenantCondition) bool {
+	existing := s.GetCondition(newCondition.Type)
+	if existing == nil {
+		if newCondition.LastTransitionTime.IsZero() {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+		s.Conditions = append(s.Conditions, newCondition)
+		return true
+	}
+
+	changed := false
+	if existing.Status != newCondition.Status {
+		existing.Status = newCondition.Status
+		existing.LastTransitionTime = newCondition.LastTransitionTime
+		if existing.LastTransitionTime.IsZero() {
+			existing.LastTransitionTime = metav1.Now()
+		}
+		changed = true
+	}
+
+	if existing.Reason != newCondition.Reason {
+		existing.Reason = newCondition.Reason
+		changed = true
+	}
+
+	if existing.Message != newCondition.Message {
+		existing.Message = newCondition.Message
+		changed = true
+	}
+
+	return changed
+}
+
+// SetReady sets the Ready condition and clears the Failed one. Returns
+// true if the status was changed
+func (s *TenantStatus) SetReady() bool {
+	changed := s.SetCondition(TenantCondition{Type: TenantReady, Status: v1.ConditionTrue, Reason: "Ready"})
+	return s.SetCondition(TenantCondition{Type: TenantFailed, Status: v1.ConditionFalse}) || changed
+}
+
+// SetFailed sets the Failed condition with the given reason and message and
+// clears the Ready one. Returns true if the status was changed
+func (s *TenantStatus) SetFailed(reason, message string) bool {
+	changed := s.SetCondition(TenantCondition{Type: TenantReady, Status: v1.ConditionFalse, Reason: reason})
+	return s.SetCondition(TenantCondition{Type: TenantFailed, Status: v1.ConditionTrue, Reason: reason, Message: message}) || changed
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -60,6 +179,31 @@ func (t *Tenant) SetDefaults() bool {
 	return changed
 }
 
+// GetDeletionPolicy returns the deletion policy of the tenant account, which defaults to Orphan
+func (t Tenant) GetDeletionPolicy() DeletionPolicy {
+	if t.Spec.DeletionPolicy == "" {
+		return DeletionPolicyOrphan
+	}
+	return t.Spec.DeletionPolicy
+}
+
+// HasFinalizer checks if the tenant object has the tenant finalizer set
+func (t Tenant) HasFinalizer() bool {
+	return hasFinalizer(t.Finalizers, TENANT_FINALIZER)
+}
+
+// AddFinalizer adds the tenant finalizer to the meta of the tenant object
+func (t *Tenant) AddFinalizer(c client.Client) error {
+	t.SetFinalizers(append(t.GetFinalizers(), TENANT_FINALIZER))
+	return c.Update(context.TODO(), t)
+}
+
+// RemoveFinalizer removes the tenant finalizer from the meta of the tenant object
+func (t *Tenant) RemoveFinalizer(c client.Client) error {
+	t.SetFinalizers(withoutFinalizer(t.GetFinalizers(), TENANT_FINALIZER))
+	return c.Update(context.TODO(), t)
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // TenantList contains a list of Tenant
@@ -0,0 +1,89 @@
+package v1alpha1
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TLSSpec defines how the certificate of the 3scale portal is verified
+// +k8s:openapi-gen=true
+type TLSSpec struct {
+	// Key of a Secret with PEM encoded CA certificates trusted on top of the system CAs
+	// +optional
+	CABundleSecretRef *v1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+	// Key of a ConfigMap with PEM encoded CA certificates trusted on top of the system CAs
+	// +optional
+	CABundleConfigMapRef *v1.ConfigMapKeySelector `json:"caBundleConfigMapRef,omitempty"`
+	// Disables the verification of the certificate of the 3scale portal.
+	// Only meant for testing, credentials are sent over an unverified connection
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// Validate checks the CA bundle is referenced at most once
+func (s *TLSSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.CABundleSecretRef != nil && s.CABundleConfigMapRef != nil {
+		return fmt.Errorf("caBundleSecretRef and caBundleConfigMapRef are mutually exclusive")
+	}
+	return nil
+}
+
+// NewTLSConfig returns the TLS configuration of the porta client.
+// The CA bundle is read from the given namespace. A nil TLSSpec verifies the
+// certificate with the system CAs
+func (s *TLSSpec) NewTLSConfig(c client.Client, namespace string) (*tls.Config, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	err := s.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	caBundle, err := s.getCABundle(c, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.TLSConfig(caBundle, s.InsecureSkipVerify)
+}
+
+func (s *TLSSpec) getCABundle(c client.Client, namespace string) ([]byte, error) {
+	if s.CABundleSecretRef != nil {
+		secret := &v1.Secret{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: s.CABundleSecretRef.Name, Namespace: namespace}, secret)
+		if err != nil {
+			return nil, err
+		}
+		caBundle, ok := secret.Data[s.CABundleSecretRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in CA bundle secret %s", s.CABundleSecretRef.Key, s.CABundleSecretRef.Name)
+		}
+		return caBundle, nil
+	}
+
+	if s.CABundleConfigMapRef != nil {
+		configMap := &v1.ConfigMap{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: s.CABundleConfigMapRef.Name, Namespace: namespace}, configMap)
+		if err != nil {
+			return nil, err
+		}
+		caBundle, ok := configMap.Data[s.CABundleConfigMapRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in CA bundle configmap %s", s.CABundleConfigMapRef.Key, s.CABundleConfigMapRef.Name)
+		}
+		return []byte(caBundle), nil
+	}
+
+	return nil, nil
+}
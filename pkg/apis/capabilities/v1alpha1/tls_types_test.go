@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testCABundle(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "3scale-test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestTLSSpecNewTLSConfig(t *testing.T) {
+	namespace := "someNS"
+	caBundle := testCABundle(t)
+
+	objs := []runtime.Object{
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca-secret", Namespace: namespace},
+			Data:       map[string][]byte{"ca.crt": caBundle, "invalid.crt": []byte("not a certificate")},
+		},
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca-configmap", Namespace: namespace},
+			Data:       map[string]string{"ca.crt": string(caBundle)},
+		},
+	}
+	cl := fake.NewFakeClient(objs...)
+
+	secretRef := func(key string) *v1.SecretKeySelector {
+		return &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "ca-secret"}, Key: key}
+	}
+	configMapRef := &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "ca-configmap"}, Key: "ca.crt"}
+
+	cases := []struct {
+		testName         string
+		spec             *TLSSpec
+		expectedErr      bool
+		expectedRootCAs  bool
+		expectedInsecure bool
+	}{
+		{"Secret", &TLSSpec{CABundleSecretRef: secretRef("ca.crt")}, false, true, false},
+		{"ConfigMap", &TLSSpec{CABundleConfigMapRef: configMapRef}, false, true, false},
+		{"InsecureSkipVerify", &TLSSpec{InsecureSkipVerify: true}, false, false, true},
+		{"MissingKey", &TLSSpec{CABundleSecretRef: secretRef("missing.crt")}, true, false, false},
+		{"InvalidBundle", &TLSSpec{CABundleSecretRef: secretRef("invalid.crt")}, true, false, false},
+		{"BothRefs", &TLSSpec{CABundleSecretRef: secretRef("ca.crt"), CABundleConfigMapRef: configMapRef}, true, false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			tlsConfig, err := tc.spec.NewTLSConfig(cl, namespace)
+			if tc.expectedErr != (err != nil) {
+				subT.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if tc.expectedRootCAs != (tlsConfig.RootCAs != nil) {
+				subT.Errorf("expected root CAs: %t, got: %v", tc.expectedRootCAs, tlsConfig.RootCAs)
+			}
+			if tlsConfig.InsecureSkipVerify != tc.expectedInsecure {
+				subT.Errorf("unexpected InsecureSkipVerify: %t", tlsConfig.InsecureSkipVerify)
+			}
+		})
+	}
+}
+
+func TestTLSSpecNewTLSConfigNil(t *testing.T) {
+	var spec *TLSSpec
+	tlsConfig, err := spec.NewTLSConfig(fake.NewFakeClient(), "someNS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The default transport verifies the certificate with the system CAs
+	if tlsConfig != nil {
+		t.Errorf("expected nil TLS configuration, got: %v", tlsConfig)
+	}
+}
@@ -11,27 +11,38 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.API":               schema_pkg_apis_capabilities_v1alpha1_API(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.APISpec":           schema_pkg_apis_capabilities_v1alpha1_APISpec(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.APIStatus":         schema_pkg_apis_capabilities_v1alpha1_APIStatus(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Binding":           schema_pkg_apis_capabilities_v1alpha1_Binding(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingSpec":       schema_pkg_apis_capabilities_v1alpha1_BindingSpec(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingStatus":     schema_pkg_apis_capabilities_v1alpha1_BindingStatus(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Limit":             schema_pkg_apis_capabilities_v1alpha1_Limit(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.LimitSpec":         schema_pkg_apis_capabilities_v1alpha1_LimitSpec(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.LimitStatus":       schema_pkg_apis_capabilities_v1alpha1_LimitStatus(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MappingRule":       schema_pkg_apis_capabilities_v1alpha1_MappingRule(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MappingRuleSpec":   schema_pkg_apis_capabilities_v1alpha1_MappingRuleSpec(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MappingRuleStatus": schema_pkg_apis_capabilities_v1alpha1_MappingRuleStatus(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Metric":            schema_pkg_apis_capabilities_v1alpha1_Metric(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MetricSpec":        schema_pkg_apis_capabilities_v1alpha1_MetricSpec(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MetricStatus":      schema_pkg_apis_capabilities_v1alpha1_MetricStatus(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Plan":              schema_pkg_apis_capabilities_v1alpha1_Plan(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PlanSpec":          schema_pkg_apis_capabilities_v1alpha1_PlanSpec(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PlanStatus":        schema_pkg_apis_capabilities_v1alpha1_PlanStatus(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Tenant":            schema_pkg_apis_capabilities_v1alpha1_Tenant(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TenantSpec":        schema_pkg_apis_capabilities_v1alpha1_TenantSpec(ref),
-		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TenantStatus":      schema_pkg_apis_capabilities_v1alpha1_TenantStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.API":                    schema_pkg_apis_capabilities_v1alpha1_API(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.APISpec":                schema_pkg_apis_capabilities_v1alpha1_APISpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.APIStatus":              schema_pkg_apis_capabilities_v1alpha1_APIStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Application":            schema_pkg_apis_capabilities_v1alpha1_Application(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.ApplicationSpec":        schema_pkg_apis_capabilities_v1alpha1_ApplicationSpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.ApplicationStatus":      schema_pkg_apis_capabilities_v1alpha1_ApplicationStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Binding":                schema_pkg_apis_capabilities_v1alpha1_Binding(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingSpec":            schema_pkg_apis_capabilities_v1alpha1_BindingSpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingStatus":          schema_pkg_apis_capabilities_v1alpha1_BindingStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.DeveloperAccount":       schema_pkg_apis_capabilities_v1alpha1_DeveloperAccount(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.DeveloperAccountSpec":   schema_pkg_apis_capabilities_v1alpha1_DeveloperAccountSpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.DeveloperAccountStatus": schema_pkg_apis_capabilities_v1alpha1_DeveloperAccountStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Limit":                  schema_pkg_apis_capabilities_v1alpha1_Limit(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.LimitSpec":              schema_pkg_apis_capabilities_v1alpha1_LimitSpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.LimitStatus":            schema_pkg_apis_capabilities_v1alpha1_LimitStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MappingRule":            schema_pkg_apis_capabilities_v1alpha1_MappingRule(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MappingRuleSpec":        schema_pkg_apis_capabilities_v1alpha1_MappingRuleSpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MappingRuleStatus":      schema_pkg_apis_capabilities_v1alpha1_MappingRuleStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Metric":                 schema_pkg_apis_capabilities_v1alpha1_Metric(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MetricSpec":             schema_pkg_apis_capabilities_v1alpha1_MetricSpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.MetricStatus":           schema_pkg_apis_capabilities_v1alpha1_MetricStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.OpenAPISpec":            schema_pkg_apis_capabilities_v1alpha1_OpenAPISpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Plan":                   schema_pkg_apis_capabilities_v1alpha1_Plan(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PlanSpec":               schema_pkg_apis_capabilities_v1alpha1_PlanSpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PlanStatus":             schema_pkg_apis_capabilities_v1alpha1_PlanStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Policy":                 schema_pkg_apis_capabilities_v1alpha1_Policy(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PolicySpec":             schema_pkg_apis_capabilities_v1alpha1_PolicySpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PolicyStatus":           schema_pkg_apis_capabilities_v1alpha1_PolicyStatus(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TLSSpec":                schema_pkg_apis_capabilities_v1alpha1_TLSSpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.Tenant":                 schema_pkg_apis_capabilities_v1alpha1_Tenant(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TenantSpec":             schema_pkg_apis_capabilities_v1alpha1_TenantSpec(ref),
+		"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TenantStatus":           schema_pkg_apis_capabilities_v1alpha1_TenantStatus(ref),
 	}
 }
 
@@ -107,12 +118,32 @@ func schema_pkg_apis_capabilities_v1alpha1_APISpec(ref common.ReferenceCallback)
 							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
+					"openapi": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OpenAPI document the metrics and mapping rules of the API are derived from, on top of the ones selected by label",
+							Ref:         ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.OpenAPISpec"),
+						},
+					},
+					"deletionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "What happens to the 3scale service when the API is no longer selected by the Binding or the Binding is deleted. Defaults to the Binding deletionPolicy",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"adopt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "When set, an existing 3scale service with the same system name is adopted by the Binding and managed from then on. Otherwise, existing services not created by the Binding are left untouched",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"description", "integrationMethod"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.IntegrationMethod", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.IntegrationMethod", "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.OpenAPISpec", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -127,6 +158,138 @@ func schema_pkg_apis_capabilities_v1alpha1_APIStatus(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_capabilities_v1alpha1_Application(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Application is the Schema for the applications API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.ApplicationSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.ApplicationStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.ApplicationSpec", "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.ApplicationStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_capabilities_v1alpha1_ApplicationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationSpec defines the desired state of Application",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"accountRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeveloperAccount the application belongs to",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"apiRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "API of the plan. The application is created in the 3scale service of the API",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"planRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Plan the application is subscribed to. It has to be selected by the API",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"credentialsSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret the credentials of the application are written to. Defaults to the name of the application",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"accountRef", "apiRef", "planRef"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
+func schema_pkg_apis_capabilities_v1alpha1_ApplicationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationStatus defines the observed state of Application",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"applicationID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID of the 3scale application",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"planID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID of the 3scale plan the application is subscribed to",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State of the 3scale application, like live or pending",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Why the application could not be provisioned",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_capabilities_v1alpha1_Binding(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -188,12 +351,39 @@ func schema_pkg_apis_capabilities_v1alpha1_BindingSpec(ref common.ReferenceCallb
 							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "When set, the changes needed to sync the APIs are only published in the status. 3scale is not modified",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verification of the certificate of the 3scale admin portal",
+							Ref:         ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TLSSpec"),
+						},
+					},
+					"deletionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "What happens to the 3scale services owned by the Binding when their APIs are no longer selected or the Binding is deleted. Defaults to Delete",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"syncPeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How often, in seconds, 3scale is read to detect and revert the changes made outside the Binding. Defaults to 60",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 				Required: []string{"credentialsRef"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.SecretReference", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TLSSpec", "k8s.io/api/core/v1.SecretReference", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -211,27 +401,203 @@ func schema_pkg_apis_capabilities_v1alpha1_BindingStatus(ref common.ReferenceCal
 					},
 					"currentState": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Deprecated: JSON encoded state of the previous versions of the binding. It is only read to migrate the binding and removed afterwards",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"desiredState": {
+					"conditions": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Current state of the Binding",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingCondition"),
+									},
+								},
+							},
 						},
 					},
-					"previousState": {
+					"apis": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Sync result of every API selected by the Binding",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingAPIStatus"),
+									},
+								},
+							},
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generation of the Binding last reconciled",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"plannedChanges": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Changes needed to sync the APIs. Only set in dry run mode",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"services": {
+						SchemaProps: spec.SchemaProps{
+							Description: "3scale services created or adopted by the Binding. Only these services are ever modified or deleted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingService"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingAPIStatus", "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingCondition", "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.BindingService", "k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp"},
+	}
+}
+
+func schema_pkg_apis_capabilities_v1alpha1_DeveloperAccount(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeveloperAccount is the Schema for the developeraccounts API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.DeveloperAccountSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.DeveloperAccountStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp"},
+			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.DeveloperAccountSpec", "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.DeveloperAccountStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_capabilities_v1alpha1_DeveloperAccountSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeveloperAccountSpec defines the desired state of DeveloperAccount",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"credentialsRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret with the credentials of the 3scale tenant, like the Binding one",
+							Ref:         ref("k8s.io/api/core/v1.SecretReference"),
+						},
+					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verification of the certificate of the 3scale admin portal",
+							Ref:         ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TLSSpec"),
+						},
+					},
+					"organizationName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Organization name of the account",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"username": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Username of the admin user of the account",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"email": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Email of the admin user of the account",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"credentialsRef", "organizationName", "username", "email"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TLSSpec", "k8s.io/api/core/v1.SecretReference"},
+	}
+}
+
+func schema_pkg_apis_capabilities_v1alpha1_DeveloperAccountStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeveloperAccountStatus defines the observed state of DeveloperAccount",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"accountID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID of the 3scale account",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State of the 3scale account, like approved or pending",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Why the account could not be provisioned",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -468,6 +834,13 @@ func schema_pkg_apis_capabilities_v1alpha1_MetricSpec(ref common.ReferenceCallba
 				Description: "MetricSpec defines the desired state of Metric",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the metric in 3scale. Defaults to the object name",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"unit": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -504,6 +877,40 @@ func schema_pkg_apis_capabilities_v1alpha1_MetricStatus(ref common.ReferenceCall
 	}
 }
 
+func schema_pkg_apis_capabilities_v1alpha1_OpenAPISpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OpenAPISpec references an OpenAPI 2 or 3 document, in JSON or YAML, describing the API. One and only one of the fields has to be set",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"configMapRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key of a ConfigMap with the document",
+							Ref:         ref("k8s.io/api/core/v1.ConfigMapKeySelector"),
+						},
+					},
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key of a Secret with the document",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"inline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The document itself",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.ConfigMapKeySelector", "k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
+
 func schema_pkg_apis_capabilities_v1alpha1_Plan(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -555,6 +962,13 @@ func schema_pkg_apis_capabilities_v1alpha1_PlanSpec(ref common.ReferenceCallback
 				Description: "PlanSpec defines the desired state of Plan",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the plan in 3scale. Defaults to the object name",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"default": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"boolean"},
@@ -603,6 +1017,144 @@ func schema_pkg_apis_capabilities_v1alpha1_PlanStatus(ref common.ReferenceCallba
 	}
 }
 
+func schema_pkg_apis_capabilities_v1alpha1_Policy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Policy is the Schema for the policies API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PolicySpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PolicyStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PolicySpec", "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.PolicyStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_capabilities_v1alpha1_PolicySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PolicySpec defines the desired state of Policy",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the APIcast policy, e.g. cors, headers, url_rewriting or ip_check",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version of the APIcast policy. Defaults to builtin",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"configuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JSON configuration of the policy, as defined by the policy schema",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the policy is applied. Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"position": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Position of the policy in the policy chain, lower positions run first. Policies with the same position are ordered by name",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_capabilities_v1alpha1_PolicyStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PolicyStatus defines the observed state of Policy",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_capabilities_v1alpha1_TLSSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TLSSpec defines how the certificate of the 3scale portal is verified",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"caBundleSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key of a Secret with PEM encoded CA certificates trusted on top of the system CAs",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"caBundleConfigMapRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key of a ConfigMap with PEM encoded CA certificates trusted on top of the system CAs",
+							Ref:         ref("k8s.io/api/core/v1.ConfigMapKeySelector"),
+						},
+					},
+					"insecureSkipVerify": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Disables the verification of the certificate of the 3scale portal. Only meant for testing, credentials are sent over an unverified connection",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.ConfigMapKeySelector", "k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
+
 func schema_pkg_apis_capabilities_v1alpha1_Tenant(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -693,12 +1245,32 @@ func schema_pkg_apis_capabilities_v1alpha1_TenantSpec(ref common.ReferenceCallba
 							Ref: ref("k8s.io/api/core/v1.SecretReference"),
 						},
 					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verification of the certificate of the 3scale master portal",
+							Ref:         ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TLSSpec"),
+						},
+					},
+					"deletionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "What happens to the 3scale tenant account when the Tenant is deleted. Delete schedules the account for deletion in 3scale. Defaults to Orphan",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"accessTokenRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Increase to regenerate the provider key of the tenant and write it into the tenant secret",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 				Required: []string{"username", "email", "organizationName", "systemMasterUrl", "tenantSecretRef", "passwordCredentialsRef", "masterCredentialsRef"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.SecretReference"},
+			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TLSSpec", "k8s.io/api/core/v1.SecretReference"},
 	}
 }
 
@@ -721,9 +1293,52 @@ func schema_pkg_apis_capabilities_v1alpha1_TenantStatus(ref common.ReferenceCall
 							Format: "int64",
 						},
 					},
+					"adminURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL of the admin portal of the tenant",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Current state of the Tenant",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TenantCondition"),
+									},
+								},
+							},
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generation of the Tenant last reconciled",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"passwordSecretVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resource version of the admin password secret last set to the admin user",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"accessTokenRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Last accessTokenRotation of the spec applied to the tenant secret",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 				Required: []string{"tenantId", "adminId"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1.TenantCondition"},
 	}
 }
@@ -3,9 +3,8 @@ package apimanager
 import (
 	"context"
 	"fmt"
-	"reflect"
-	"sort"
 
+	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/api/policy/v1beta1"
 
 	"github.com/3scale/3scale-operator/version"
@@ -13,11 +12,15 @@ import (
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 
 	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
 	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
-	"github.com/RHsyseng/operator-utils/pkg/olm"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -81,12 +84,31 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	// Watch for changes to DeploymentConfigs to update deployment status
+	// Watch for changes to DeploymentConfigs and Deployments to update deployment status
 	ownerHandler := &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &appsv1alpha1.APIManager{},
 	}
-	err = c.Watch(&source.Kind{Type: &appsv1.DeploymentConfig{}}, ownerHandler)
+
+	// DeploymentConfigs are not available in the Kubernetes platform
+	dcAvailable, err := isKindAvailable(mgr, appsv1.SchemeGroupVersion.WithKind("DeploymentConfig"))
+	if err != nil {
+		return err
+	}
+	if dcAvailable {
+		err = c.Watch(&source.Kind{Type: &appsv1.DeploymentConfig{}}, ownerHandler)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.Watch(&source.Kind{Type: &k8sappsv1.Deployment{}}, ownerHandler)
+	if err != nil {
+		return err
+	}
+
+	// Pre deployment hook jobs gate the rollouts of the Deployments in the Kubernetes platform
+	err = c.Watch(&source.Kind{Type: &batchv1.Job{}}, ownerHandler)
 	if err != nil {
 		return err
 	}
@@ -96,9 +118,41 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	err = c.Watch(&source.Kind{Type: &v2beta2.HorizontalPodAutoscaler{}}, ownerHandler)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Secrets and ConfigMaps to roll out the DeploymentConfigs consuming them.
+	// Not all of them are owned by the APIManager, like the external databases secrets
+	mapper := apimanagerMapper{client: mgr.GetClient()}
+	configHandler := &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapConfigObject)}
+
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, configHandler)
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, configHandler)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// isKindAvailable returns true when the given kind is served by the cluster
+func isKindAvailable(mgr manager.Manager, gvk schema.GroupVersionKind) (bool, error) {
+	_, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // blank assignment to verify that ReconcileAPIManager implements reconcile.Reconciler
 var _ reconcile.Reconciler = &ReconcileAPIManager{}
 
@@ -117,14 +171,15 @@ func (r *ReconcileAPIManager) updateVersionAnnotations(cr *appsv1alpha1.APIManag
 }
 
 func (r *ReconcileAPIManager) upgradeAPIManager(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
-	// The object to instantiate would change in every release of the operator
-	// that upgrades the threescale version
 	upgradeApiManager := &operator.UpgradeApiManager{
 		Client:          r.Client(),
 		ApiClientReader: r.APIClientReader(),
 		Scheme:          r.Scheme(),
 		Cr:              cr,
 		Logger:          r.Logger(),
+		ReconcileComponents: func() (reconcile.Result, error) {
+			return r.reconcileAPIManagerLogic(cr)
+		},
 	}
 	return upgradeApiManager.Upgrade()
 }
@@ -154,6 +209,10 @@ func (r *ReconcileAPIManager) Reconcile(request reconcile.Request) (reconcile.Re
 	res, err := r.setAPIManagerDefaults(instance)
 	if err != nil {
 		logger.Error(err, "Error")
+		statusErr := r.reconcileAPIManagerStatus(instance, &reconcileError{reason: reasonInvalidSpec, err: err})
+		if statusErr != nil {
+			logger.Error(statusErr, "Error updating status")
+		}
 		return reconcile.Result{}, err
 	}
 	if res.Requeue {
@@ -161,13 +220,40 @@ func (r *ReconcileAPIManager) Reconcile(request reconcile.Request) (reconcile.Re
 		return res, nil
 	}
 
+	if instance.PlatformChanged() {
+		err = fmt.Errorf("spec.platform cannot be changed from %s once deployed", instance.Status.Platform)
+		logger.Error(err, "Invalid spec")
+		statusErr := r.reconcileAPIManagerStatus(instance, &reconcileError{reason: reasonInvalidSpec, err: err})
+		if statusErr != nil {
+			logger.Error(statusErr, "Error updating status")
+			return reconcile.Result{}, statusErr
+		}
+		// Retrying does not help. The APIManager is left untouched
+		// until the platform is reverted
+		return reconcile.Result{}, nil
+	}
+
 	if instance.Annotations[appsv1alpha1.OperatorVersionAnnotation] != version.Version {
 		logger.Info(fmt.Sprintf("Upgrade %s -> %s", instance.Annotations[appsv1alpha1.OperatorVersionAnnotation], version.Version))
-		// TODO add logic to check that only immediate consecutive installs
-		// are possible?
+		err = r.setUpgradeInProgressCondition(instance, corev1.ConditionTrue, reasonUpgrading,
+			fmt.Sprintf("Upgrading from operator version %s to %s", instance.Annotations[appsv1alpha1.OperatorVersionAnnotation], version.Version))
+		if err != nil {
+			logger.Error(err, "Error updating status")
+			return reconcile.Result{}, err
+		}
+
 		res, err := r.upgradeAPIManager(instance)
 		if err != nil {
 			logger.Error(err, "Error upgrading APIManager")
+			statusErr := r.reconcileAPIManagerStatus(instance, &reconcileError{reason: reasonUpgradeError, err: err})
+			if statusErr != nil {
+				logger.Error(statusErr, "Error updating status")
+			}
+			if operator.IsUnsupportedUpgradeError(err) {
+				// Retrying does not help. The APIManager is left untouched
+				// until an operator version supporting the upgrade is installed
+				return reconcile.Result{}, nil
+			}
 			return reconcile.Result{}, err
 		}
 		if res.Requeue {
@@ -180,23 +266,38 @@ func (r *ReconcileAPIManager) Reconcile(request reconcile.Request) (reconcile.Re
 			logger.Error(err, "Error updating annotations")
 			return reconcile.Result{}, err
 		}
+
+		err = r.setUpgradeInProgressCondition(instance, corev1.ConditionFalse, reasonUpgradeCompleted,
+			fmt.Sprintf("Upgraded to operator version %s", version.Version))
+		if err != nil {
+			logger.Error(err, "Error updating status")
+			return reconcile.Result{}, err
+		}
 		return reconcile.Result{Requeue: true}, nil
 	}
 
 	result, err := r.reconcileAPIManagerLogic(instance)
+	var reconcileErr *reconcileError
+	if err != nil {
+		reconcileErr = &reconcileError{reason: reasonReconcileError, err: err}
+	}
+
+	statusErr := r.reconcileAPIManagerStatus(instance, reconcileErr)
+	if statusErr != nil {
+		logger.Error(statusErr, "Error updating status")
+	}
+
 	if err != nil {
 		logger.Error(err, "Error during reconciliation")
 		return result, err
 	}
-	if result.Requeue {
+	if result.Requeue || result.RequeueAfter > 0 {
 		logger.Info("Reconciling not finished. Requeueing.")
 		return result, nil
 	}
 
-	err = r.reconcileAPIManagerStatus(instance)
-	if err != nil {
-		logger.Error(err, "Error updating status")
-		return reconcile.Result{}, err
+	if statusErr != nil {
+		return reconcile.Result{}, statusErr
 	}
 
 	return reconcile.Result{}, nil
@@ -220,7 +321,9 @@ func (r *ReconcileAPIManager) apiManagerInstance(namespacedName types.Namespaced
 }
 
 func (r *ReconcileAPIManager) setAPIManagerDefaults(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
-	changed, err := cr.SetDefaults() // TODO check where to put this
+	// Defaults are stored by the mutating admission webhook when it is
+	// deployed. Otherwise they are set here and the APIManager is requeued
+	changed, err := cr.SetDefaults()
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -282,7 +385,12 @@ func (r *ReconcileAPIManager) reconcileAPIManagerLogic(cr *appsv1alpha1.APIManag
 		return result, err
 	}
 
-	return reconcile.Result{}, nil
+	result, err = r.reconcileExposure(cr)
+	if err != nil || result.Requeue {
+		return result, err
+	}
+
+	return r.reconcileCredentialRotation(cr)
 }
 
 func (r *ReconcileAPIManager) reconcileAMPImagesLogic(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
@@ -362,42 +470,16 @@ func (r *ReconcileAPIManager) reconcileApicast(cr *appsv1alpha1.APIManager) (rec
 	return reconciler.Reconcile()
 }
 
-func (r *ReconcileAPIManager) reconcileAPIManagerStatus(cr *appsv1alpha1.APIManager) error {
-	return r.setDeploymentStatus(cr)
+func (r *ReconcileAPIManager) reconcileExposure(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
+	baseLogicReconciler := operator.NewBaseLogicReconciler(r.BaseReconciler)
+	reconciler := operator.NewExposureReconciler(operator.NewBaseAPIManagerLogicReconciler(baseLogicReconciler, cr))
+	return reconciler.Reconcile()
 }
 
-func (r *ReconcileAPIManager) setDeploymentStatus(instance *appsv1alpha1.APIManager) error {
-	listOps := []client.ListOption{
-		client.InNamespace(instance.Namespace),
-	}
-	dcList := &appsv1.DeploymentConfigList{}
-	err := r.Client().List(context.TODO(), dcList, listOps...)
-	if err != nil {
-		r.Logger().Error(err, "Failed to list deployment configs")
-		return err
-	}
-	var dcs []appsv1.DeploymentConfig
-	for _, dc := range dcList.Items {
-		for _, ownerRef := range dc.GetOwnerReferences() {
-			if ownerRef.UID == instance.UID {
-				dcs = append(dcs, dc)
-				break
-			}
-		}
-	}
-	sort.Slice(dcs, func(i, j int) bool { return dcs[i].Name < dcs[j].Name })
-
-	deploymentStatus := olm.GetDeploymentConfigStatus(dcs)
-	if !reflect.DeepEqual(instance.Status.Deployments, deploymentStatus) {
-		r.Logger().Info("Deployment status will be updated")
-		instance.Status.Deployments = deploymentStatus
-		err = r.Client().Status().Update(context.TODO(), instance)
-		if err != nil {
-			r.Logger().Error(err, "Failed to update API Manager deployment status")
-			return err
-		}
-	}
-	return nil
+func (r *ReconcileAPIManager) reconcileCredentialRotation(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
+	baseLogicReconciler := operator.NewBaseLogicReconciler(r.BaseReconciler)
+	reconciler := operator.NewCredentialRotationReconciler(operator.NewBaseAPIManagerLogicReconciler(baseLogicReconciler, cr))
+	return reconciler.Reconcile()
 }
 
 func (r *ReconcileAPIManager) externalDatabasesCheck(cr *appsv1alpha1.APIManager) error {
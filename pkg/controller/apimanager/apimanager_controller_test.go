@@ -2,7 +2,6 @@ package apimanager
 
 import (
 	"context"
-	"fmt"
 	"testing"
 
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
@@ -12,6 +11,8 @@ import (
 	appsv1 "github.com/openshift/api/apps/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -110,6 +111,77 @@ func TestAPIManagerControllerCreate(t *testing.T) {
 	if *backendListenerExistingReplicas != 1 {
 		t.Errorf("APIManager's backend listener replicas size (%d) is not the expected size (%d)", backendListenerExistingReplicas, 1)
 	}
+
+	// Deployments are not ready yet because nothing runs them in the fake client
+	availableCondition := finalAPIManager.Status.GetCondition(appsv1alpha1.APIManagerAvailable)
+	if availableCondition == nil {
+		t.Fatalf("APIManager status does not have the %s condition", appsv1alpha1.APIManagerAvailable)
+	}
+	if availableCondition.Status != corev1.ConditionFalse {
+		t.Errorf("APIManager %s condition status (%s) is not the expected (%s)", appsv1alpha1.APIManagerAvailable, availableCondition.Status, corev1.ConditionFalse)
+	}
+
+	for _, conditionType := range []appsv1alpha1.APIManagerConditionType{
+		appsv1alpha1.APIManagerProgressing,
+		appsv1alpha1.APIManagerDegraded,
+		appsv1alpha1.APIManagerSystemReady,
+		appsv1alpha1.APIManagerBackendReady,
+		appsv1alpha1.APIManagerZyncReady,
+		appsv1alpha1.APIManagerApicastReady,
+		appsv1alpha1.APIManagerRolloutPending,
+	} {
+		if finalAPIManager.Status.GetCondition(conditionType) == nil {
+			t.Errorf("APIManager status does not have the %s condition", conditionType)
+		}
+	}
+
+	// Nothing rolls out the DeploymentConfigs in the fake client
+	if !finalAPIManager.Status.IsConditionTrue(appsv1alpha1.APIManagerRolloutPending) {
+		t.Errorf("APIManager %s condition should be true", appsv1alpha1.APIManagerRolloutPending)
+	}
+
+	if finalAPIManager.Status.IsConditionTrue(appsv1alpha1.APIManagerDegraded) {
+		t.Errorf("APIManager should not be degraded: %s", finalAPIManager.Status.GetCondition(appsv1alpha1.APIManagerDegraded).Message)
+	}
+
+	if finalAPIManager.Status.GetCondition(appsv1alpha1.APIManagerExternalDatabasesValid) != nil {
+		t.Errorf("APIManager status should not have the %s condition when HighAvailability is disabled", appsv1alpha1.APIManagerExternalDatabasesValid)
+	}
+
+	if finalAPIManager.Status.Platform != appsv1alpha1.PlatformOpenShift {
+		t.Errorf("APIManager status platform (%s) is not the expected (%s)", finalAPIManager.Status.Platform, appsv1alpha1.PlatformOpenShift)
+	}
+
+	// Changing the platform is rejected, the components are not deployed again
+	kubernetesPlatform := appsv1alpha1.PlatformKubernetes
+	finalAPIManager.Spec.Platform = &kubernetesPlatform
+	err = r.Client().Update(context.TODO(), finalAPIManager)
+	if err != nil {
+		t.Fatalf("update APIManager: (%v)", err)
+	}
+
+	_, err = r.Reconcile(req)
+	if err != nil {
+		t.Fatalf("reconcile: (%v)", err)
+	}
+
+	err = r.Client().Get(context.TODO(), req.NamespacedName, finalAPIManager)
+	if err != nil {
+		t.Fatalf("get APIManager: (%v)", err)
+	}
+	degradedCondition := finalAPIManager.Status.GetCondition(appsv1alpha1.APIManagerDegraded)
+	if degradedCondition == nil || degradedCondition.Status != corev1.ConditionTrue || degradedCondition.Reason != reasonInvalidSpec {
+		t.Errorf("APIManager should be degraded by the platform change: %v", degradedCondition)
+	}
+
+	deployments := &k8sappsv1.DeploymentList{}
+	err = r.Client().List(context.TODO(), deployments)
+	if err != nil {
+		t.Fatalf("list deployments: (%v)", err)
+	}
+	if len(deployments.Items) > 0 {
+		t.Errorf("Deployments should not be created after a platform change, got %d", len(deployments.Items))
+	}
 }
 
 func TestAPIManagerControllerUpgrade(t *testing.T) {
@@ -119,13 +191,23 @@ func TestAPIManagerControllerUpgrade(t *testing.T) {
 		wildcardDomain = "test.3scale.net"
 	)
 
+	var upgradePath *operator.UpgradePath
+	for idx := range operator.UpgradePaths {
+		if operator.UpgradePaths[idx].ToOperatorVersion == version.Version {
+			upgradePath = &operator.UpgradePaths[idx]
+		}
+	}
+	if upgradePath == nil {
+		t.Fatalf("no upgrade path to operator version %s", version.Version)
+	}
+
 	apimanager := &appsv1alpha1.APIManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 			Annotations: map[string]string{
-				appsv1alpha1.OperatorVersionAnnotation:   fmt.Sprintf("not_%s", version.Version),
-				appsv1alpha1.ThreescaleVersionAnnotation: "something",
+				appsv1alpha1.OperatorVersionAnnotation:   upgradePath.FromOperatorVersion,
+				appsv1alpha1.ThreescaleVersionAnnotation: upgradePath.FromThreescaleVersion,
 			},
 		},
 		Spec: appsv1alpha1.APIManagerSpec{
@@ -214,4 +296,23 @@ func TestAPIManagerControllerUpgrade(t *testing.T) {
 	if operatorVersion != version.Version {
 		t.Errorf("APIManager cr OperatorVersionAnnotation value (%s) not the expected (%s)", operatorVersion, version.Version)
 	}
+
+	upgradeCondition := finalAPIManager.Status.GetCondition(appsv1alpha1.APIManagerUpgradeInProgress)
+	if upgradeCondition == nil {
+		t.Fatalf("APIManager status does not have the %s condition", appsv1alpha1.APIManagerUpgradeInProgress)
+	}
+	if upgradeCondition.Status != corev1.ConditionFalse {
+		t.Errorf("APIManager %s condition status (%s) is not the expected (%s)", appsv1alpha1.APIManagerUpgradeInProgress, upgradeCondition.Status, corev1.ConditionFalse)
+	}
+
+	upgradeStatus := finalAPIManager.Status.Upgrade
+	if upgradeStatus == nil {
+		t.Fatal("APIManager status does not have the upgrade status")
+	}
+	if upgradeStatus.Phase != appsv1alpha1.APIManagerUpgradePhaseCompleted {
+		t.Errorf("APIManager upgrade phase (%s) is not the expected (%s)", upgradeStatus.Phase, appsv1alpha1.APIManagerUpgradePhaseCompleted)
+	}
+	if upgradeStatus.FromOperatorVersion != upgradePath.FromOperatorVersion {
+		t.Errorf("APIManager upgrade from operator version (%s) is not the expected (%s)", upgradeStatus.FromOperatorVersion, upgradePath.FromOperatorVersion)
+	}
 }
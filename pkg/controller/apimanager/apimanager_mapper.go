@@ -0,0 +1,34 @@
+package apimanager
+
+import (
+	"context"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// apimanagerMapper maps the changed secrets and configmaps to the APIManagers of their
+// namespace, so the deployment configs consuming them are rolled out
+type apimanagerMapper struct {
+	client client.Client
+}
+
+func (m apimanagerMapper) mapConfigObject(o handler.MapObject) []reconcile.Request {
+	apimanagers := &appsv1alpha1.APIManagerList{}
+	err := m.client.List(context.TODO(), apimanagers, client.InNamespace(o.Meta.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Failed to list APIManagers", "Namespace", o.Meta.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, apimanager := range apimanagers.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: apimanager.Namespace, Name: apimanager.Name},
+		})
+	}
+	return requests
+}
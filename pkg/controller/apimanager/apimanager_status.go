@@ -0,0 +1,361 @@
+package apimanager
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/RHsyseng/operator-utils/pkg/olm"
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons used in the APIManager status conditions
+const (
+	reasonAllComponentsReady    = "AllComponentsReady"
+	reasonComponentsNotReady    = "ComponentsNotReady"
+	reasonDeploymentsReady      = "DeploymentsReady"
+	reasonDeploymentsNotReady   = "DeploymentsNotReady"
+	reasonDeploymentsNotFound   = "DeploymentsNotFound"
+	reasonDeploymentsInProgress = "DeploymentsInProgress"
+	reasonDeploymentsComplete   = "DeploymentsComplete"
+	reasonReconcileSucceeded    = "ReconcileSucceeded"
+	reasonReconcileError        = "ReconcileError"
+	reasonInvalidSpec           = "InvalidSpec"
+	reasonUpgrading             = "Upgrading"
+	reasonUpgradeError          = "UpgradeError"
+	reasonUpgradeCompleted      = "UpgradeCompleted"
+	reasonExternalDatabasesOK   = "ExternalDatabasesSecretsValid"
+	reasonExternalDatabasesErr  = "ExternalDatabasesSecretsInvalid"
+	reasonRolloutsPending       = "RolloutsPending"
+	reasonRolloutsComplete      = "RolloutsComplete"
+)
+
+// reconcileError holds an error produced during the reconciliation of the
+// APIManager along with the reason to be published in the Degraded condition
+type reconcileError struct {
+	reason string
+	err    error
+}
+
+// componentLabel is the label of the DeploymentConfigs with the 3scale component they belong to
+const componentLabel = "threescale_component"
+
+// componentConditions maps each per-component readiness condition to the
+// componentLabel value of the DeploymentConfigs of that component
+var componentConditions = []struct {
+	conditionType appsv1alpha1.APIManagerConditionType
+	component     string
+	label         string
+}{
+	{appsv1alpha1.APIManagerSystemReady, "System", "system"},
+	{appsv1alpha1.APIManagerBackendReady, "Backend", "backend"},
+	{appsv1alpha1.APIManagerZyncReady, "Zync", "zync"},
+	{appsv1alpha1.APIManagerApicastReady, "APIcast", "apicast"},
+}
+
+func (r *ReconcileAPIManager) reconcileAPIManagerStatus(cr *appsv1alpha1.APIManager, reconcileErr *reconcileError) error {
+	newStatus, err := r.calculateStatus(cr, reconcileErr)
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(&cr.Status, newStatus) {
+		return nil
+	}
+
+	r.Logger().Info("APIManager status will be updated")
+	cr.Status = *newStatus
+	err = r.Client().Status().Update(context.TODO(), cr)
+	if err != nil {
+		r.Logger().Error(err, "Failed to update API Manager status")
+		return err
+	}
+	return nil
+}
+
+func (r *ReconcileAPIManager) calculateStatus(cr *appsv1alpha1.APIManager, reconcileErr *reconcileError) (*appsv1alpha1.APIManagerStatus, error) {
+	newStatus := cr.Status.DeepCopy()
+
+	deployments, components, err := r.deploymentStatus(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	newStatus.Deployments = deployments
+
+	// The platform is recorded once, so later changes of the spec don't duplicate the components
+	if newStatus.Platform == "" && cr.Spec.Platform != nil {
+		newStatus.Platform = *cr.Spec.Platform
+	}
+
+	allComponentsReady := true
+	notReadyComponents := []string{}
+	for _, c := range componentConditions {
+		condition := componentCondition(c.conditionType, c.label, newStatus.Deployments, components)
+		newStatus.SetCondition(condition)
+		if condition.Status != corev1.ConditionTrue {
+			allComponentsReady = false
+			notReadyComponents = append(notReadyComponents, c.component)
+		}
+	}
+
+	if allComponentsReady {
+		newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+			Type:    appsv1alpha1.APIManagerAvailable,
+			Status:  corev1.ConditionTrue,
+			Reason:  reasonAllComponentsReady,
+			Message: "All the APIManager components are ready",
+		})
+	} else {
+		newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+			Type:    appsv1alpha1.APIManagerAvailable,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonComponentsNotReady,
+			Message: fmt.Sprintf("Components not ready: %s", strings.Join(notReadyComponents, ", ")),
+		})
+	}
+
+	if len(newStatus.Deployments.Starting) > 0 {
+		newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+			Type:    appsv1alpha1.APIManagerProgressing,
+			Status:  corev1.ConditionTrue,
+			Reason:  reasonDeploymentsInProgress,
+			Message: fmt.Sprintf("Deployments starting: %s", strings.Join(newStatus.Deployments.Starting, ", ")),
+		})
+	} else {
+		newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+			Type:    appsv1alpha1.APIManagerProgressing,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonDeploymentsComplete,
+			Message: "No deployments are being rolled out",
+		})
+	}
+
+	pendingRollouts, err := r.pendingRollouts(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pendingRollouts) > 0 {
+		newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+			Type:    appsv1alpha1.APIManagerRolloutPending,
+			Status:  corev1.ConditionTrue,
+			Reason:  reasonRolloutsPending,
+			Message: fmt.Sprintf("Deployments waiting on a rollout: %s", strings.Join(pendingRollouts, ", ")),
+		})
+	} else {
+		newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+			Type:    appsv1alpha1.APIManagerRolloutPending,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonRolloutsComplete,
+			Message: "All deployments run their latest pod template",
+		})
+	}
+
+	if reconcileErr != nil {
+		newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+			Type:    appsv1alpha1.APIManagerDegraded,
+			Status:  corev1.ConditionTrue,
+			Reason:  reconcileErr.reason,
+			Message: reconcileErr.err.Error(),
+		})
+	} else {
+		newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+			Type:    appsv1alpha1.APIManagerDegraded,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonReconcileSucceeded,
+			Message: "Last reconciliation succeeded",
+		})
+	}
+
+	if cr.IsExternalDatabaseEnabled() {
+		err := r.externalDatabasesCheck(cr)
+		if err != nil {
+			newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+				Type:    appsv1alpha1.APIManagerExternalDatabasesValid,
+				Status:  corev1.ConditionFalse,
+				Reason:  reasonExternalDatabasesErr,
+				Message: err.Error(),
+			})
+		} else {
+			newStatus.SetCondition(appsv1alpha1.APIManagerCondition{
+				Type:    appsv1alpha1.APIManagerExternalDatabasesValid,
+				Status:  corev1.ConditionTrue,
+				Reason:  reasonExternalDatabasesOK,
+				Message: "External databases secrets are valid",
+			})
+		}
+	} else {
+		newStatus.RemoveCondition(appsv1alpha1.APIManagerExternalDatabasesValid)
+	}
+
+	return newStatus, nil
+}
+
+// componentCondition returns the readiness condition of the deployments whose component,
+// indexed by deployment name, is the given one
+func componentCondition(conditionType appsv1alpha1.APIManagerConditionType, component string, deployments olm.DeploymentStatus, components map[string]string) appsv1alpha1.APIManagerCondition {
+	ready := filterByComponent(deployments.Ready, component, components)
+	notReady := append(filterByComponent(deployments.Starting, component, components), filterByComponent(deployments.Stopped, component, components)...)
+
+	if len(ready) == 0 && len(notReady) == 0 {
+		return appsv1alpha1.APIManagerCondition{
+			Type:    conditionType,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonDeploymentsNotFound,
+			Message: "No deployments found",
+		}
+	}
+
+	if len(notReady) > 0 {
+		sort.Strings(notReady)
+		return appsv1alpha1.APIManagerCondition{
+			Type:    conditionType,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonDeploymentsNotReady,
+			Message: fmt.Sprintf("Deployments not ready: %s", strings.Join(notReady, ", ")),
+		}
+	}
+
+	return appsv1alpha1.APIManagerCondition{
+		Type:    conditionType,
+		Status:  corev1.ConditionTrue,
+		Reason:  reasonDeploymentsReady,
+		Message: "All deployments are ready",
+	}
+}
+
+func filterByComponent(names []string, component string, components map[string]string) []string {
+	result := []string{}
+	for _, name := range names {
+		if components[name] == component {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// deploymentStatus returns the status of the DeploymentConfigs, or the
+// Deployments in the Kubernetes platform, owned by the APIManager, and
+// their component label indexed by name
+func (r *ReconcileAPIManager) deploymentStatus(cr *appsv1alpha1.APIManager) (olm.DeploymentStatus, map[string]string, error) {
+	components := map[string]string{}
+
+	if cr.IsKubernetesPlatform() {
+		deployments, err := r.ownedDeployments(cr)
+		if err != nil {
+			return olm.DeploymentStatus{}, nil, err
+		}
+		for _, deployment := range deployments {
+			components[deployment.Name] = deployment.Labels[componentLabel]
+		}
+		return olm.GetDeploymentStatus(deployments), components, nil
+	}
+
+	dcs, err := r.ownedDeploymentConfigs(cr)
+	if err != nil {
+		return olm.DeploymentStatus{}, nil, err
+	}
+	for _, dc := range dcs {
+		components[dc.Name] = dc.Labels[componentLabel]
+	}
+	return olm.GetDeploymentConfigStatus(dcs), components, nil
+}
+
+// pendingRollouts returns the names of the DeploymentConfigs, or the Deployments in the
+// Kubernetes platform, whose pods don't run their latest pod template yet, like after a
+// change of the secrets and configmaps they consume
+func (r *ReconcileAPIManager) pendingRollouts(cr *appsv1alpha1.APIManager) ([]string, error) {
+	pending := []string{}
+
+	if cr.IsKubernetesPlatform() {
+		deployments, err := r.ownedDeployments(cr)
+		if err != nil {
+			return nil, err
+		}
+		for idx := range deployments {
+			if operator.DeploymentRolloutPending(&deployments[idx]) {
+				pending = append(pending, deployments[idx].Name)
+			}
+		}
+		return pending, nil
+	}
+
+	dcs, err := r.ownedDeploymentConfigs(cr)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range dcs {
+		if operator.DeploymentConfigRolloutPending(&dcs[idx]) {
+			pending = append(pending, dcs[idx].Name)
+		}
+	}
+	return pending, nil
+}
+
+func (r *ReconcileAPIManager) ownedDeployments(cr *appsv1alpha1.APIManager) ([]k8sappsv1.Deployment, error) {
+	listOps := []client.ListOption{
+		client.InNamespace(cr.Namespace),
+	}
+	deploymentList := &k8sappsv1.DeploymentList{}
+	err := r.Client().List(context.TODO(), deploymentList, listOps...)
+	if err != nil {
+		r.Logger().Error(err, "Failed to list deployments")
+		return nil, err
+	}
+	var deployments []k8sappsv1.Deployment
+	for _, deployment := range deploymentList.Items {
+		for _, ownerRef := range deployment.GetOwnerReferences() {
+			if ownerRef.UID == cr.UID {
+				deployments = append(deployments, deployment)
+				break
+			}
+		}
+	}
+	sort.Slice(deployments, func(i, j int) bool { return deployments[i].Name < deployments[j].Name })
+	return deployments, nil
+}
+
+func (r *ReconcileAPIManager) ownedDeploymentConfigs(cr *appsv1alpha1.APIManager) ([]appsv1.DeploymentConfig, error) {
+	listOps := []client.ListOption{
+		client.InNamespace(cr.Namespace),
+	}
+	dcList := &appsv1.DeploymentConfigList{}
+	err := r.Client().List(context.TODO(), dcList, listOps...)
+	if err != nil {
+		r.Logger().Error(err, "Failed to list deployment configs")
+		return nil, err
+	}
+	var dcs []appsv1.DeploymentConfig
+	for _, dc := range dcList.Items {
+		for _, ownerRef := range dc.GetOwnerReferences() {
+			if ownerRef.UID == cr.UID {
+				dcs = append(dcs, dc)
+				break
+			}
+		}
+	}
+	sort.Slice(dcs, func(i, j int) bool { return dcs[i].Name < dcs[j].Name })
+	return dcs, nil
+}
+
+func (r *ReconcileAPIManager) setUpgradeInProgressCondition(cr *appsv1alpha1.APIManager, status corev1.ConditionStatus, reason, message string) error {
+	changed := cr.Status.SetCondition(appsv1alpha1.APIManagerCondition{
+		Type:    appsv1alpha1.APIManagerUpgradeInProgress,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if !changed {
+		return nil
+	}
+
+	return r.Client().Status().Update(context.TODO(), cr)
+}
@@ -0,0 +1,41 @@
+package apimanager
+
+import (
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/RHsyseng/operator-utils/pkg/olm"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestComponentCondition(t *testing.T) {
+	deployments := olm.DeploymentStatus{
+		Ready:    []string{"system-app", "system-redis", "backend-listener"},
+		Starting: []string{"backend-redis"},
+		Stopped:  []string{"zync-que"},
+	}
+	components := map[string]string{
+		"system-app":       "system",
+		"system-redis":     "system",
+		"backend-listener": "backend",
+		"backend-redis":    "backend",
+		"zync-que":         "zync",
+	}
+
+	cases := []struct {
+		component      string
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{"system", corev1.ConditionTrue, reasonDeploymentsReady},
+		{"backend", corev1.ConditionFalse, reasonDeploymentsNotReady},
+		{"zync", corev1.ConditionFalse, reasonDeploymentsNotReady},
+		{"apicast", corev1.ConditionFalse, reasonDeploymentsNotFound},
+	}
+	for _, tc := range cases {
+		condition := componentCondition(appsv1alpha1.APIManagerSystemReady, tc.component, deployments, components)
+		if condition.Status != tc.expectedStatus || condition.Reason != tc.expectedReason {
+			t.Errorf("component %s: unexpected condition: %v", tc.component, condition)
+		}
+	}
+}
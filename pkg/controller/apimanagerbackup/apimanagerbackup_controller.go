@@ -0,0 +1,295 @@
+package apimanagerbackup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_apimanagerbackup")
+
+const (
+	secretsBackupStep = "secrets"
+	// Jobs are watched. The requeue only covers missed events
+	jobRequeueDelay = 30 * time.Second
+)
+
+// Add creates a new APIManagerBackup Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	baseReconciler := operator.NewBaseReconciler(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme(), log)
+	return &ReconcileAPIManagerBackup{
+		BaseControllerReconciler: operator.NewBaseControllerReconciler(baseReconciler),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("apimanagerbackup-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource APIManagerBackup
+	err = c.Watch(&source.Kind{Type: &appsv1alpha1.APIManagerBackup{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the backup jobs
+	return c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &appsv1alpha1.APIManagerBackup{},
+	})
+}
+
+// blank assignment to verify that ReconcileAPIManagerBackup implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileAPIManagerBackup{}
+
+// ReconcileAPIManagerBackup reconciles a APIManagerBackup object
+type ReconcileAPIManagerBackup struct {
+	operator.BaseControllerReconciler
+}
+
+// Reconcile runs the steps of the backup in order, recording the completed
+// steps in the status. A finished backup is never run again
+func (r *ReconcileAPIManagerBackup) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := r.Logger().WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	logger.Info("ReconcileAPIManagerBackup")
+
+	backup := &appsv1alpha1.APIManagerBackup{}
+	err := r.Client().Get(context.TODO(), request.NamespacedName, backup)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("resource not found. Ignoring since object must have been deleted")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if backup.Status.Phase == appsv1alpha1.APIManagerBackupPhaseCompleted ||
+		backup.Status.Phase == appsv1alpha1.APIManagerBackupPhaseFailed {
+		return reconcile.Result{}, nil
+	}
+
+	if backup.SetDefaults() {
+		logger.Info("Defaults set for APIManagerBackup resource")
+		return reconcile.Result{Requeue: true}, r.Client().Update(context.TODO(), backup)
+	}
+
+	err = backup.Validate()
+	if err != nil {
+		return reconcile.Result{}, r.failBackup(backup, err)
+	}
+
+	apiManager, err := r.apiManager(backup)
+	if err != nil {
+		if errors.IsNotFound(err) || isAmbiguousAPIManagerError(err) {
+			return reconcile.Result{}, r.failBackup(backup, err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	if backup.Status.Phase == "" {
+		backup.Status.Phase = appsv1alpha1.APIManagerBackupPhaseRunning
+		backup.Status.APIManagerName = apiManager.Name
+		if backup.Spec.BackupDestination.PersistentVolumeClaim != nil {
+			backup.Status.BackupPersistentVolumeClaimName = operator.BackupPersistentVolumeClaimName(backup.Name)
+		}
+		err = r.Client().Status().Update(context.TODO(), backup)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	optsProvider := operator.OperatorBackupOptionsProvider{
+		APIManager:  apiManager,
+		Name:        backup.Name,
+		BackupName:  backup.Name,
+		Destination: &backup.Spec.BackupDestination,
+		Client:      r.Client(),
+	}
+	opts, err := optsProvider.GetBackupOptions()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	backupComponent := component.NewBackup(opts)
+
+	pvc := backupComponent.PersistentVolumeClaim()
+	if pvc != nil {
+		err = r.ensureObject(backup, pvc)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if !backup.Status.IsStepCompleted(secretsBackupStep) {
+		err = r.backupSecrets(backup, &optsProvider)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		err = r.completeStep(backup, secretsBackupStep)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	steps := backupComponent.BackupSteps()
+	operator.ApplyBackupSecretSources(&apiManager.Spec, steps)
+	for _, step := range steps {
+		if backup.Status.IsStepCompleted(step.Name) {
+			continue
+		}
+
+		state, err := operator.EnsureBackupJob(r.Client(), r.Scheme(), backup, step.Job)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		switch state {
+		case operator.BackupJobRunning:
+			logger.Info(fmt.Sprintf("Waiting for backup job %s", step.Job.Name))
+			return reconcile.Result{RequeueAfter: jobRequeueDelay}, nil
+		case operator.BackupJobFailed:
+			return reconcile.Result{}, r.failBackup(backup, &operator.BackupJobFailedError{JobName: step.Job.Name})
+		}
+
+		err = r.completeStep(backup, step.Name)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	logger.Info("Backup completed")
+	backup.Status.Phase = appsv1alpha1.APIManagerBackupPhaseCompleted
+	return reconcile.Result{}, r.Client().Status().Update(context.TODO(), backup)
+}
+
+// apiManager returns the APIManager set in the backup spec or, if not set,
+// the only APIManager of the namespace
+func (r *ReconcileAPIManagerBackup) apiManager(backup *appsv1alpha1.APIManagerBackup) (*appsv1alpha1.APIManager, error) {
+	apiManagerName := backup.Status.APIManagerName
+	if apiManagerName == "" && backup.Spec.APIManagerName != nil {
+		apiManagerName = *backup.Spec.APIManagerName
+	}
+
+	if apiManagerName != "" {
+		apiManager := &appsv1alpha1.APIManager{}
+		err := r.Client().Get(context.TODO(), types.NamespacedName{Name: apiManagerName, Namespace: backup.Namespace}, apiManager)
+		return apiManager, err
+	}
+
+	apiManagerList := &appsv1alpha1.APIManagerList{}
+	err := r.Client().List(context.TODO(), apiManagerList, client.InNamespace(backup.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	if len(apiManagerList.Items) != 1 {
+		return nil, &ambiguousAPIManagerError{count: len(apiManagerList.Items), namespace: backup.Namespace}
+	}
+	return &apiManagerList.Items[0], nil
+}
+
+type ambiguousAPIManagerError struct {
+	count     int
+	namespace string
+}
+
+func (e *ambiguousAPIManagerError) Error() string {
+	return fmt.Sprintf("found %d APIManagers in namespace %s. Set the apiManagerName of the backup", e.count, e.namespace)
+}
+
+func isAmbiguousAPIManagerError(err error) bool {
+	_, ok := err.(*ambiguousAPIManagerError)
+	return ok
+}
+
+// backupSecrets stores a copy of every existing secret the backed up data
+// depends on. The copies are owned by the backup. The secrets read from a
+// secret source are copied with the field names of the APIManager secret
+func (r *ReconcileAPIManagerBackup) backupSecrets(backup *appsv1alpha1.APIManagerBackup, optsProvider *operator.OperatorBackupOptionsProvider) error {
+	secrets, err := optsProvider.BackupSecrets()
+	if err != nil {
+		return err
+	}
+
+	for _, secretName := range component.BackupSecretNames() {
+		secret, ok := secrets[secretName]
+		if !ok {
+			continue
+		}
+
+		secretCopy := &v1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Secret",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("%s-%s", backup.Name, secretName),
+				Labels:      map[string]string{appsv1alpha1.BackupSecretLabel: backup.Name},
+				Annotations: map[string]string{appsv1alpha1.BackupSecretSourceAnnotation: secretName},
+			},
+			Type: secret.Type,
+			Data: secret.Data,
+		}
+		err = r.ensureObject(backup, secretCopy)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureObject creates the given object, owned by the backup, unless it
+// already exists. Backup objects are never updated
+func (r *ReconcileAPIManagerBackup) ensureObject(backup *appsv1alpha1.APIManagerBackup, obj common.KubernetesObject) error {
+	obj.SetNamespace(backup.Namespace)
+	err := controllerutil.SetControllerReference(backup, obj, r.Scheme())
+	if err != nil {
+		return err
+	}
+
+	err = r.Client().Create(context.TODO(), obj)
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err == nil {
+		r.Logger().Info(fmt.Sprintf("Created object %s", operator.ObjectInfo(obj)))
+	}
+	return err
+}
+
+func (r *ReconcileAPIManagerBackup) completeStep(backup *appsv1alpha1.APIManagerBackup, step string) error {
+	backup.Status.CompletedSteps = append(backup.Status.CompletedSteps, step)
+	return r.Client().Status().Update(context.TODO(), backup)
+}
+
+func (r *ReconcileAPIManagerBackup) failBackup(backup *appsv1alpha1.APIManagerBackup, err error) error {
+	r.Logger().Error(err, "Backup failed", "APIManagerBackup", backup.Name)
+	backup.Status.Phase = appsv1alpha1.APIManagerBackupPhaseFailed
+	backup.Status.Message = err.Error()
+	return r.Client().Status().Update(context.TODO(), backup)
+}
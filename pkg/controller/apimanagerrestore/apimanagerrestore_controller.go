@@ -0,0 +1,287 @@
+package apimanagerrestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	appsv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/apps/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_apimanagerrestore")
+
+const (
+	secretsRestoreStep = "secrets"
+	// Jobs are watched. The requeue only covers missed events and the
+	// scaling of the stopped DeploymentConfigs
+	requeueDelay = 10 * time.Second
+)
+
+// Add creates a new APIManagerRestore Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	baseReconciler := operator.NewBaseReconciler(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme(), log)
+	return &ReconcileAPIManagerRestore{
+		BaseControllerReconciler: operator.NewBaseControllerReconciler(baseReconciler),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("apimanagerrestore-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource APIManagerRestore
+	err = c.Watch(&source.Kind{Type: &appsv1alpha1.APIManagerRestore{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the restore jobs
+	return c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &appsv1alpha1.APIManagerRestore{},
+	})
+}
+
+// blank assignment to verify that ReconcileAPIManagerRestore implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileAPIManagerRestore{}
+
+// ReconcileAPIManagerRestore reconciles a APIManagerRestore object
+type ReconcileAPIManagerRestore struct {
+	operator.BaseControllerReconciler
+}
+
+// Reconcile reapplies the secrets stored by the backup and then runs the
+// restore steps in order, recording the completed steps in the status. A
+// finished restore is never run again
+func (r *ReconcileAPIManagerRestore) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := r.Logger().WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	logger.Info("ReconcileAPIManagerRestore")
+
+	restore := &appsv1alpha1.APIManagerRestore{}
+	err := r.Client().Get(context.TODO(), request.NamespacedName, restore)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("resource not found. Ignoring since object must have been deleted")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if restore.Status.Phase == appsv1alpha1.APIManagerRestorePhaseCompleted ||
+		restore.Status.Phase == appsv1alpha1.APIManagerRestorePhaseFailed {
+		return reconcile.Result{}, nil
+	}
+
+	backup := &appsv1alpha1.APIManagerBackup{}
+	err = r.Client().Get(context.TODO(), types.NamespacedName{Name: restore.Spec.APIManagerBackupName, Namespace: restore.Namespace}, backup)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, r.failRestore(restore, fmt.Errorf("APIManagerBackup %s not found", restore.Spec.APIManagerBackupName))
+		}
+		return reconcile.Result{}, err
+	}
+
+	switch backup.Status.Phase {
+	case appsv1alpha1.APIManagerBackupPhaseCompleted:
+	case appsv1alpha1.APIManagerBackupPhaseFailed:
+		return reconcile.Result{}, r.failRestore(restore, fmt.Errorf("APIManagerBackup %s failed", backup.Name))
+	default:
+		logger.Info(fmt.Sprintf("Waiting for APIManagerBackup %s to complete", backup.Name))
+		return reconcile.Result{RequeueAfter: requeueDelay}, nil
+	}
+
+	apiManager := &appsv1alpha1.APIManager{}
+	err = r.Client().Get(context.TODO(), types.NamespacedName{Name: backup.Status.APIManagerName, Namespace: restore.Namespace}, apiManager)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, r.failRestore(restore, fmt.Errorf("APIManager %s not found", backup.Status.APIManagerName))
+		}
+		return reconcile.Result{}, err
+	}
+
+	if restore.Status.Phase == "" {
+		restore.Status.Phase = appsv1alpha1.APIManagerRestorePhaseRunning
+		restore.Status.APIManagerName = apiManager.Name
+		err = r.Client().Status().Update(context.TODO(), restore)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if !restore.Status.IsStepCompleted(secretsRestoreStep) {
+		err = r.restoreSecrets(restore, backup, apiManager)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		err = r.completeStep(restore, secretsRestoreStep)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	optsProvider := operator.OperatorBackupOptionsProvider{
+		APIManager:  apiManager,
+		Name:        restore.Name,
+		BackupName:  backup.Name,
+		Destination: &backup.Spec.BackupDestination,
+		Client:      r.Client(),
+	}
+	opts, err := optsProvider.GetBackupOptions()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	steps := component.NewBackup(opts).RestoreSteps()
+	operator.ApplyBackupSecretSources(&apiManager.Spec, steps)
+	for _, step := range steps {
+		if restore.Status.IsStepCompleted(step.Name) {
+			continue
+		}
+
+		res, err := r.runStep(restore, apiManager, step)
+		if err != nil || res.RequeueAfter > 0 {
+			return res, err
+		}
+		if restore.Status.Phase == appsv1alpha1.APIManagerRestorePhaseFailed {
+			return reconcile.Result{}, nil
+		}
+
+		err = r.completeStep(restore, step.Name)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	logger.Info("Restore completed")
+	restore.Status.Phase = appsv1alpha1.APIManagerRestorePhaseCompleted
+	return reconcile.Result{}, r.Client().Status().Update(context.TODO(), restore)
+}
+
+// runStep stops the DeploymentConfigs of the step, if any, runs the job of
+// the step and starts the DeploymentConfigs again, with the replicas they
+// had, once the job has finished
+func (r *ReconcileAPIManagerRestore) runStep(restore *appsv1alpha1.APIManagerRestore, apiManager *appsv1alpha1.APIManager, step component.BackupStep) (reconcile.Result, error) {
+	allStopped := true
+	for _, name := range step.StoppedDeploymentNames {
+		stopped, err := operator.StopBackupDeployment(r.Client(), restore.Namespace, name, apiManager.IsKubernetesPlatform())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !stopped {
+			r.Logger().Info(fmt.Sprintf("Waiting for %s to be stopped", name))
+			allStopped = false
+		}
+	}
+	if !allStopped {
+		return reconcile.Result{RequeueAfter: requeueDelay}, nil
+	}
+
+	state, err := operator.EnsureBackupJob(r.Client(), r.Scheme(), restore, step.Job)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if state == operator.BackupJobRunning {
+		r.Logger().Info(fmt.Sprintf("Waiting for restore job %s", step.Job.Name))
+		return reconcile.Result{RequeueAfter: requeueDelay}, nil
+	}
+
+	for _, name := range step.StoppedDeploymentNames {
+		err = operator.StartBackupDeployment(r.Client(), restore.Namespace, name, apiManager.IsKubernetesPlatform())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if state == operator.BackupJobFailed {
+		return reconcile.Result{}, r.failRestore(restore, &operator.BackupJobFailedError{JobName: step.Job.Name})
+	}
+	return reconcile.Result{}, nil
+}
+
+// restoreSecrets creates, or overwrites, every secret stored by the backup. The
+// secrets read from a secret source are kept, they are restored in their external store
+func (r *ReconcileAPIManagerRestore) restoreSecrets(restore *appsv1alpha1.APIManagerRestore, backup *appsv1alpha1.APIManagerBackup, apiManager *appsv1alpha1.APIManager) error {
+	secretList := &v1.SecretList{}
+	err := r.Client().List(context.TODO(), secretList,
+		client.InNamespace(restore.Namespace),
+		client.MatchingLabels{appsv1alpha1.BackupSecretLabel: backup.Name})
+	if err != nil {
+		return err
+	}
+
+	for idx := range secretList.Items {
+		secretCopy := &secretList.Items[idx]
+		secretName, ok := secretCopy.Annotations[appsv1alpha1.BackupSecretSourceAnnotation]
+		if !ok {
+			continue
+		}
+		if apiManager.Spec.SecretSource(secretName) != nil {
+			r.Logger().Info(fmt.Sprintf("Secret %s is read from a secret source, it has to be restored in its external store", secretName))
+			continue
+		}
+
+		secret := &v1.Secret{}
+		err = r.Client().Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: restore.Namespace}, secret)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+
+			secret = &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: restore.Namespace},
+				Type:       secretCopy.Type,
+				Data:       secretCopy.Data,
+			}
+			r.Logger().Info(fmt.Sprintf("Restoring secret %s", secretName))
+			err = r.Client().Create(context.TODO(), secret)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		secret.Data = secretCopy.Data
+		r.Logger().Info(fmt.Sprintf("Restoring secret %s", secretName))
+		err = r.Client().Update(context.TODO(), secret)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileAPIManagerRestore) completeStep(restore *appsv1alpha1.APIManagerRestore, step string) error {
+	restore.Status.CompletedSteps = append(restore.Status.CompletedSteps, step)
+	return r.Client().Status().Update(context.TODO(), restore)
+}
+
+func (r *ReconcileAPIManagerRestore) failRestore(restore *appsv1alpha1.APIManagerRestore, err error) error {
+	r.Logger().Error(err, "Restore failed", "APIManagerRestore", restore.Name)
+	restore.Status.Phase = appsv1alpha1.APIManagerRestorePhaseFailed
+	restore.Status.Message = err.Error()
+	return r.Client().Status().Update(context.TODO(), restore)
+}
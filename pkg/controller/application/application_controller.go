@@ -0,0 +1,353 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_application")
+
+// retryPeriod is how long a failed application waits to be reconciled again
+const retryPeriod = 1 * time.Minute
+
+// Add creates a new Application Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileApplication{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	mapper := applicationMapper{client: mgr.GetClient()}
+
+	// Create a new controller
+	c, err := controller.New("application-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource Application
+	err = c.Watch(&source.Kind{Type: &apiv1alpha1.Application{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// The applications wait for their account to be provisioned
+	err = c.Watch(&source.Kind{Type: &apiv1alpha1.DeveloperAccount{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapDeveloperAccount)})
+	if err != nil {
+		return err
+	}
+
+	// The credentials secrets are written again when deleted
+	err = c.Watch(&source.Kind{Type: &v1.Secret{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &apiv1alpha1.Application{},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// blank assignment to verify that ReconcileApplication implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileApplication{}
+
+// ReconcileApplication reconciles an Application object
+type ReconcileApplication struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile makes sure the 3scale application of the Application exists, subscribed to
+// its plan, and its credentials are in the credentials secret
+func (r *ReconcileApplication) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling Application")
+
+	app := &apiv1alpha1.Application{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, app)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("Application resource not found")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if app.DeletionTimestamp != nil {
+		if app.HasFinalizer() {
+			reqLogger.Info("Application is terminating, cleaning up")
+			err = r.cleanUp(app, reqLogger)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, app.RemoveFinalizer(r.client)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if !app.HasFinalizer() {
+		err = app.AddFinalizer(r.client)
+		// Expect for re-trigger
+		return reconcile.Result{}, err
+	}
+
+	status, err := r.reconcileApplication(app, reqLogger)
+	if err != nil {
+		reqLogger.Error(err, "Error in application reconciliation")
+		status.Error = err.Error()
+	}
+
+	updateErr := r.updateStatus(app, status)
+	if updateErr != nil {
+		return reconcile.Result{}, updateErr
+	}
+	if err != nil {
+		return reconcile.Result{RequeueAfter: retryPeriod}, nil
+	}
+
+	reqLogger.Info("Application reconciled successfully")
+	return reconcile.Result{}, nil
+}
+
+// reconcileApplication creates the 3scale application, or updates its plan when it exists.
+// The returned status keeps the application ID even on error
+func (r *ReconcileApplication) reconcileApplication(app *apiv1alpha1.Application, logger logr.Logger) (apiv1alpha1.ApplicationStatus, error) {
+	status := apiv1alpha1.ApplicationStatus{ApplicationID: app.Status.ApplicationID, PlanID: app.Status.PlanID, State: app.Status.State}
+
+	account := &apiv1alpha1.DeveloperAccount{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: app.Spec.AccountRef.Name, Namespace: app.Namespace}, account)
+	if err != nil {
+		return status, err
+	}
+	if account.Status.AccountID == 0 {
+		return status, fmt.Errorf("developer account %s is not provisioned in 3scale yet", account.Name)
+	}
+
+	portaClient, err := account.PortaClient(r.client)
+	if err != nil {
+		return status, err
+	}
+	developerClient, err := account.DeveloperClient(r.client)
+	if err != nil {
+		return status, err
+	}
+
+	plan, err := app.GetPlan(r.client, portaClient)
+	if err != nil {
+		return status, err
+	}
+
+	secret, err := r.getCredentialsSecret(app)
+	if err != nil {
+		return status, err
+	}
+
+	var application *helper.DeveloperApplication
+	if status.ApplicationID != 0 {
+		application, err = developerClient.ReadApplication(account.Status.AccountID, status.ApplicationID)
+		if err != nil && !helper.IsDeveloperNotFound(err) {
+			return status, err
+		}
+		if err != nil {
+			logger.Info("Application not found in 3scale, creating it again", "ApplicationID", status.ApplicationID)
+			application = nil
+		}
+	}
+
+	if application == nil {
+		// A previous reconciliation may have created the application and failed to save its ID
+		application, err = findApplication(developerClient, account.Status.AccountID, app.Name)
+		if err != nil {
+			return status, err
+		}
+		if application != nil {
+			logger.Info("Application found in 3scale by name", "ApplicationID", application.ID)
+		}
+	}
+
+	if application == nil {
+		// The credentials of an existing secret are kept, so the clients don't need to be updated
+		credentials := map[string]string{}
+		if secret != nil {
+			for key, value := range secret.Data {
+				credentials[key] = string(value)
+			}
+		} else {
+			credentials, err = apiv1alpha1.NewCredentials(plan.BackendVersion)
+			if err != nil {
+				return status, err
+			}
+			err = r.createCredentialsSecret(app, credentials)
+			if err != nil {
+				return status, err
+			}
+		}
+
+		logger.Info("Creating a new application", "AccountID", account.Status.AccountID, "PlanID", plan.ID)
+		application, err = developerClient.CreateApplication(account.Status.AccountID, plan.ID, app.Name, app.Spec.Description, apiv1alpha1.CredentialsParams(credentials))
+		if err != nil {
+			return status, err
+		}
+	} else {
+		if strconv.FormatInt(application.PlanID, 10) != plan.ID {
+			logger.Info("Changing the plan of the application", "ApplicationID", application.ID, "PlanID", plan.ID)
+			application, err = developerClient.ChangeApplicationPlan(account.Status.AccountID, application.ID, plan.ID)
+			if err != nil {
+				return status, err
+			}
+		}
+
+		if secret == nil {
+			credentials, err := r.applicationCredentials(developerClient, account.Status.AccountID, application)
+			if err != nil {
+				return status, err
+			}
+			err = r.createCredentialsSecret(app, credentials)
+			if err != nil {
+				return status, err
+			}
+		}
+	}
+
+	status.ApplicationID = application.ID
+	status.PlanID = application.PlanID
+	status.State = application.State
+	return status, nil
+}
+
+// findApplication returns the application of the account with the given name, nil if there is none
+func findApplication(developerClient *helper.DeveloperClient, accountID int64, name string) (*helper.DeveloperApplication, error) {
+	applications, err := developerClient.ListApplications(accountID)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range applications {
+		if applications[idx].AppName == name {
+			return &applications[idx], nil
+		}
+	}
+	return nil, nil
+}
+
+// applicationCredentials returns the credentials of an existing application. The application
+// keys can't be read back, a new one is added to the application
+func (r *ReconcileApplication) applicationCredentials(developerClient *helper.DeveloperClient, accountID int64, application *helper.DeveloperApplication) (map[string]string, error) {
+	if application.UserKey != "" {
+		return map[string]string{apiv1alpha1.ApplicationUserKeySecretField: application.UserKey}, nil
+	}
+
+	appKey, err := apiv1alpha1.NewApplicationKey()
+	if err != nil {
+		return nil, err
+	}
+	err = developerClient.CreateApplicationKey(accountID, application.ID, appKey)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		apiv1alpha1.ApplicationAppIDSecretField:  application.ApplicationID,
+		apiv1alpha1.ApplicationAppKeySecretField: appKey,
+	}, nil
+}
+
+func (r *ReconcileApplication) getCredentialsSecret(app *apiv1alpha1.Application) (*v1.Secret, error) {
+	secret := &v1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: app.GetCredentialsSecretName(), Namespace: app.Namespace}, secret)
+	if err != nil && errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (r *ReconcileApplication) createCredentialsSecret(app *apiv1alpha1.Application, credentials map[string]string) error {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: app.Namespace,
+			Name:      app.GetCredentialsSecretName(),
+			Labels:    map[string]string{"app": "3scale-operator"},
+		},
+		StringData: credentials,
+		Type:       v1.SecretTypeOpaque,
+	}
+	err := controllerutil.SetControllerReference(app, secret, r.scheme)
+	if err != nil {
+		return err
+	}
+	return r.client.Create(context.TODO(), secret)
+}
+
+// cleanUp deletes the 3scale application. Nothing is deleted when the account is gone,
+// its applications were deleted with it
+func (r *ReconcileApplication) cleanUp(app *apiv1alpha1.Application, logger logr.Logger) error {
+	if app.Status.ApplicationID == 0 {
+		return nil
+	}
+
+	account := &apiv1alpha1.DeveloperAccount{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: app.Spec.AccountRef.Name, Namespace: app.Namespace}, account)
+	if err != nil && errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if account.Status.AccountID == 0 {
+		return nil
+	}
+
+	developerClient, err := account.DeveloperClient(r.client)
+	if err != nil {
+		// Without the tenant credentials the application can't be deleted, it is left in 3scale
+		logger.Error(err, "Application not deleted from 3scale", "ApplicationID", app.Status.ApplicationID)
+		return nil
+	}
+
+	logger.Info("Deleting application", "ApplicationID", app.Status.ApplicationID)
+	err = developerClient.DeleteApplication(account.Status.AccountID, app.Status.ApplicationID)
+	if err != nil && !helper.IsDeveloperNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *ReconcileApplication) updateStatus(app *apiv1alpha1.Application, status apiv1alpha1.ApplicationStatus) error {
+	// don't update the status if there aren't any changes.
+	if reflect.DeepEqual(app.Status, status) {
+		return nil
+	}
+	app.Status = status
+	return r.client.Status().Update(context.TODO(), app)
+}
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+
+	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// applicationMapper maps the changed developer accounts to the applications referencing them
+type applicationMapper struct {
+	client client.Client
+}
+
+func (m applicationMapper) mapDeveloperAccount(o handler.MapObject) []reconcile.Request {
+	apps := &apiv1alpha1.ApplicationList{}
+	err := m.client.List(context.TODO(), apps, client.InNamespace(o.Meta.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Failed to list applications", "Namespace", o.Meta.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, app := range apps.Items {
+		if app.Spec.AccountRef.Name == o.Meta.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: app.Namespace, Name: app.Name},
+			})
+		}
+	}
+	return requests
+}
@@ -2,11 +2,14 @@ package binding
 
 import (
 	"context"
-	"time"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
-	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -25,28 +28,18 @@ func Add(mgr manager.Manager) error {
 	return add(mgr, newReconciler(mgr))
 }
 
-type NonBindingTrigger func(handler.MapObject) []reconcile.Request
-
-func (r NonBindingTrigger) Map(o handler.MapObject) []reconcile.Request {
-	return r(o)
-}
-
 // newReconciler returns a new reconcile.
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileBinding{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+	return &ReconcileBinding{client: mgr.GetClient(), scheme: mgr.GetScheme(), stateCache: newCurrentStateCache()}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 
-	var NonBindingTriggerFunc NonBindingTrigger = func(o handler.MapObject) []reconcile.Request {
-		return []reconcile.Request{
-			{NamespacedName: types.NamespacedName{
-				Namespace: o.Meta.GetNamespace(),
-				Name:      "_NonBinding",
-			}},
-		}
-	}
+	// Changes of the objects selected by the bindings only trigger the
+	// reconciliation of the bindings selecting them
+	mapper := bindingMapper{client: mgr.GetClient()}
+
 	// Create a new controller
 	c, err := controller.New("binding-controller", mgr, controller.Options{Reconciler: r})
 	if err != nil {
@@ -58,23 +51,37 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	err = c.Watch(&source.Kind{Type: &apiv1alpha1.API{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: NonBindingTriggerFunc})
+	err = c.Watch(&source.Kind{Type: &apiv1alpha1.API{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapAPI)})
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &apiv1alpha1.Plan{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapPlan)})
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &apiv1alpha1.Limit{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapLimit)})
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &apiv1alpha1.Metric{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapMetric)})
 	if err != nil {
 		return err
 	}
-	err = c.Watch(&source.Kind{Type: &apiv1alpha1.Plan{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: NonBindingTriggerFunc})
+	err = c.Watch(&source.Kind{Type: &apiv1alpha1.MappingRule{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapMappingRule)})
 	if err != nil {
 		return err
 	}
-	err = c.Watch(&source.Kind{Type: &apiv1alpha1.Limit{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: NonBindingTriggerFunc})
+	err = c.Watch(&source.Kind{Type: &apiv1alpha1.Policy{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapPolicy)})
 	if err != nil {
 		return err
 	}
-	err = c.Watch(&source.Kind{Type: &apiv1alpha1.Metric{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: NonBindingTriggerFunc})
+
+	// ConfigMaps and Secrets holding the OpenAPI documents of the APIs
+	err = c.Watch(&source.Kind{Type: &v1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapConfigMap)})
 	if err != nil {
 		return err
 	}
-	err = c.Watch(&source.Kind{Type: &apiv1alpha1.MappingRule{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: NonBindingTriggerFunc})
+	err = c.Watch(&source.Kind{Type: &v1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapSecret)})
 	if err != nil {
 		return err
 	}
@@ -91,51 +98,30 @@ type ReconcileBinding struct {
 	// that reads objects from the cache and writes to the apiserver
 	client client.Client
 	scheme *runtime.Scheme
+	// Last state read from 3scale for every binding
+	stateCache *currentStateCache
 }
 
 func (r *ReconcileBinding) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling Binding")
-	// If the trigger comes from an object different from a Binding, we will get
-	// all the binding object from the same namespace and reconcile them.
-	// This is a hack. but we don't have owner references, so it should work.
-	if request.Name == "_NonBinding" {
-		opts := []client.ListOption{
-			client.InNamespace(request.Namespace),
-		}
-		BindingList := &apiv1alpha1.BindingList{}
-		err := r.client.List(context.TODO(), BindingList, opts...)
-		if err != nil {
+	binding := &apiv1alpha1.Binding{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, binding)
+	if err != nil {
+		// if it's not there (user deleted it for ex.)
+		if errors.IsNotFound(err) {
+			r.stateCache.invalidate(request.NamespacedName)
 			reqLogger.Error(err, "error")
 			return reconcile.Result{}, nil
 		}
-
-		for _, binding := range BindingList.Items {
-			_, err := ReconcileBindingFunc(binding, r.client, reqLogger)
-			if err != nil {
-				reqLogger.Error(err, "error")
-			}
-		}
-		return reconcile.Result{}, nil
-	} else {
-		binding := &apiv1alpha1.Binding{}
-		err := r.client.Get(context.TODO(), request.NamespacedName, binding)
-		if err != nil {
-			// if it's not there (user deleted it for ex.)
-			if errors.IsNotFound(err) {
-				reqLogger.Error(err, "error")
-				return reconcile.Result{}, nil
-			}
-			// Error reading the object - requeue the request.
-			reqLogger.Error(err, "error")
-			return reconcile.Result{Requeue: true}, err
-		}
-		return ReconcileBindingFunc(*binding, r.client, reqLogger)
-
+		// Error reading the object - requeue the request.
+		reqLogger.Error(err, "error")
+		return reconcile.Result{Requeue: true}, err
 	}
+	return ReconcileBindingFunc(*binding, r.client, r.stateCache, reqLogger)
 }
 
-func ReconcileBindingFunc(binding apiv1alpha1.Binding, c client.Client, log logr.Logger) (reconcile.Result, error) {
+func ReconcileBindingFunc(binding apiv1alpha1.Binding, c client.Client, stateCache *currentStateCache, log logr.Logger) (reconcile.Result, error) {
 
 	// UpdateRequired controls whether if we need to update the status of the object or not
 	UpdateRequired := false
@@ -144,6 +130,7 @@ func ReconcileBindingFunc(binding apiv1alpha1.Binding, c client.Client, log logr
 	if binding.HasFinalizer() {
 		if binding.IsTerminating() {
 			log.Info("Binding is terminating, cleaning up.", binding.Name, binding.Namespace)
+			stateCache.invalidate(bindingKey(binding))
 			err := binding.CleanUp(c)
 			if err != nil {
 				log.Info("Clean up for Binding failed.", binding.Name, binding.Namespace)
@@ -159,104 +146,178 @@ func ReconcileBindingFunc(binding apiv1alpha1.Binding, c client.Client, log logr
 		return reconcile.Result{Requeue: true}, err
 	}
 
-	// Get the current state in the binding object
-	initialState, err := binding.GetCurrentState()
-	if err != nil {
-		log.Error(err, "Error getting the initial state binding")
-		return reconcile.Result{RequeueAfter: 1 * time.Minute, Requeue: true}, err
-	}
-
-	// Generate a new current state from 3scale
-	currentState, err := binding.NewCurrentState(c)
-	if err != nil {
-		log.Error(err, "Error getting current state from binding status")
-		return reconcile.Result{RequeueAfter: 1 * time.Minute, Requeue: true}, err
+	// The services claimed or released during the reconciliation are published in the status
+	initialServices := append([]apiv1alpha1.BindingService(nil), binding.Status.Services...)
 
+	if binding.Status.ObservedGeneration != binding.Generation {
+		binding.Status.ObservedGeneration = binding.Generation
+		UpdateRequired = true
 	}
 
-	// Set the current state in the binding object
-	err = binding.SetCurrentState(*currentState)
+	//Generate a new desiredState from the CRDs. The APIs with invalid objects are
+	//left out of it and reported in apiErrors
+	desiredState, apiErrors, err := binding.NewDesiredState(c)
 	if err != nil {
-		log.Error(err, "Error Reconciling APIs")
+		log.Error(err, "Error getting desired state from binding status")
+		return failBinding(&binding, c, log, "DesiredStateFailed", err)
 	}
 
-	// If the initial state and the current state are different, set the previousState field in the status and
-	// mark the object for update
-	if initialState != nil && !apiv1alpha1.CompareStates(*initialState, *currentState) {
-		err := binding.SetPreviousState(*initialState)
+	// Generate a new current state from 3scale, unless it was read for the same
+	// credentials and APIs during the sync period
+	stateCacheKey := currentStateCacheKey(desiredState.Credentials, bindingAPINames(desiredState, apiErrors))
+	currentState, cached := stateCache.get(bindingKey(binding), stateCacheKey, binding.GetSyncPeriod())
+	if !cached {
+		currentState, err = binding.NewCurrentState(c)
 		if err != nil {
-			log.Error(err, "Error setting previous state")
+			log.Error(err, "Error getting current state from binding status")
+			return failBinding(&binding, c, log, "CurrentStateFailed", err)
 		}
-		UpdateRequired = true
+		stateCache.set(bindingKey(binding), stateCacheKey, *currentState)
 	}
 
-	//Generate a new desiredState from the CRDs
-	desiredState, err := binding.NewDesiredState(c)
+	portaClient, err := binding.PortaClient(c, desiredState.Credentials)
 	if err != nil {
-		log.Error(err, "Error getting desired state from binding status")
+		log.Error(err, "Failed creating client")
+		return failBinding(&binding, c, log, "InvalidCredentials", err)
+	}
 
+	// In dry run mode the services are not claimed, the ownership is only
+	// computed on a copy of the binding to know which APIs would be managed
+	ownerBinding := &binding
+	if binding.Spec.DryRun {
+		ownerBinding = binding.DeepCopy()
 	}
-	// Set the desiredState in the binding objects
-	err = binding.SetDesiredState(*desiredState)
+
+	// Bindings of previous versions keep their state in the status, the
+	// services they managed are claimed and the state is removed
+	migrated, err := ownerBinding.MigrateStatus(c, portaClient)
 	if err != nil {
-		log.Error(err, "Error Reconciling APIs")
+		log.Error(err, "Error migrating the binding status")
+		return failBinding(&binding, c, log, "OwnershipFailed", err)
+	}
+	if migrated && !binding.Spec.DryRun {
+		UpdateRequired = true
 	}
 
-	// Reconcile the previousState, usually to remove a non existant API
-	previousState, _ := binding.GetPreviousState()
-	if previousState != nil {
-		log.Info("Previous State exists, reconciling.", binding.Name, binding.Namespace)
+	// Only the services created or adopted by the binding are managed, the
+	// other existing services are reported as failed and left untouched
+	ownershipErrors, err := ownerBinding.ClaimServices(c, portaClient, apiNames(currentState.APIs), false)
+	if err != nil {
+		log.Error(err, "Error claiming the services of the APIs")
+		return failBinding(&binding, c, log, "OwnershipFailed", err)
+	}
+	for apiName, ownershipErr := range ownershipErrors {
+		apiErrors[apiName] = ownershipErr
+	}
 
-		c, err := helper.PortaClientFromURLString(currentState.Credentials.AdminURL, currentState.Credentials.AuthToken)
-		if err != nil {
-			log.Error(err, "Failed creating client")
-		}
-		desiredState, err := binding.GetDesiredState()
-		if err != nil {
-			log.Error(err, "Failed to get desired state")
-		}
-		apisDiff := apiv1alpha1.DiffAPIs(previousState.APIs, desiredState.APIs)
-		for _, api := range apisDiff.MissingFromB {
-			err := api.DeleteFrom3scale(c)
-			if err != nil {
-				log.Error(err, "Failed to delete internal api from 3scale")
+	// In dry run mode the changes are only listed
+	var plannedChanges []string
+
+	// The owned services of the APIs no longer selected by the binding are
+	// released, depending on their deletion policy
+	releasedAPIs := unselectedServices(binding.Status.Services, bindingAPINames(desiredState, apiErrors))
+	if len(releasedAPIs) > 0 && binding.Spec.DryRun {
+		for _, apiName := range releasedAPIs {
+			if binding.Status.DeletesService(apiName) {
+				plannedChanges = append(plannedChanges, fmt.Sprintf("delete API %s", apiName))
+			} else {
+				plannedChanges = append(plannedChanges, fmt.Sprintf("orphan API %s", apiName))
 			}
 		}
-		// Clean the "PreviousState" if needed, and mark the object for udpate
-		binding.Status.PreviousState = nil
-		UpdateRequired = true
+	} else if len(releasedAPIs) > 0 {
+		log.Info("Releasing the services of unselected APIs.", binding.Name, binding.Namespace)
+
+		stateCache.invalidate(bindingKey(binding))
+		releaseErrors := binding.ReleaseServices(portaClient, releasedAPIs)
+		for apiName, releaseErr := range releaseErrors {
+			log.Error(releaseErr, "Failed to delete internal api from 3scale", "API", apiName)
+			apiErrors[apiName] = releaseErr
+		}
 	}
 
 	// Now we check if the State (current, desired) is in sync.
 	// if it's not in sync, we reconcile the APIs and mark the object for update
-	if binding.StateInSync() {
+	if apiv1alpha1.CompareStates(*desiredState, *currentState) {
 		log.Info("State is in sync")
 
 	} else {
-		log.Info("State is not in sync, reconciling APIs")
 		apisDiff := apiv1alpha1.DiffAPIs(desiredState.APIs, currentState.APIs)
-		err = apisDiff.ReconcileWith3scale(desiredState.Credentials)
-		if err != nil {
-			log.Error(err, "Error Reconciling APIs")
+		// APIs with invalid objects are missing from the desired state, but
+		// they must not be deleted from 3scale
+		apisDiff.MissingFromA = withoutFailedAPIs(apisDiff.MissingFromA, apiErrors)
+		// Services not owned by the binding are not updated
+		apisDiff.NotEqual = withoutFailedAPIPairs(apisDiff.NotEqual, apiErrors)
+
+		if binding.Spec.DryRun {
+			log.Info("State is not in sync, dry run enabled. Planning changes")
+			plannedChanges = append(plannedChanges, apisDiff.PlannedChanges()...)
+		} else {
+			log.Info("State is not in sync, reconciling APIs")
+			syncErrors := apisDiff.ReconcileWith3scale(portaClient)
+			for apiName, syncErr := range syncErrors {
+				log.Error(syncErr, "Error Reconciling API", "API", apiName)
+				apiErrors[apiName] = syncErr
+			}
+
+			// The services created by the binding are owned by it, even
+			// when the creation of their objects failed
+			_, err = binding.ClaimServices(c, portaClient, apiNames(apisDiff.MissingFromB), true)
+			if err != nil {
+				log.Error(err, "Error claiming the created services")
+				return failBinding(&binding, c, log, "OwnershipFailed", err)
+			}
+
+			// Refresh the current State
+			stateCache.invalidate(bindingKey(binding))
+			currentState, err = binding.NewCurrentState(c)
+			if err != nil {
+				log.Error(err, "Error getting current state from binding status")
+				return failBinding(&binding, c, log, "CurrentStateFailed", err)
+			}
+			stateCache.set(bindingKey(binding), stateCacheKey, *currentState)
+
+			if apiv1alpha1.CompareStates(*desiredState, *currentState) {
+				// Update the LastSync field.
+				binding.SetLastSuccessfulSync()
+			}
+			log.Info("Reconciliation finished.")
 		}
+		UpdateRequired = true
+	}
 
-		// Refresh the current State
-		currentState, err := binding.NewCurrentState(c)
-		if err != nil {
-			log.Error(err, "Error getting current state from binding status")
+	if !reflect.DeepEqual(binding.Status.Services, initialServices) {
+		UpdateRequired = true
+	}
+
+	if !reflect.DeepEqual(binding.Status.PlannedChanges, plannedChanges) {
+		binding.Status.PlannedChanges = plannedChanges
+		UpdateRequired = true
+	}
+
+	if binding.Status.SetAPIStatuses(bindingAPINames(desiredState, apiErrors), apiErrors, desiredState, currentState) {
+		UpdateRequired = true
+	}
+
+	if len(apiErrors) > 0 {
+		failedAPIs := []string{}
+		for apiName := range apiErrors {
+			failedAPIs = append(failedAPIs, apiName)
 		}
-		err = binding.SetCurrentState(*currentState)
-		if err != nil {
-			log.Error(err, "Error Reconciling APIs")
+		sort.Strings(failedAPIs)
+		message := fmt.Sprintf("APIs failed to sync: %s", strings.Join(failedAPIs, ", "))
+		if binding.Status.SetFailed("APISyncFailed", message) {
+			UpdateRequired = true
 		}
-
-		if binding.StateInSync() {
-			// Update the LastSync field.
-			binding.SetLastSuccessfulSync()
+	} else if len(plannedChanges) > 0 {
+		if binding.Status.SetDryRun(len(plannedChanges)) {
+			UpdateRequired = true
 		}
+	} else if !apiv1alpha1.CompareStates(*desiredState, *currentState) {
+		if binding.Status.SetFailed("NotInSync", "3scale APIs do not match the desired state") {
+			UpdateRequired = true
+		}
+	} else if binding.Status.SetSynced() {
 		UpdateRequired = true
-
-		log.Info("Reconciliation finished.")
 	}
 
 	// Update the object status fields.
@@ -268,5 +329,93 @@ func ReconcileBindingFunc(binding apiv1alpha1.Binding, c client.Client, log logr
 		}
 	}
 
-	return reconcile.Result{RequeueAfter: 1 * time.Minute, Requeue: true}, nil
+	return reconcile.Result{RequeueAfter: binding.GetSyncPeriod(), Requeue: true}, nil
+}
+
+// failBinding publishes the given error in the Failed condition of the
+// binding and requeues it
+func failBinding(binding *apiv1alpha1.Binding, c client.Client, log logr.Logger, reason string, err error) (reconcile.Result, error) {
+	binding.Status.SetFailed(reason, err.Error())
+	updateErr := binding.UpdateStatus(c)
+	if updateErr != nil {
+		log.Error(updateErr, "Failed to update status of binding object")
+	}
+	return reconcile.Result{RequeueAfter: binding.GetSyncPeriod(), Requeue: true}, err
+}
+
+func bindingKey(binding apiv1alpha1.Binding) types.NamespacedName {
+	return types.NamespacedName{Namespace: binding.Namespace, Name: binding.Name}
+}
+
+// withoutFailedAPIs returns the given APIs except the ones with an error
+func withoutFailedAPIs(apis []apiv1alpha1.InternalAPI, apiErrors map[string]error) []apiv1alpha1.InternalAPI {
+	var filtered []apiv1alpha1.InternalAPI
+	for _, api := range apis {
+		if _, ok := apiErrors[api.Name]; !ok {
+			filtered = append(filtered, api)
+		}
+	}
+	return filtered
+}
+
+// withoutFailedAPIPairs returns the given API pairs except the ones with an error
+func withoutFailedAPIPairs(apiPairs []apiv1alpha1.APIPair, apiErrors map[string]error) []apiv1alpha1.APIPair {
+	var filtered []apiv1alpha1.APIPair
+	for _, apiPair := range apiPairs {
+		if _, ok := apiErrors[apiPair.A.Name]; !ok {
+			filtered = append(filtered, apiPair)
+		}
+	}
+	return filtered
+}
+
+// apiNames returns the names of the given APIs
+func apiNames(apis []apiv1alpha1.InternalAPI) []string {
+	names := []string{}
+	for _, api := range apis {
+		names = append(names, api.Name)
+	}
+	return names
+}
+
+// unselectedServices returns the names of the APIs of the given services that are not in apiNames
+func unselectedServices(services []apiv1alpha1.BindingService, apiNames []string) []string {
+	var unselected []string
+	for _, service := range services {
+		selected := false
+		for _, apiName := range apiNames {
+			if service.Name == apiName {
+				selected = true
+				break
+			}
+		}
+		if !selected {
+			unselected = append(unselected, service.Name)
+		}
+	}
+	return unselected
+}
+
+// bindingAPINames returns the names of the APIs selected by the binding
+func bindingAPINames(desiredState *apiv1alpha1.State, apiErrors map[string]error) []string {
+	apiNames := []string{}
+	for _, api := range desiredState.APIs {
+		apiNames = append(apiNames, api.Name)
+	}
+	// The APIs with invalid objects are not in the desired state
+	for apiName := range apiErrors {
+		if _, ok := findAPI(desiredState.APIs, apiName); !ok {
+			apiNames = append(apiNames, apiName)
+		}
+	}
+	return apiNames
+}
+
+func findAPI(apis []apiv1alpha1.InternalAPI, apiName string) (*apiv1alpha1.InternalAPI, bool) {
+	for idx := range apis {
+		if apis[idx].Name == apiName {
+			return &apis[idx], true
+		}
+	}
+	return nil, false
 }
@@ -0,0 +1,191 @@
+package binding
+
+import (
+	"context"
+
+	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// bindingMapper maps the changed capabilities objects to the Bindings selecting them,
+// following the selector chain Binding -> API -> Plan, Metric, MappingRule, Policy -> Limit.
+// Changed ConfigMaps and Secrets are mapped to the Bindings selecting the APIs whose
+// OpenAPI document they hold
+type bindingMapper struct {
+	client client.Client
+}
+
+// openAPIRefFunc returns the name of the object holding the OpenAPI document of an API, if any
+type openAPIRefFunc func(openAPI *apiv1alpha1.OpenAPISpec) string
+
+// apiSelectorFunc returns the selector of the API for a kind of object
+type apiSelectorFunc func(api apiv1alpha1.API) *metav1.LabelSelector
+
+func (m bindingMapper) mapAPI(o handler.MapObject) []reconcile.Request {
+	return m.bindingRequests(o.Meta.GetNamespace(), []map[string]string{o.Meta.GetLabels()})
+}
+
+func (m bindingMapper) mapPlan(o handler.MapObject) []reconcile.Request {
+	return m.mapAPIObject(o.Meta.GetNamespace(), []map[string]string{o.Meta.GetLabels()}, planSelector)
+}
+
+func (m bindingMapper) mapMetric(o handler.MapObject) []reconcile.Request {
+	return m.mapAPIObject(o.Meta.GetNamespace(), []map[string]string{o.Meta.GetLabels()}, metricSelector)
+}
+
+func (m bindingMapper) mapMappingRule(o handler.MapObject) []reconcile.Request {
+	return m.mapAPIObject(o.Meta.GetNamespace(), []map[string]string{o.Meta.GetLabels()}, mappingRulesSelector)
+}
+
+func (m bindingMapper) mapPolicy(o handler.MapObject) []reconcile.Request {
+	return m.mapAPIObject(o.Meta.GetNamespace(), []map[string]string{o.Meta.GetLabels()}, policiesSelector)
+}
+
+func (m bindingMapper) mapLimit(o handler.MapObject) []reconcile.Request {
+	plans := &apiv1alpha1.PlanList{}
+	err := m.client.List(context.TODO(), plans, client.InNamespace(o.Meta.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Failed to list plans", "Namespace", o.Meta.GetNamespace())
+		return nil
+	}
+
+	planLabels := []map[string]string{}
+	for _, plan := range plans.Items {
+		if selectorMatches(&plan.Spec.LimitSelector, o.Meta.GetLabels()) {
+			planLabels = append(planLabels, plan.Labels)
+		}
+	}
+	return m.mapAPIObject(o.Meta.GetNamespace(), planLabels, planSelector)
+}
+
+func (m bindingMapper) mapConfigMap(o handler.MapObject) []reconcile.Request {
+	return m.mapOpenAPIObject(o.Meta.GetNamespace(), o.Meta.GetName(), openAPIConfigMapRef)
+}
+
+func (m bindingMapper) mapSecret(o handler.MapObject) []reconcile.Request {
+	return m.mapOpenAPIObject(o.Meta.GetNamespace(), o.Meta.GetName(), openAPISecretRef)
+}
+
+// mapOpenAPIObject returns the Bindings selecting the APIs whose OpenAPI document is held by the named object
+func (m bindingMapper) mapOpenAPIObject(namespace, name string, refFn openAPIRefFunc) []reconcile.Request {
+	apis := &apiv1alpha1.APIList{}
+	err := m.client.List(context.TODO(), apis, client.InNamespace(namespace))
+	if err != nil {
+		log.Error(err, "Failed to list APIs", "Namespace", namespace)
+		return nil
+	}
+
+	apiLabels := []map[string]string{}
+	for _, api := range apis.Items {
+		if api.Spec.OpenAPI != nil && refFn(api.Spec.OpenAPI) == name {
+			apiLabels = append(apiLabels, api.Labels)
+		}
+	}
+	return m.bindingRequests(namespace, apiLabels)
+}
+
+// mapAPIObject returns the Bindings selecting the APIs that select any of the objects with the given labels
+func (m bindingMapper) mapAPIObject(namespace string, objectLabels []map[string]string, selectorFn apiSelectorFunc) []reconcile.Request {
+	if len(objectLabels) == 0 {
+		return nil
+	}
+
+	apis := &apiv1alpha1.APIList{}
+	err := m.client.List(context.TODO(), apis, client.InNamespace(namespace))
+	if err != nil {
+		log.Error(err, "Failed to list APIs", "Namespace", namespace)
+		return nil
+	}
+
+	apiLabels := []map[string]string{}
+	for _, api := range apis.Items {
+		for _, objectLabelSet := range objectLabels {
+			if selectorMatches(selectorFn(api), objectLabelSet) {
+				apiLabels = append(apiLabels, api.Labels)
+				break
+			}
+		}
+	}
+	return m.bindingRequests(namespace, apiLabels)
+}
+
+// bindingRequests returns the Bindings selecting any of the APIs with the given labels
+func (m bindingMapper) bindingRequests(namespace string, apiLabels []map[string]string) []reconcile.Request {
+	if len(apiLabels) == 0 {
+		return nil
+	}
+
+	bindings := &apiv1alpha1.BindingList{}
+	err := m.client.List(context.TODO(), bindings, client.InNamespace(namespace))
+	if err != nil {
+		log.Error(err, "Failed to list bindings", "Namespace", namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, binding := range bindings.Items {
+		for _, apiLabelSet := range apiLabels {
+			if selectorMatches(&binding.Spec.APISelector, apiLabelSet) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: binding.Namespace, Name: binding.Name},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// selectorMatches follows the selection of the capabilities objects, which only uses
+// the matchLabels of the selectors. Nil selectors don't select anything
+func selectorMatches(selector *metav1.LabelSelector, objectLabels map[string]string) bool {
+	if selector == nil {
+		return false
+	}
+	return labels.SelectorFromSet(selector.MatchLabels).Matches(labels.Set(objectLabels))
+}
+
+func planSelector(api apiv1alpha1.API) *metav1.LabelSelector {
+	return api.Spec.PlanSelector
+}
+
+func metricSelector(api apiv1alpha1.API) *metav1.LabelSelector {
+	return api.Spec.MetricSelector
+}
+
+func mappingRulesSelector(api apiv1alpha1.API) *metav1.LabelSelector {
+	if api.Spec.IntegrationMethod.ApicastHosted != nil {
+		return api.Spec.IntegrationMethod.ApicastHosted.MappingRulesSelector
+	} else if api.Spec.IntegrationMethod.ApicastOnPrem != nil {
+		return api.Spec.IntegrationMethod.ApicastOnPrem.MappingRulesSelector
+	}
+	return nil
+}
+
+func policiesSelector(api apiv1alpha1.API) *metav1.LabelSelector {
+	if api.Spec.IntegrationMethod.ApicastHosted != nil {
+		return api.Spec.IntegrationMethod.ApicastHosted.PoliciesSelector
+	} else if api.Spec.IntegrationMethod.ApicastOnPrem != nil {
+		return api.Spec.IntegrationMethod.ApicastOnPrem.PoliciesSelector
+	}
+	return nil
+}
+
+func openAPIConfigMapRef(openAPI *apiv1alpha1.OpenAPISpec) string {
+	if openAPI.ConfigMapRef != nil {
+		return openAPI.ConfigMapRef.Name
+	}
+	return ""
+}
+
+func openAPISecretRef(openAPI *apiv1alpha1.OpenAPISpec) string {
+	if openAPI.SecretRef != nil {
+		return openAPI.SecretRef.Name
+	}
+	return ""
+}
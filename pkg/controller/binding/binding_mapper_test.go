@@ -0,0 +1,163 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func testMapper(t *testing.T) bindingMapper {
+	namespace := "someNS"
+	selector := func(key, value string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{key: value}}
+	}
+
+	objects := []runtime.Object{
+		&apiv1alpha1.Binding{
+			ObjectMeta: metav1.ObjectMeta{Name: "binding01", Namespace: namespace},
+			Spec:       apiv1alpha1.BindingSpec{APISelector: *selector("environment", "testing")},
+		},
+		&apiv1alpha1.Binding{
+			ObjectMeta: metav1.ObjectMeta{Name: "binding02", Namespace: namespace},
+			Spec:       apiv1alpha1.BindingSpec{APISelector: *selector("environment", "production")},
+		},
+		&apiv1alpha1.API{
+			ObjectMeta: metav1.ObjectMeta{Name: "api01", Namespace: namespace, Labels: map[string]string{"environment": "testing"}},
+			Spec: apiv1alpha1.APISpec{
+				APISelectors: apiv1alpha1.APISelectors{
+					PlanSelector:   selector("api", "api01"),
+					MetricSelector: selector("api", "api01"),
+				},
+				APIBase: apiv1alpha1.APIBase{
+					IntegrationMethod: apiv1alpha1.IntegrationMethod{
+						ApicastHosted: &apiv1alpha1.ApicastHosted{
+							APIcastBaseSelectors: apiv1alpha1.APIcastBaseSelectors{MappingRulesSelector: selector("api", "api01")},
+						},
+					},
+				},
+			},
+		},
+		&apiv1alpha1.API{
+			ObjectMeta: metav1.ObjectMeta{Name: "api02", Namespace: namespace, Labels: map[string]string{"environment": "production"}},
+			Spec: apiv1alpha1.APISpec{
+				OpenAPI: &apiv1alpha1.OpenAPISpec{
+					ConfigMapRef: &v1.ConfigMapKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "api02-openapi"},
+						Key:                  "openapi.yaml",
+					},
+				},
+			},
+		},
+		&apiv1alpha1.Plan{
+			ObjectMeta: metav1.ObjectMeta{Name: "plan01", Namespace: namespace, Labels: map[string]string{"api": "api01"}},
+			Spec: apiv1alpha1.PlanSpec{
+				PlanSelectors: apiv1alpha1.PlanSelectors{LimitSelector: *selector("plan", "plan01")},
+			},
+		},
+	}
+
+	s := runtime.NewScheme()
+	err := apiv1alpha1.SchemeBuilder.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bindingMapper{client: fake.NewFakeClientWithScheme(s, objects...)}
+}
+
+func TestBindingMapper(t *testing.T) {
+	mapper := testMapper(t)
+	binding01 := []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "someNS", Name: "binding01"}}}
+
+	mapObject := func(objectLabels map[string]string) handler.MapObject {
+		return handler.MapObject{Meta: &metav1.ObjectMeta{Namespace: "someNS", Labels: objectLabels}}
+	}
+
+	cases := []struct {
+		testName         string
+		mapFunc          func(handler.MapObject) []reconcile.Request
+		objectLabels     map[string]string
+		expectedRequests []reconcile.Request
+	}{
+		{"API", mapper.mapAPI, map[string]string{"environment": "testing"}, binding01},
+		{"NotSelectedAPI", mapper.mapAPI, map[string]string{"environment": "staging"}, nil},
+		{"Plan", mapper.mapPlan, map[string]string{"api": "api01"}, binding01},
+		{"Metric", mapper.mapMetric, map[string]string{"api": "api01"}, binding01},
+		{"MappingRule", mapper.mapMappingRule, map[string]string{"api": "api01"}, binding01},
+		{"NotSelectedMappingRule", mapper.mapMappingRule, map[string]string{"api": "api02"}, nil},
+		{"PolicyWithoutSelector", mapper.mapPolicy, map[string]string{"api": "api01"}, nil},
+		{"Limit", mapper.mapLimit, map[string]string{"plan": "plan01"}, binding01},
+		{"NotSelectedLimit", mapper.mapLimit, map[string]string{"plan": "plan02"}, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			requests := tc.mapFunc(mapObject(tc.objectLabels))
+			if !reflect.DeepEqual(requests, tc.expectedRequests) {
+				subT.Errorf("unexpected requests.\ngot:\n%v\nexpected:\n%v", requests, tc.expectedRequests)
+			}
+		})
+	}
+}
+
+func TestBindingMapperOpenAPI(t *testing.T) {
+	mapper := testMapper(t)
+	binding02 := []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "someNS", Name: "binding02"}}}
+
+	mapObject := func(name string) handler.MapObject {
+		return handler.MapObject{Meta: &metav1.ObjectMeta{Namespace: "someNS", Name: name}}
+	}
+
+	cases := []struct {
+		testName         string
+		mapFunc          func(handler.MapObject) []reconcile.Request
+		objectName       string
+		expectedRequests []reconcile.Request
+	}{
+		{"ConfigMap", mapper.mapConfigMap, "api02-openapi", binding02},
+		{"NotReferencedConfigMap", mapper.mapConfigMap, "api01-openapi", nil},
+		{"NotReferencedSecret", mapper.mapSecret, "api02-openapi", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			requests := tc.mapFunc(mapObject(tc.objectName))
+			if !reflect.DeepEqual(requests, tc.expectedRequests) {
+				subT.Errorf("unexpected requests.\ngot:\n%v\nexpected:\n%v", requests, tc.expectedRequests)
+			}
+		})
+	}
+}
+
+func TestCurrentStateCache(t *testing.T) {
+	cache := newCurrentStateCache()
+	binding := types.NamespacedName{Namespace: "someNS", Name: "binding01"}
+	credentials := apiv1alpha1.InternalCredentials{AdminURL: "https://3scale-admin.example.com", AuthToken: "token"}
+	key := currentStateCacheKey(credentials, []string{"api01"})
+	state := apiv1alpha1.State{Credentials: credentials, APIs: []apiv1alpha1.InternalAPI{{Name: "api01"}}}
+
+	cache.set(binding, key, state)
+
+	if _, ok := cache.get(binding, key, time.Minute); !ok {
+		t.Error("expected cached state")
+	}
+	if _, ok := cache.get(binding, currentStateCacheKey(credentials, []string{"api01", "api02"}), time.Minute); ok {
+		t.Error("expected no cached state for other APIs")
+	}
+	if _, ok := cache.get(binding, key, 0); ok {
+		t.Error("expected expired state")
+	}
+
+	cache.invalidate(binding)
+	if _, ok := cache.get(binding, key, time.Minute); ok {
+		t.Error("expected invalidated state")
+	}
+}
@@ -0,0 +1,62 @@
+package binding
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// currentStateCache keeps the last state read from 3scale for every Binding, so the
+// reconciliations triggered by object changes don't read 3scale again before the
+// sync period of the Binding expires. The entries are invalidated when the Binding
+// modifies 3scale
+type currentStateCache struct {
+	mutex   sync.Mutex
+	entries map[types.NamespacedName]currentStateCacheEntry
+}
+
+type currentStateCacheEntry struct {
+	// Credentials and APIs the state was read for
+	key    string
+	state  apiv1alpha1.State
+	readAt time.Time
+}
+
+func newCurrentStateCache() *currentStateCache {
+	return &currentStateCache{entries: map[types.NamespacedName]currentStateCacheEntry{}}
+}
+
+// currentStateCacheKey identifies the 3scale objects read for the APIs of a Binding
+func currentStateCacheKey(credentials apiv1alpha1.InternalCredentials, apiNames []string) string {
+	return credentials.AdminURL + "\n" + credentials.AuthToken + "\n" + strings.Join(apiNames, ",")
+}
+
+// get returns the cached state of the Binding when it was read for the same key less than maxAge ago
+func (c *currentStateCache) get(binding types.NamespacedName, key string, maxAge time.Duration) (*apiv1alpha1.State, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[binding]
+	if !ok || entry.key != key || time.Since(entry.readAt) >= maxAge {
+		return nil, false
+	}
+	state := entry.state
+	return &state, true
+}
+
+func (c *currentStateCache) set(binding types.NamespacedName, key string, state apiv1alpha1.State) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[binding] = currentStateCacheEntry{key: key, state: state, readAt: time.Now()}
+}
+
+func (c *currentStateCache) invalidate(binding types.NamespacedName) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, binding)
+}
@@ -0,0 +1,186 @@
+package developeraccount
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_developeraccount")
+
+// retryPeriod is how long a failed account waits to be reconciled again
+const retryPeriod = 1 * time.Minute
+
+// Add creates a new DeveloperAccount Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileDeveloperAccount{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("developeraccount-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource DeveloperAccount
+	err = c.Watch(&source.Kind{Type: &apiv1alpha1.DeveloperAccount{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// blank assignment to verify that ReconcileDeveloperAccount implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileDeveloperAccount{}
+
+// ReconcileDeveloperAccount reconciles a DeveloperAccount object
+type ReconcileDeveloperAccount struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile makes sure the 3scale developer account of the DeveloperAccount exists and matches its spec
+func (r *ReconcileDeveloperAccount) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling DeveloperAccount")
+
+	account := &apiv1alpha1.DeveloperAccount{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, account)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("DeveloperAccount resource not found")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if account.DeletionTimestamp != nil {
+		if account.HasFinalizer() {
+			reqLogger.Info("DeveloperAccount is terminating, cleaning up")
+			err = r.cleanUp(account, reqLogger)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, account.RemoveFinalizer(r.client)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if !account.HasFinalizer() {
+		err = account.AddFinalizer(r.client)
+		// Expect for re-trigger
+		return reconcile.Result{}, err
+	}
+
+	status, err := r.reconcileAccount(account, reqLogger)
+	if err != nil {
+		reqLogger.Error(err, "Error in developer account reconciliation")
+		status.Error = err.Error()
+	}
+
+	updateErr := r.updateStatus(account, status)
+	if updateErr != nil {
+		return reconcile.Result{}, updateErr
+	}
+	if err != nil {
+		return reconcile.Result{RequeueAfter: retryPeriod}, nil
+	}
+
+	reqLogger.Info("DeveloperAccount reconciled successfully")
+	return reconcile.Result{}, nil
+}
+
+// reconcileAccount creates the 3scale account, or updates it when it exists. The
+// returned status keeps the account ID even on error
+func (r *ReconcileDeveloperAccount) reconcileAccount(account *apiv1alpha1.DeveloperAccount, logger logr.Logger) (apiv1alpha1.DeveloperAccountStatus, error) {
+	status := apiv1alpha1.DeveloperAccountStatus{AccountID: account.Status.AccountID, State: account.Status.State}
+
+	developerClient, err := account.DeveloperClient(r.client)
+	if err != nil {
+		return status, err
+	}
+
+	if status.AccountID != 0 {
+		accountDef, err := developerClient.ReadAccount(status.AccountID)
+		if err != nil && !helper.IsDeveloperNotFound(err) {
+			return status, err
+		}
+		if err == nil {
+			if accountDef.OrgName != account.Spec.OrganizationName {
+				logger.Info("Syncing developer account", "AccountID", status.AccountID)
+				accountDef, err = developerClient.UpdateAccount(status.AccountID, account.Spec.OrganizationName)
+				if err != nil {
+					return status, err
+				}
+			}
+			status.State = accountDef.State
+			return status, nil
+		}
+		logger.Info("Developer account not found in 3scale, creating it again", "AccountID", status.AccountID)
+	}
+
+	logger.Info("Creating a new developer account", "OrganizationName", account.Spec.OrganizationName,
+		"Username", account.Spec.Username, "Email", account.Spec.Email)
+	accountDef, err := developerClient.CreateAccount(account.Spec.OrganizationName, account.Spec.Username, account.Spec.Email)
+	if err != nil {
+		return status, err
+	}
+	status.AccountID = accountDef.ID
+	status.State = accountDef.State
+	return status, nil
+}
+
+// cleanUp deletes the 3scale account, and with it its applications
+func (r *ReconcileDeveloperAccount) cleanUp(account *apiv1alpha1.DeveloperAccount, logger logr.Logger) error {
+	if account.Status.AccountID == 0 {
+		return nil
+	}
+
+	developerClient, err := account.DeveloperClient(r.client)
+	if err != nil {
+		// Without the tenant credentials the account can't be deleted, it is left in 3scale
+		logger.Error(err, "Developer account not deleted from 3scale", "AccountID", account.Status.AccountID)
+		return nil
+	}
+
+	logger.Info("Deleting developer account", "AccountID", account.Status.AccountID)
+	err = developerClient.DeleteAccount(account.Status.AccountID)
+	if err != nil && !helper.IsDeveloperNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *ReconcileDeveloperAccount) updateStatus(account *apiv1alpha1.DeveloperAccount, status apiv1alpha1.DeveloperAccountStatus) error {
+	// don't update the status if there aren't any changes.
+	if reflect.DeepEqual(account.Status, status) {
+		return nil
+	}
+	account.Status = status
+	return r.client.Status().Update(context.TODO(), account)
+}
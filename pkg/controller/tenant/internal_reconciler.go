@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
 	porta_client_pkg "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
@@ -21,61 +22,93 @@ type InternalReconciler struct {
 	k8sClient   client.Client
 	tenantR     *apiv1alpha1.Tenant
 	portaClient *porta_client_pkg.ThreeScaleClient
-	logger      logr.Logger
+	// masterClient manages the provider application of the tenant, not covered by the porta client
+	masterClient *helper.DeveloperClient
+	logger       logr.Logger
 }
 
 // NewInternalReconciler constructs InternalReconciler object
 func NewInternalReconciler(k8sClient client.Client, tenantR *apiv1alpha1.Tenant,
-	portaClient *porta_client_pkg.ThreeScaleClient, log logr.Logger) *InternalReconciler {
+	portaClient *porta_client_pkg.ThreeScaleClient, masterClient *helper.DeveloperClient, log logr.Logger) *InternalReconciler {
 	return &InternalReconciler{
-		k8sClient:   k8sClient,
-		tenantR:     tenantR,
-		portaClient: portaClient,
-		logger:      log,
+		k8sClient:    k8sClient,
+		tenantR:      tenantR,
+		portaClient:  portaClient,
+		masterClient: masterClient,
+		logger:       log,
 	}
 }
 
 // Run tenant reconciliation logic
 // Facts to reconcile:
 // - Have 3scale Tenant Account
-// - Have active admin user
+// - Have active admin user with the password of the admin secret
 // - Have secret with tenant's access_token
+// The status is updated with the progress and the Ready or Failed condition, also on error
 func (r *InternalReconciler) Run() error {
-	tenantDef, err := r.reconcileTenant()
+	tenantStatus := r.tenantR.Status.DeepCopy()
+	tenantStatus.ObservedGeneration = r.tenantR.Generation
+
+	reason, err := r.reconcileStatus(tenantStatus)
 	if err != nil {
-		return err
+		tenantStatus.SetFailed(reason, err.Error())
+	} else {
+		tenantStatus.SetReady()
 	}
 
-	adminUserDef, err := r.reconcileAdminUser(tenantDef)
+	updateErr := r.updateTenantStatus(tenantStatus)
 	if err != nil {
 		return err
 	}
+	return updateErr
+}
 
-	err = r.reconcileAccessTokenSecret(tenantDef)
+// reconcileStatus reconciles the tenant and fills the status with the result.
+// On error, the reason of the failure is returned
+func (r *InternalReconciler) reconcileStatus(tenantStatus *apiv1alpha1.TenantStatus) (string, error) {
+	tenantDef, err := r.reconcileTenant(tenantStatus)
 	if err != nil {
-		return err
+		return "TenantFailed", err
+	}
+
+	adminURL, err := URLFromDomain(tenantDef.Signup.Account.AdminDomain)
+	if err != nil {
+		return "TenantFailed", err
 	}
+	tenantStatus.AdminURL = adminURL.String()
 
-	tenantStatus := r.getTenantStatus(tenantDef, adminUserDef)
+	adminUserDef, err := r.reconcileAdminUser(tenantDef, tenantStatus)
+	if err != nil {
+		return "AdminUserFailed", err
+	}
+	tenantStatus.AdminId = adminUserDef.ID
+
+	err = r.reconcileAccessTokenSecret(tenantDef, tenantStatus)
+	if err != nil {
+		return "TenantSecretFailed", err
+	}
 
-	return r.updateTenantStatus(tenantStatus)
+	return "", nil
 }
 
 // This method makes sure that tenant exists, otherwise it will create one
 // On method completion:
 // * tenant will exist
 // * tenant's attributes will be updated if required
-func (r *InternalReconciler) reconcileTenant() (*porta_client_pkg.Tenant, error) {
+func (r *InternalReconciler) reconcileTenant(tenantStatus *apiv1alpha1.TenantStatus) (*porta_client_pkg.Tenant, error) {
 	tenantDef, err := r.fetchTenant()
 	if err != nil {
 		return nil, err
 	}
 
 	if tenantDef == nil {
-		tenantDef, err = r.createTenant()
+		tenantDef, err = r.createTenant(tenantStatus)
 		if err != nil {
 			return nil, err
 		}
+		// The admin user of a previous tenant is gone with it
+		tenantStatus.TenantId = tenantDef.Signup.Account.ID
+		tenantStatus.AdminId = 0
 	} else {
 		r.logger.Info("Tenant already exists", "TenantId", tenantDef.Signup.Account.ID)
 		// Tenant is not created, check tenant desired state matches current state
@@ -141,8 +174,8 @@ func (r *InternalReconciler) syncTenant(tenantDef *porta_client_pkg.Tenant) erro
 // This method makes sure admin user:
 // * is active
 // * user's attributes will be updated if required
-func (r *InternalReconciler) reconcileAdminUser(tenantDef *porta_client_pkg.Tenant) (*porta_client_pkg.User, error) {
-	adminUserDef, err := r.fetchAdminUser(tenantDef)
+func (r *InternalReconciler) reconcileAdminUser(tenantDef *porta_client_pkg.Tenant, tenantStatus *apiv1alpha1.TenantStatus) (*porta_client_pkg.User, error) {
+	adminUserDef, err := r.fetchAdminUser(tenantDef, tenantStatus)
 	if err != nil {
 		return nil, err
 	}
@@ -152,11 +185,17 @@ func (r *InternalReconciler) reconcileAdminUser(tenantDef *porta_client_pkg.Tena
 		return nil, err
 	}
 
+	err = r.syncAdminPassword(tenantDef, adminUserDef, tenantStatus)
+	if err != nil {
+		return nil, err
+	}
+
 	return adminUserDef, nil
 }
 
 // This method makes sure secret with tenant's access_token exists
-func (r *InternalReconciler) reconcileAccessTokenSecret(tenantDef *porta_client_pkg.Tenant) error {
+// and regenerates the access token when a rotation is requested
+func (r *InternalReconciler) reconcileAccessTokenSecret(tenantDef *porta_client_pkg.Tenant, tenantStatus *apiv1alpha1.TenantStatus) error {
 	tenantProviderKeySecretNN := types.NamespacedName{
 		Name:      r.tenantR.Spec.TenantSecretRef.Name,
 		Namespace: r.tenantR.Spec.TenantSecretRef.Namespace,
@@ -166,6 +205,14 @@ func (r *InternalReconciler) reconcileAccessTokenSecret(tenantDef *porta_client_
 		return err
 	}
 
+	if r.tenantR.Spec.AccessTokenRotation != tenantStatus.AccessTokenRotation {
+		err = r.rotateTenantProviderKey(tenantDef, tenantProviderKeySecret)
+		if err != nil {
+			return err
+		}
+		tenantStatus.AccessTokenRotation = r.tenantR.Spec.AccessTokenRotation
+	}
+
 	if tenantProviderKeySecret == nil {
 		err = r.createTenantProviderKeySecret(tenantDef, tenantProviderKeySecretNN)
 		if err != nil {
@@ -179,23 +226,30 @@ func (r *InternalReconciler) reconcileAccessTokenSecret(tenantDef *porta_client_
 }
 
 // Create Tenant using porta client
-func (r *InternalReconciler) createTenant() (*porta_client_pkg.Tenant, error) {
-	password, err := r.getAdminPassword()
+func (r *InternalReconciler) createTenant(tenantStatus *apiv1alpha1.TenantStatus) (*porta_client_pkg.Tenant, error) {
+	password, passwordVersion, err := r.getAdminPassword()
 	if err != nil {
 		return nil, err
 	}
 
 	r.logger.Info("Creating a new tenant", "OrganizationName", r.tenantR.Spec.OrganizationName,
 		"Username", r.tenantR.Spec.Username, "Email", r.tenantR.Spec.Email)
-	return r.portaClient.CreateTenant(
+	tenantDef, err := r.portaClient.CreateTenant(
 		r.tenantR.Spec.OrganizationName,
 		r.tenantR.Spec.Username,
 		r.tenantR.Spec.Email,
 		password,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantStatus.PasswordSecretVersion = passwordVersion
+	return tenantDef, nil
 }
 
-func (r *InternalReconciler) getAdminPassword() (string, error) {
+// getAdminPassword returns the admin password and the resource version of its secret
+func (r *InternalReconciler) getAdminPassword() (string, string, error) {
 	// Get tenant admin password from secret reference
 	tenantAdminSecret := &v1.Secret{}
 
@@ -207,28 +261,28 @@ func (r *InternalReconciler) getAdminPassword() (string, error) {
 		tenantAdminSecret)
 
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	passwordByteArray, ok := tenantAdminSecret.Data[TenantAdminPasswordSecretField]
 	if !ok {
-		return "", fmt.Errorf("Not found admin password secret (ns: %s, name: %s) attribute: %s",
+		return "", "", fmt.Errorf("Not found admin password secret (ns: %s, name: %s) attribute: %s",
 			r.tenantR.Namespace, r.tenantR.Spec.PasswordCredentialsRef.Name,
 			TenantAdminPasswordSecretField)
 	}
 
-	return bytes.NewBuffer(passwordByteArray).String(), err
+	return bytes.NewBuffer(passwordByteArray).String(), tenantAdminSecret.ResourceVersion, err
 }
 
 //
-func (r *InternalReconciler) fetchAdminUser(tenantDef *porta_client_pkg.Tenant) (*porta_client_pkg.User, error) {
-	if r.tenantR.Status.AdminId == 0 {
+func (r *InternalReconciler) fetchAdminUser(tenantDef *porta_client_pkg.Tenant, tenantStatus *apiv1alpha1.TenantStatus) (*porta_client_pkg.User, error) {
+	if tenantStatus.AdminId == 0 {
 		// UserID not in status field
 		return r.findAdminUser(tenantDef)
 	}
 
 	//
-	return r.portaClient.ReadUser(tenantDef.Signup.Account.ID, r.tenantR.Status.AdminId)
+	return r.portaClient.ReadUser(tenantDef.Signup.Account.ID, tenantStatus.AdminId)
 }
 
 func (r *InternalReconciler) findAdminUser(tenantDef *porta_client_pkg.Tenant) (*porta_client_pkg.User, error) {
@@ -292,6 +346,31 @@ func (r *InternalReconciler) syncAdminUser(tenantDef *porta_client_pkg.Tenant, a
 	return nil
 }
 
+// syncAdminPassword sets the password of the admin secret to the admin user when
+// the secret changed since the password was last set
+func (r *InternalReconciler) syncAdminPassword(tenantDef *porta_client_pkg.Tenant, adminUser *porta_client_pkg.User, tenantStatus *apiv1alpha1.TenantStatus) error {
+	password, passwordVersion, err := r.getAdminPassword()
+	if err != nil {
+		return err
+	}
+
+	if passwordVersion == tenantStatus.PasswordSecretVersion {
+		return nil
+	}
+
+	r.logger.Info("Rotating admin user password", "TenantId", tenantDef.Signup.Account.ID, "UserID", adminUser.ID)
+	params := porta_client_pkg.Params{
+		"password": password,
+	}
+	_, err = r.portaClient.UpdateUser(tenantDef.Signup.Account.ID, adminUser.ID, params)
+	if err != nil {
+		return err
+	}
+
+	tenantStatus.PasswordSecretVersion = passwordVersion
+	return nil
+}
+
 func (r *InternalReconciler) activateAdminUser(tenantDef *porta_client_pkg.Tenant, adminUser *porta_client_pkg.User) error {
 	r.logger.Info("Activating pending admin user", "Account ID", tenantDef.Signup.Account.ID, "ID", adminUser.ID)
 	return r.portaClient.ActivateUser(tenantDef.Signup.Account.ID, adminUser.ID)
@@ -346,25 +425,57 @@ func (r *InternalReconciler) createTenantProviderKeySecret(tenantDef *porta_clie
 	return r.k8sClient.Create(context.TODO(), secret)
 }
 
+// rotateTenantProviderKey regenerates the provider key of the tenant and writes it
+// into the existing tenant secret. A missing secret is created afterwards with the new key
+func (r *InternalReconciler) rotateTenantProviderKey(tenantDef *porta_client_pkg.Tenant, secret *v1.Secret) error {
+	providerApplication, err := r.findTenantProviderApplication(tenantDef)
+	if err != nil {
+		return err
+	}
+
+	tenantProviderKey, err := apiv1alpha1.NewUserKey()
+	if err != nil {
+		return err
+	}
+
+	r.logger.Info("Regenerating tenant provider key", "TenantId", tenantDef.Signup.Account.ID)
+	_, err = r.masterClient.UpdateApplicationUserKey(tenantDef.Signup.Account.ID, providerApplication.ID, tenantProviderKey)
+	if err != nil {
+		return err
+	}
+
+	if secret == nil {
+		return nil
+	}
+
+	r.logger.Info("Updating admin access token secret", "Secret NS", secret.Namespace, "Secret name", secret.Name)
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[TenantProviderKeySecretField] = []byte(tenantProviderKey)
+	return r.k8sClient.Update(context.TODO(), secret)
+}
+
 func (r *InternalReconciler) findTenantProviderKey(tenantDef *porta_client_pkg.Tenant) (string, error) {
+	providerApplication, err := r.findTenantProviderApplication(tenantDef)
+	if err != nil {
+		return "", err
+	}
+	return providerApplication.UserKey, nil
+}
+
+func (r *InternalReconciler) findTenantProviderApplication(tenantDef *porta_client_pkg.Tenant) (*porta_client_pkg.Application, error) {
 	// Tenant Provider Key is available on provider application list
 	appList, err := r.portaClient.ListApplications(tenantDef.Signup.Account.ID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if len(appList.Applications) != 1 {
-		return "", fmt.Errorf("Unexpected application list. TenantId: %d", tenantDef.Signup.Account.ID)
+		return nil, fmt.Errorf("Unexpected application list. TenantId: %d", tenantDef.Signup.Account.ID)
 	}
 
-	return appList.Applications[0].Application.UserKey, nil
-}
-
-func (r *InternalReconciler) getTenantStatus(tenantDef *porta_client_pkg.Tenant, adminUserDef *porta_client_pkg.User) *apiv1alpha1.TenantStatus {
-	return &apiv1alpha1.TenantStatus{
-		TenantId: tenantDef.Signup.Account.ID,
-		AdminId:  adminUserDef.ID,
-	}
+	return &appList.Applications[0].Application, nil
 }
 
 func (r *InternalReconciler) updateTenantStatus(tenantStatus *apiv1alpha1.TenantStatus) error {
@@ -3,11 +3,14 @@ package tenant
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	porta_client_pkg "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -50,6 +53,8 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	mapper := tenantMapper{client: mgr.GetClient()}
+
 	// Create a new controller
 	c, err := controller.New("tenant-controller", mgr, controller.Options{Reconciler: r})
 	if err != nil {
@@ -62,6 +67,12 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// The admin password is rotated when its secret changes
+	err = c.Watch(&source.Kind{Type: &v1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(mapper.mapPasswordSecret)})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -102,6 +113,20 @@ func (r *ReconcileTenant) Reconcile(request reconcile.Request) (reconcile.Result
 		return reconcile.Result{}, err
 	}
 
+	if tenantR.DeletionTimestamp != nil {
+		if tenantR.HasFinalizer() {
+			reqLogger.Info("Tenant is terminating, cleaning up")
+			err = r.cleanUp(tenantR, reqLogger)
+			if err != nil {
+				// The master portal may be unreachable, the finalizer is kept until it is back
+				log.Error(err, "Error cleaning up tenant")
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, tenantR.RemoveFinalizer(r.client)
+		}
+		return reconcile.Result{}, nil
+	}
+
 	changed := tenantR.SetDefaults()
 	if changed {
 		err = r.client.Update(context.TODO(), tenantR)
@@ -113,21 +138,27 @@ func (r *ReconcileTenant) Reconcile(request reconcile.Request) (reconcile.Result
 		return reconcile.Result{}, nil
 	}
 
-	masterAccessToken, err := FetchMasterCredentials(r.client, tenantR)
-	if err != nil {
-		log.Error(err, "Error fetching master credentials secret")
-		// Error reading the object - requeue the request.
+	if !tenantR.HasFinalizer() {
+		err = tenantR.AddFinalizer(r.client)
+		// Expect for re-trigger
 		return reconcile.Result{}, err
 	}
 
-	portaClient, err := helper.PortaClientFromURLString(tenantR.Spec.SystemMasterUrl, masterAccessToken)
+	portaClient, err := r.masterPortaClient(tenantR)
 	if err != nil {
 		log.Error(err, "Error creating porta client object")
 		// Error reading the object - requeue the request.
-		return reconcile.Result{}, err
+		return r.failTenant(tenantR, "InvalidMasterCredentials", err)
 	}
 
-	internalReconciler := NewInternalReconciler(r.client, tenantR, portaClient, reqLogger)
+	masterClient, err := r.masterDeveloperClient(tenantR)
+	if err != nil {
+		log.Error(err, "Error creating master client object")
+		// Error reading the object - requeue the request.
+		return r.failTenant(tenantR, "InvalidMasterCredentials", err)
+	}
+
+	internalReconciler := NewInternalReconciler(r.client, tenantR, portaClient, masterClient, reqLogger)
 	err = internalReconciler.Run()
 	if err != nil {
 		log.Error(err, "Error in tenant reconciliation")
@@ -139,6 +170,109 @@ func (r *ReconcileTenant) Reconcile(request reconcile.Request) (reconcile.Result
 	return reconcile.Result{}, nil
 }
 
+// failTenant sets the Failed condition of the tenant and returns the error to requeue it
+func (r *ReconcileTenant) failTenant(tenantR *apiv1alpha1.Tenant, reason string, err error) (reconcile.Result, error) {
+	tenantR.Status.ObservedGeneration = tenantR.Generation
+	tenantR.Status.SetFailed(reason, err.Error())
+	updateErr := r.client.Status().Update(context.TODO(), tenantR)
+	if updateErr != nil {
+		log.Error(updateErr, "Failed to update status of tenant object")
+	}
+	return reconcile.Result{}, err
+}
+
+// masterPortaClient returns a 3scale client for the master portal of the tenant
+func (r *ReconcileTenant) masterPortaClient(tenantR *apiv1alpha1.Tenant) (*porta_client_pkg.ThreeScaleClient, error) {
+	masterAccessToken, tlsConfig, err := r.masterCredentials(tenantR)
+	if err != nil {
+		return nil, err
+	}
+	return helper.PortaClientFromURLString(tenantR.Spec.SystemMasterUrl, masterAccessToken, tlsConfig)
+}
+
+// masterDeveloperClient returns a client managing the tenant accounts and their provider
+// applications, which are the developer accounts and applications of the master portal
+func (r *ReconcileTenant) masterDeveloperClient(tenantR *apiv1alpha1.Tenant) (*helper.DeveloperClient, error) {
+	masterAccessToken, tlsConfig, err := r.masterCredentials(tenantR)
+	if err != nil {
+		return nil, err
+	}
+	return helper.DeveloperClientFromURLString(tenantR.Spec.SystemMasterUrl, masterAccessToken, tlsConfig)
+}
+
+func (r *ReconcileTenant) masterCredentials(tenantR *apiv1alpha1.Tenant) (string, *tls.Config, error) {
+	masterAccessToken, err := FetchMasterCredentials(r.client, tenantR)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tlsConfig, err := tenantR.Spec.TLS.NewTLSConfig(r.client, tenantR.Namespace)
+	if err != nil {
+		return "", nil, err
+	}
+	return masterAccessToken, tlsConfig, nil
+}
+
+// cleanUp schedules the tenant account for deletion when the deletion policy is Delete, and then
+// deletes the tenant secret written by the controller. An unreachable master portal returns an error,
+// so the tenant is kept until the account can be deleted
+func (r *ReconcileTenant) cleanUp(tenantR *apiv1alpha1.Tenant, logger logr.Logger) error {
+	if tenantR.GetDeletionPolicy() == apiv1alpha1.DeletionPolicyDelete && tenantR.Status.TenantId != 0 {
+		portaClient, err := r.masterPortaClient(tenantR)
+		if err != nil && errors.IsNotFound(err) {
+			// Without the master credentials the account can't be deleted, it is left in 3scale
+			logger.Error(err, "Tenant account not deleted from 3scale", "TenantId", tenantR.Status.TenantId)
+		} else if err != nil {
+			return err
+		} else {
+			logger.Info("Scheduling tenant account for deletion", "TenantId", tenantR.Status.TenantId)
+			err = portaClient.DeleteTenant(tenantR.Status.TenantId)
+			if err != nil && !porta_client_pkg.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return r.deleteTenantSecret(tenantR, logger)
+}
+
+// deleteTenantSecret deletes the tenant secret when it was created by the tenant. Secrets
+// provided by the user are kept
+func (r *ReconcileTenant) deleteTenantSecret(tenantR *apiv1alpha1.Tenant, logger logr.Logger) error {
+	if tenantR.Spec.TenantSecretRef.Name == "" {
+		return nil
+	}
+
+	secret := &v1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{
+		Name:      tenantR.Spec.TenantSecretRef.Name,
+		Namespace: tenantR.Spec.TenantSecretRef.Namespace,
+	}, secret)
+	if err != nil && errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	owned := false
+	for _, ref := range secret.GetOwnerReferences() {
+		if ref.UID == tenantR.UID {
+			owned = true
+		}
+	}
+	if !owned {
+		return nil
+	}
+
+	logger.Info("Deleting tenant secret", "Secret NS", secret.Namespace, "Secret name", secret.Name)
+	err = r.client.Delete(context.TODO(), secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 // FetchMasterCredentials get secret using k8s client
 func FetchMasterCredentials(k8sClient client.Client, tenantR *apiv1alpha1.Tenant) (string, error) {
 	masterCredentialsSecret := &v1.Secret{}
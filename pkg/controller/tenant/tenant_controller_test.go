@@ -0,0 +1,207 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestTenantCleanUp(t *testing.T) {
+	const namespace = "operator-unittest"
+
+	cases := []struct {
+		name             string
+		deletionPolicy   apiv1alpha1.DeletionPolicy
+		secretOwned      bool
+		masterStatus     int
+		expectDeleteCall bool
+		expectError      bool
+		expectSecret     bool
+	}{
+		{"Orphan", "", true, http.StatusOK, false, false, false},
+		{"Delete", apiv1alpha1.DeletionPolicyDelete, true, http.StatusOK, true, false, false},
+		{"AlreadyDeleted", apiv1alpha1.DeletionPolicyDelete, true, http.StatusNotFound, true, false, false},
+		{"MasterUnavailable", apiv1alpha1.DeletionPolicyDelete, true, http.StatusServiceUnavailable, true, true, true},
+		{"UserSecret", apiv1alpha1.DeletionPolicyDelete, false, http.StatusOK, true, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			deleteCalled := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.Method == "DELETE" && req.URL.Path == "/master/api/providers/2.json" {
+					deleteCalled = true
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.masterStatus)
+				w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			tenantR := &apiv1alpha1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "ecorp", Namespace: namespace, UID: "tenant-uid"},
+				Spec: apiv1alpha1.TenantSpec{
+					SystemMasterUrl:      server.URL,
+					MasterCredentialsRef: v1.SecretReference{Name: "system-seed"},
+					TenantSecretRef:      v1.SecretReference{Name: "ecorp-tenant-secret", Namespace: namespace},
+					DeletionPolicy:       tc.deletionPolicy,
+				},
+				Status: apiv1alpha1.TenantStatus{TenantId: 2, AdminId: 3},
+			}
+			masterSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "system-seed", Namespace: namespace},
+				Data:       map[string][]byte{component.SystemSecretSystemSeedMasterAccessTokenFieldName: []byte("token")},
+			}
+			tenantSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "ecorp-tenant-secret", Namespace: namespace},
+			}
+			if tc.secretOwned {
+				addOwnerRefToObject(tenantSecret, asOwner(tenantR))
+			}
+
+			s := runtime.NewScheme()
+			err := scheme.AddToScheme(s)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			err = apiv1alpha1.SchemeBuilder.AddToScheme(s)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			cl := fake.NewFakeClientWithScheme(s, tenantR, masterSecret, tenantSecret)
+			r := &ReconcileTenant{client: cl, scheme: s}
+
+			err = r.cleanUp(tenantR, logf.Log.WithName("test"))
+			if tc.expectError != (err != nil) {
+				subT.Fatalf("unexpected error: %v", err)
+			}
+			if deleteCalled != tc.expectDeleteCall {
+				subT.Errorf("expected delete call %t, got %t", tc.expectDeleteCall, deleteCalled)
+			}
+
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: "ecorp-tenant-secret", Namespace: namespace}, &v1.Secret{})
+			if err != nil && !errors.IsNotFound(err) {
+				subT.Fatal(err)
+			}
+			if secretExists := err == nil; secretExists != tc.expectSecret {
+				subT.Errorf("expected tenant secret %t, got %t", tc.expectSecret, secretExists)
+			}
+		})
+	}
+}
+
+func TestTenantRotateCredentials(t *testing.T) {
+	const namespace = "operator-unittest"
+
+	var updates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch fmt.Sprintf("%s %s", req.Method, req.URL.Path) {
+		case "GET /master/api/providers/2.json":
+			fmt.Fprint(w, `{"signup":{"account":{"id":2,"org_name":"ECorp","support_email":"admin@example.com","admin_domain":"ecorp-admin.example.com"}}}`)
+		case "GET /admin/api/accounts/2/users/3.json", "PUT /admin/api/accounts/2/users/3.json":
+			if req.Method == "PUT" {
+				updates = append(updates, "password="+req.FormValue("password"))
+			}
+			fmt.Fprint(w, `{"user":{"id":3,"state":"active","username":"admin","email":"admin@example.com","account_id":2}}`)
+		case "GET /admin/api/accounts/2/applications.json":
+			fmt.Fprint(w, `{"applications":[{"application":{"id":5,"user_key":"oldkey"}}]}`)
+		case "PUT /admin/api/accounts/2/applications/5.json":
+			updates = append(updates, "user_key="+req.FormValue("user_key"))
+			fmt.Fprintf(w, `{"application":{"id":5,"user_key":"%s"}}`, req.FormValue("user_key"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":"Not found"}`)
+		}
+	}))
+	defer server.Close()
+
+	tenantR := &apiv1alpha1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "ecorp", Namespace: namespace, Generation: 2},
+		Spec: apiv1alpha1.TenantSpec{
+			Username:               "admin",
+			Email:                  "admin@example.com",
+			OrganizationName:       "ECorp",
+			SystemMasterUrl:        server.URL,
+			PasswordCredentialsRef: v1.SecretReference{Name: "ecorp-admin-secret"},
+			TenantSecretRef:        v1.SecretReference{Name: "ecorp-tenant-secret", Namespace: namespace},
+			AccessTokenRotation:    1,
+		},
+		Status: apiv1alpha1.TenantStatus{TenantId: 2, AdminId: 3, PasswordSecretVersion: "old"},
+	}
+	passwordSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ecorp-admin-secret", Namespace: namespace},
+		Data:       map[string][]byte{TenantAdminPasswordSecretField: []byte("newpassword")},
+	}
+	tenantSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ecorp-tenant-secret", Namespace: namespace},
+		Data:       map[string][]byte{TenantProviderKeySecretField: []byte("oldkey")},
+	}
+
+	s := runtime.NewScheme()
+	err := scheme.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = apiv1alpha1.SchemeBuilder.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewFakeClientWithScheme(s, tenantR, passwordSecret, tenantSecret)
+
+	portaClient, err := helper.PortaClientFromURLString(server.URL, "token", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	masterClient, err := helper.DeveloperClientFromURLString(server.URL, "token", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err = NewInternalReconciler(cl, tenantR, portaClient, masterClient, logf.Log.WithName("test")).Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(updates) != 2 || updates[0] != "password=newpassword" || len(updates[1]) != len("user_key=")+32 {
+		t.Fatalf("unexpected updates: %v", updates)
+	}
+
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "ecorp-tenant-secret", Namespace: namespace}, tenantSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if "user_key="+string(tenantSecret.Data[TenantProviderKeySecretField]) != updates[1] {
+		t.Errorf("tenant secret not updated with the new provider key: %s", tenantSecret.Data[TenantProviderKeySecretField])
+	}
+
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "ecorp", Namespace: namespace}, tenantR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tenantR.Status.IsConditionTrue(apiv1alpha1.TenantReady) || tenantR.Status.IsConditionTrue(apiv1alpha1.TenantFailed) {
+		t.Errorf("unexpected conditions: %v", tenantR.Status.Conditions)
+	}
+	if tenantR.Status.AdminURL != "https://ecorp-admin.example.com" {
+		t.Errorf("unexpected admin URL: %s", tenantR.Status.AdminURL)
+	}
+	if tenantR.Status.ObservedGeneration != 2 || tenantR.Status.AccessTokenRotation != 1 || tenantR.Status.PasswordSecretVersion == "old" {
+		t.Errorf("unexpected status: %v", tenantR.Status)
+	}
+}
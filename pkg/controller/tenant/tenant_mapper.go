@@ -0,0 +1,35 @@
+package tenant
+
+import (
+	"context"
+
+	apiv1alpha1 "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// tenantMapper maps the changed admin password secrets to the tenants referencing them
+type tenantMapper struct {
+	client client.Client
+}
+
+func (m tenantMapper) mapPasswordSecret(o handler.MapObject) []reconcile.Request {
+	tenants := &apiv1alpha1.TenantList{}
+	err := m.client.List(context.TODO(), tenants, client.InNamespace(o.Meta.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Failed to list tenants", "Namespace", o.Meta.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, tenantR := range tenants.Items {
+		if tenantR.Spec.PasswordCredentialsRef.Name == o.Meta.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: tenantR.Namespace, Name: tenantR.Name},
+			})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AccessTokenClient manages the personal access tokens of the user owning the access
+// token of the client. The requests can be sent to the system services with the host
+// of the portal, which is how system finds the tenant of the request
+type AccessTokenClient struct {
+	DeveloperClient
+}
+
+// AccessToken is a personal access token. The value is only returned when it is created
+type AccessToken struct {
+	ID         int64    `json:"id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	Permission string   `json:"permission"`
+	Value      string   `json:"value"`
+}
+
+type accessTokenElem struct {
+	AccessToken AccessToken `json:"access_token"`
+}
+
+// AccessTokenClientFromURLString instantiates an AccessTokenClient from the portal url string.
+// An empty host sends the host of the url. A nil tlsConfig verifies the portal certificate
+// with the system CAs
+func AccessTokenClientFromURLString(portalURLStr, host, accessToken string, tlsConfig *tls.Config) (*AccessTokenClient, error) {
+	developerClient, err := DeveloperClientFromURLString(portalURLStr, accessToken, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	developerClient.host = host
+	return &AccessTokenClient{DeveloperClient: *developerClient}, nil
+}
+
+// CreateAccessToken creates a read-write access token with the given scopes
+func (c *AccessTokenClient) CreateAccessToken(name string, scopes []string) (*AccessToken, error) {
+	values := url.Values{}
+	values.Set("name", name)
+	values.Set("permission", "rw")
+	for _, scope := range scopes {
+		values.Add("scopes[]", scope)
+	}
+
+	elem := &accessTokenElem{}
+	err := c.do("POST", "/admin/api/personal/access_tokens.json", values, http.StatusCreated, elem)
+	if err != nil {
+		return nil, err
+	}
+	return &elem.AccessToken, nil
+}
+
+// DeleteAccessToken deletes the access token with the given ID or value
+func (c *AccessTokenClient) DeleteAccessToken(idOrValue string) error {
+	return c.do("DELETE", fmt.Sprintf("/admin/api/personal/access_tokens/%s.json", url.PathEscape(idOrValue)), nil, http.StatusOK, nil)
+}
@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccessTokenClient(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s %s", r.Host, r.Method, r.URL.Path))
+		if _, password, ok := r.BasicAuth(); !ok || password != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch fmt.Sprintf("%s %s", r.Method, r.URL.Path) {
+		case "POST /admin/api/personal/access_tokens.json":
+			if r.FormValue("permission") != "rw" || len(r.Form["scopes[]"]) != 2 {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"access_token":{"id":4,"name":"%s","permission":"rw","value":"newtoken"}}`, r.FormValue("name"))
+		case "DELETE /admin/api/personal/access_tokens/token.json":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := AccessTokenClientFromURLString(server.URL, "ecorp-admin.example.com", "token", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := c.CreateAccessToken("rotated", []string{"account_management", "stats"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ID != 4 || token.Name != "rotated" || token.Value != "newtoken" {
+		t.Errorf("unexpected access token: %v", token)
+	}
+
+	err = c.DeleteAccessToken("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.DeleteAccessToken("unknown")
+	if !IsDeveloperNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	expected := "ecorp-admin.example.com POST /admin/api/personal/access_tokens.json"
+	if len(requests) != 3 || requests[0] != expected {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+}
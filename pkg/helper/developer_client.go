@@ -0,0 +1,241 @@
+package helper
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/3scale/3scale-porta-go-client/client"
+)
+
+// DeveloperClient manages the developer accounts and applications of a 3scale tenant.
+// The porta client only lists them, the account management API endpoints needed
+// to provision them are called here with the same admin URL and access token
+type DeveloperClient struct {
+	adminURL    *url.URL
+	accessToken string
+	httpClient  *http.Client
+	// Host header of the requests, the host of the admin URL when empty
+	host string
+}
+
+// DeveloperApplication is a 3scale application with the credentials of the
+// app_id/app_key authentication mode, not included in the porta client type
+type DeveloperApplication struct {
+	client.Application
+	ApplicationID string `json:"application_id"`
+}
+
+type developerApplicationElem struct {
+	Application DeveloperApplication `json:"application"`
+}
+
+type developerApplicationList struct {
+	Applications []developerApplicationElem `json:"applications"`
+}
+
+// DeveloperAPIError is an unexpected response of the account management API
+type DeveloperAPIError struct {
+	Code int
+	Body string
+}
+
+func (e DeveloperAPIError) Error() string {
+	return fmt.Sprintf("error calling 3scale system - code: %d - %s", e.Code, e.Body)
+}
+
+// IsDeveloperNotFound returns true if the error is a not found response of the account management API
+func IsDeveloperNotFound(err error) bool {
+	apiErr, ok := err.(DeveloperAPIError)
+	return ok && apiErr.Code == http.StatusNotFound
+}
+
+// DeveloperClientFromURLString instantiates a DeveloperClient from the admin url string.
+// A nil tlsConfig verifies the portal certificate with the system CAs
+func DeveloperClientFromURLString(adminURLStr, accessToken string, tlsConfig *tls.Config) (*DeveloperClient, error) {
+	adminURL, err := url.Parse(adminURLStr)
+	if err != nil {
+		return nil, err
+	}
+	if adminURL.Scheme != "http" && adminURL.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported schema %s passed to adminPortal", adminURL.Scheme)
+	}
+
+	tr := &http.Transport{
+		TLSClientConfig: tlsConfig,
+	}
+
+	return &DeveloperClient{
+		adminURL:    adminURL,
+		accessToken: accessToken,
+		httpClient:  &http.Client{Transport: tr},
+	}, nil
+}
+
+// CreateAccount signs up a developer account with its admin user
+func (c *DeveloperClient) CreateAccount(orgName, username, email string) (*client.Account, error) {
+	values := url.Values{}
+	values.Add("org_name", orgName)
+	values.Add("username", username)
+	values.Add("email", email)
+
+	accountElem := &client.AccountElem{}
+	err := c.do("POST", "/admin/api/signup.json", values, http.StatusCreated, accountElem)
+	if err != nil {
+		return nil, err
+	}
+	return &accountElem.Account, nil
+}
+
+// ReadAccount returns the developer account with the given ID
+func (c *DeveloperClient) ReadAccount(accountID int64) (*client.Account, error) {
+	accountElem := &client.AccountElem{}
+	err := c.do("GET", fmt.Sprintf("/admin/api/accounts/%d.json", accountID), nil, http.StatusOK, accountElem)
+	if err != nil {
+		return nil, err
+	}
+	return &accountElem.Account, nil
+}
+
+// UpdateAccount updates the organization name of the developer account
+func (c *DeveloperClient) UpdateAccount(accountID int64, orgName string) (*client.Account, error) {
+	values := url.Values{}
+	values.Add("org_name", orgName)
+
+	accountElem := &client.AccountElem{}
+	err := c.do("PUT", fmt.Sprintf("/admin/api/accounts/%d.json", accountID), values, http.StatusOK, accountElem)
+	if err != nil {
+		return nil, err
+	}
+	return &accountElem.Account, nil
+}
+
+// DeleteAccount deletes the developer account and its applications
+func (c *DeveloperClient) DeleteAccount(accountID int64) error {
+	return c.do("DELETE", fmt.Sprintf("/admin/api/accounts/%d.json", accountID), nil, http.StatusOK, nil)
+}
+
+// CreateApplication creates an application of the account subscribed to the plan. The
+// credentials, user_key or application_id and application_key, are generated by 3scale
+// when they are not given
+func (c *DeveloperClient) CreateApplication(accountID int64, planID, name, description string, credentials map[string]string) (*DeveloperApplication, error) {
+	values := url.Values{}
+	values.Add("plan_id", planID)
+	values.Add("name", name)
+	values.Add("description", description)
+	for key, value := range credentials {
+		values.Add(key, value)
+	}
+
+	applicationElem := &developerApplicationElem{}
+	err := c.do("POST", fmt.Sprintf("/admin/api/accounts/%d/applications.json", accountID), values, http.StatusCreated, applicationElem)
+	if err != nil {
+		return nil, err
+	}
+	return &applicationElem.Application, nil
+}
+
+// ReadApplication returns the application of the account with the given ID
+func (c *DeveloperClient) ReadApplication(accountID, applicationID int64) (*DeveloperApplication, error) {
+	applicationElem := &developerApplicationElem{}
+	err := c.do("GET", fmt.Sprintf("/admin/api/accounts/%d/applications/%d.json", accountID, applicationID), nil, http.StatusOK, applicationElem)
+	if err != nil {
+		return nil, err
+	}
+	return &applicationElem.Application, nil
+}
+
+// ListApplications returns the applications of the account
+func (c *DeveloperClient) ListApplications(accountID int64) ([]DeveloperApplication, error) {
+	applicationList := &developerApplicationList{}
+	err := c.do("GET", fmt.Sprintf("/admin/api/accounts/%d/applications.json", accountID), nil, http.StatusOK, applicationList)
+	if err != nil {
+		return nil, err
+	}
+	applications := []DeveloperApplication{}
+	for _, applicationElem := range applicationList.Applications {
+		applications = append(applications, applicationElem.Application)
+	}
+	return applications, nil
+}
+
+// ChangeApplicationPlan subscribes the application to another plan of the same service
+func (c *DeveloperClient) ChangeApplicationPlan(accountID, applicationID int64, planID string) (*DeveloperApplication, error) {
+	values := url.Values{}
+	values.Add("plan_id", planID)
+
+	applicationElem := &developerApplicationElem{}
+	err := c.do("PUT", fmt.Sprintf("/admin/api/accounts/%d/applications/%d/change_plan.json", accountID, applicationID), values, http.StatusOK, applicationElem)
+	if err != nil {
+		return nil, err
+	}
+	return &applicationElem.Application, nil
+}
+
+// UpdateApplicationUserKey replaces the user_key of an application of the API key authentication mode
+func (c *DeveloperClient) UpdateApplicationUserKey(accountID, applicationID int64, userKey string) (*DeveloperApplication, error) {
+	values := url.Values{}
+	values.Add("user_key", userKey)
+
+	applicationElem := &developerApplicationElem{}
+	err := c.do("PUT", fmt.Sprintf("/admin/api/accounts/%d/applications/%d.json", accountID, applicationID), values, http.StatusOK, applicationElem)
+	if err != nil {
+		return nil, err
+	}
+	return &applicationElem.Application, nil
+}
+
+// CreateApplicationKey adds an application_key to an application of the app_id/app_key authentication mode
+func (c *DeveloperClient) CreateApplicationKey(accountID, applicationID int64, key string) error {
+	values := url.Values{}
+	values.Add("key", key)
+
+	return c.do("POST", fmt.Sprintf("/admin/api/accounts/%d/applications/%d/keys.json", accountID, applicationID), values, http.StatusCreated, nil)
+}
+
+// DeleteApplication deletes the application of the account
+func (c *DeveloperClient) DeleteApplication(accountID, applicationID int64) error {
+	return c.do("DELETE", fmt.Sprintf("/admin/api/accounts/%d/applications/%d.json", accountID, applicationID), nil, http.StatusOK, nil)
+}
+
+// do sends the form encoded values to the endpoint and decodes the JSON response into decodeInto
+func (c *DeveloperClient) do(method, endpoint string, values url.Values, expectCode int, decodeInto interface{}) error {
+	var body *strings.Reader
+	if values != nil {
+		body = strings.NewReader(values.Encode())
+	} else {
+		body = strings.NewReader("")
+	}
+
+	req, err := http.NewRequest(method, c.adminURL.ResolveReference(&url.URL{Path: endpoint}).String(), body)
+	if err != nil {
+		return err
+	}
+	if c.host != "" {
+		req.Host = c.host
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(":"+c.accessToken)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectCode {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return DeveloperAPIError{Code: resp.StatusCode, Body: string(respBody)}
+	}
+
+	if decodeInto == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(decodeInto)
+}
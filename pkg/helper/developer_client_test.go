@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeveloperClient(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		if user, password, ok := r.BasicAuth(); !ok || user != "" || password != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch fmt.Sprintf("%s %s", r.Method, r.URL.Path) {
+		case "POST /admin/api/signup.json":
+			if r.FormValue("org_name") != "Developers" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"account":{"id":3,"state":"approved","org_name":"Developers"}}`)
+		case "POST /admin/api/accounts/3/applications.json":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"application":{"id":7,"state":"live","plan_id":5,"application_id":"%s"}}`, r.FormValue("application_id"))
+		case "GET /admin/api/accounts/3/applications.json":
+			fmt.Fprint(w, `{"applications":[{"application":{"id":7,"state":"live","plan_id":5,"name":"app01"}}]}`)
+		case "GET /admin/api/accounts/3/applications/8.json":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":"Not found"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	c, err := DeveloperClientFromURLString(server.URL, "token", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := c.CreateAccount("Developers", "developer", "developer@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.ID != 3 || account.State != "approved" {
+		t.Errorf("unexpected account: %v", account)
+	}
+
+	application, err := c.CreateApplication(3, "5", "app01", "", map[string]string{"application_id": "abcd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if application.ID != 7 || application.PlanID != 5 || application.ApplicationID != "abcd" {
+		t.Errorf("unexpected application: %v", application)
+	}
+
+	applications, err := c.ListApplications(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applications) != 1 || applications[0].ID != 7 || applications[0].AppName != "app01" {
+		t.Errorf("unexpected applications: %v", applications)
+	}
+
+	_, err = c.ReadApplication(3, 8)
+	if !IsDeveloperNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+
+	err = c.DeleteAccount(3)
+	if err == nil || IsDeveloperNotFound(err) {
+		t.Errorf("expected server error, got: %v", err)
+	}
+}
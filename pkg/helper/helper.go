@@ -2,6 +2,7 @@ package helper
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -16,28 +17,51 @@ import (
 )
 
 // PortaClientFromURLString instantiate porta_client.ThreeScaleClient from admin url string
-func PortaClientFromURLString(adminURLStr, masterAccessToken string) (*client.ThreeScaleClient, error) {
+// A nil tlsConfig verifies the portal certificate with the system CAs
+func PortaClientFromURLString(adminURLStr, masterAccessToken string, tlsConfig *tls.Config) (*client.ThreeScaleClient, error) {
 	adminURL, err := url.Parse(adminURLStr)
 	if err != nil {
 		return nil, err
 	}
-	return PortaClient(adminURL, masterAccessToken)
+	return PortaClient(adminURL, masterAccessToken, tlsConfig)
 }
 
 // PortaClient instantiates porta_client.ThreeScaleClient from admin url object
-func PortaClient(url *url.URL, masterAccessToken string) (*client.ThreeScaleClient, error) {
+// A nil tlsConfig verifies the portal certificate with the system CAs
+func PortaClient(url *url.URL, masterAccessToken string, tlsConfig *tls.Config) (*client.ThreeScaleClient, error) {
 	adminPortal, err := client.NewAdminPortal(url.Scheme, url.Hostname(), PortFromURL(url))
 	if err != nil {
 		return nil, err
 	}
 
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		TLSClientConfig: tlsConfig,
 	}
 
 	return client.NewThreeScale(adminPortal, masterAccessToken, &http.Client{Transport: tr}), nil
 }
 
+// TLSConfig builds the TLS configuration of the porta client.
+// The PEM encoded caBundle certificates are trusted on top of the system CAs
+func TLSConfig(caBundle []byte, insecureSkipVerify bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+
+	if len(caBundle) == 0 {
+		return tlsConfig, nil
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil || rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("no valid PEM certificates found in CA bundle")
+	}
+	tlsConfig.RootCAs = rootCAs
+
+	return tlsConfig, nil
+}
+
 // PortFromURL infers port number if it is not explict
 func PortFromURL(url *url.URL) int {
 	if url.Port() != "" {
@@ -12,6 +12,7 @@ import (
 	capabilities "github.com/3scale/3scale-operator/pkg/apis/capabilities/v1alpha1"
 	"github.com/RHsyseng/operator-utils/pkg/validation"
 	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -19,14 +20,19 @@ import (
 func TestSampleCustomResources(t *testing.T) {
 	root := "../../deploy/crds"
 	crdCrMap := map[string]string{
-		"apps.3scale.net_apimanagers_crd.yaml":          "apps.3scale.net_v1alpha1_apimanager_cr",
-		"capabilities.3scale.net_apis_crd.yaml":         "capabilities.3scale.net_v1alpha1_api_cr",
-		"capabilities.3scale.net_bindings_crd.yaml":     "capabilities.3scale.net_v1alpha1_binding_cr",
-		"capabilities.3scale.net_limits_crd.yaml":       "capabilities.3scale.net_v1alpha1_limit_cr",
-		"capabilities.3scale.net_mappingrules_crd.yaml": "capabilities.3scale.net_v1alpha1_mappingrule_cr",
-		"capabilities.3scale.net_metrics_crd.yaml":      "capabilities.3scale.net_v1alpha1_metric_cr",
-		"capabilities.3scale.net_plans_crd.yaml":        "capabilities.3scale.net_v1alpha1_plan_cr",
-		"capabilities.3scale.net_tenants_crd.yaml":      "capabilities.3scale.net_v1alpha1_tenant_cr",
+		"apps.3scale.net_apimanagers_crd.yaml":               "apps.3scale.net_v1alpha1_apimanager_cr",
+		"apps.3scale.net_apimanagerbackups_crd.yaml":         "apps.3scale.net_v1alpha1_apimanagerbackup_cr",
+		"apps.3scale.net_apimanagerrestores_crd.yaml":        "apps.3scale.net_v1alpha1_apimanagerrestore_cr",
+		"capabilities.3scale.net_apis_crd.yaml":              "capabilities.3scale.net_v1alpha1_api_cr",
+		"capabilities.3scale.net_applications_crd.yaml":      "capabilities.3scale.net_v1alpha1_application_cr",
+		"capabilities.3scale.net_bindings_crd.yaml":          "capabilities.3scale.net_v1alpha1_binding_cr",
+		"capabilities.3scale.net_developeraccounts_crd.yaml": "capabilities.3scale.net_v1alpha1_developeraccount_cr",
+		"capabilities.3scale.net_limits_crd.yaml":            "capabilities.3scale.net_v1alpha1_limit_cr",
+		"capabilities.3scale.net_mappingrules_crd.yaml":      "capabilities.3scale.net_v1alpha1_mappingrule_cr",
+		"capabilities.3scale.net_metrics_crd.yaml":           "capabilities.3scale.net_v1alpha1_metric_cr",
+		"capabilities.3scale.net_plans_crd.yaml":             "capabilities.3scale.net_v1alpha1_plan_cr",
+		"capabilities.3scale.net_policies_crd.yaml":          "capabilities.3scale.net_v1alpha1_policy_cr",
+		"capabilities.3scale.net_tenants_crd.yaml":           "capabilities.3scale.net_v1alpha1_tenant_cr",
 	}
 	for crd, prefix := range crdCrMap {
 		validateCustomResources(t, root, crd, prefix)
@@ -57,19 +63,28 @@ func validateCustomResources(t *testing.T, root string, crd string, prefix strin
 func TestCompleteCRD(t *testing.T) {
 	root := "../../deploy/crds"
 	crdStructMap := map[string]interface{}{
-		"apps.3scale.net_apimanagers_crd.yaml":          &apps.APIManager{},
-		"capabilities.3scale.net_apis_crd.yaml":         &capabilities.API{},
-		"capabilities.3scale.net_bindings_crd.yaml":     &capabilities.Binding{},
-		"capabilities.3scale.net_limits_crd.yaml":       &capabilities.Limit{},
-		"capabilities.3scale.net_mappingrules_crd.yaml": &capabilities.MappingRule{},
-		"capabilities.3scale.net_metrics_crd.yaml":      &capabilities.Metric{},
-		"capabilities.3scale.net_plans_crd.yaml":        &capabilities.Plan{},
-		"capabilities.3scale.net_tenants_crd.yaml":      &capabilities.Tenant{},
+		"apps.3scale.net_apimanagers_crd.yaml":               &apps.APIManager{},
+		"apps.3scale.net_apimanagerbackups_crd.yaml":         &apps.APIManagerBackup{},
+		"apps.3scale.net_apimanagerrestores_crd.yaml":        &apps.APIManagerRestore{},
+		"capabilities.3scale.net_apis_crd.yaml":              &capabilities.API{},
+		"capabilities.3scale.net_applications_crd.yaml":      &capabilities.Application{},
+		"capabilities.3scale.net_bindings_crd.yaml":          &capabilities.Binding{},
+		"capabilities.3scale.net_developeraccounts_crd.yaml": &capabilities.DeveloperAccount{},
+		"capabilities.3scale.net_limits_crd.yaml":            &capabilities.Limit{},
+		"capabilities.3scale.net_mappingrules_crd.yaml":      &capabilities.MappingRule{},
+		"capabilities.3scale.net_metrics_crd.yaml":           &capabilities.Metric{},
+		"capabilities.3scale.net_plans_crd.yaml":             &capabilities.Plan{},
+		"capabilities.3scale.net_policies_crd.yaml":          &capabilities.Policy{},
+		"capabilities.3scale.net_tenants_crd.yaml":           &capabilities.Tenant{},
 	}
 	for crd, obj := range crdStructMap {
 		schema := getSchema(t, fmt.Sprintf("%s/%s", root, crd))
 		missingEntries := schema.GetMissingEntries(obj)
 		for _, missing := range missingEntries {
+			if isDateTimeEntry(t, fmt.Sprintf("%s/%s", root, crd), missing.Path) {
+				// metav1.Time structs are serialized as RFC 3339 strings
+				continue
+			}
 			assert.Fail(t, "Discrepancy between CRD and Struct", "CRD: %s: Missing or incorrect schema validation at %s, expected type %s", crd, missing.Path, missing.Type)
 		}
 	}
@@ -82,3 +97,27 @@ func getSchema(t *testing.T, crd string) validation.Schema {
 	assert.NoError(t, err)
 	return schema
 }
+
+// isDateTimeEntry returns true when the path, or one of its parents, is a
+// date-time string in the schema of the CRD
+func isDateTimeEntry(t *testing.T, crd string, path string) bool {
+	bytes, err := ioutil.ReadFile(crd)
+	assert.NoError(t, err, "Error reading CRD yaml from %v", crd)
+	var crdObj map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal(bytes, &crdObj))
+
+	schema, _, _ := unstructured.NestedMap(crdObj, "spec", "validation", "openAPIV3Schema")
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if schema["type"] == "array" {
+			schema, _, _ = unstructured.NestedMap(schema, "items")
+		}
+		schema, _, _ = unstructured.NestedMap(schema, "properties", name)
+		if schema == nil {
+			return false
+		}
+		if schema["type"] == "string" && schema["format"] == "date-time" {
+			return true
+		}
+	}
+	return false
+}